@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -33,24 +34,72 @@ var (
 		"gatewayclass",
 		"",
 		"The name of the GatewayClass resource. Every NGINX Gateway must have a unique corresponding GatewayClass resource")
+
+	initializeConfig = flag.Bool(
+		"initialize-config",
+		false,
+		"Write the initial NGINX configuration and exit. Used by the init container of the NGINX Kubernetes Gateway Pod")
+
+	service = flag.String(
+		"service",
+		fmt.Sprintf("%s/nginx-gateway", namespace),
+		"The namespaced name of the Service of NGINX in the form NAMESPACE/NAME. The addresses of the Service are reported in the statuses of the Gateway resources")
+
+	gatewayAddresses = flag.StringSlice(
+		"gateway-addresses",
+		nil,
+		"A comma-separated list of the static addresses (IP addresses or hostnames) of NGINX. If set, they are reported in the statuses of the Gateway resources instead of the addresses of the Service")
+
+	eventDebounceWindow = flag.Duration(
+		"event-debounce-window",
+		100*time.Millisecond,
+		"How long the Gateway collects the changes of the resources after the first change before it updates NGINX, so that the changes that come together are applied with one NGINX reload")
+
+	minReloadInterval = flag.Duration(
+		"min-reload-interval",
+		time.Second,
+		"The minimum interval between the NGINX configuration updates, which limits how often NGINX is reloaded. The initial configuration is not delayed")
 )
 
 func main() {
 	flag.Parse()
 
 	logger := zap.New()
-	conf := config.Config{
-		GatewayCtlrName:  *gatewayCtlrName,
-		Logger:           logger,
-		GatewayClassName: *gatewayClassName,
+
+	if *initializeConfig {
+		err := manager.InitializeNginxConfig()
+		if err != nil {
+			logger.Error(err, "Failed to initialize NGINX configuration")
+			os.Exit(1)
+		}
+
+		logger.Info("NGINX configuration was successfully initialized")
+		return
 	}
 
 	MustValidateArguments(
 		flag.CommandLine,
 		GatewayControllerParam(domain, namespace /* FIXME(f5yacobucci) dynamically set */),
 		GatewayClassParam(),
+		ServiceParam(),
+		GatewayAddressesParam(),
+		NonNegativeDurationParam("event-debounce-window"),
+		NonNegativeDurationParam("min-reload-interval"),
 	)
 
+	// the flag is validated, so the error is not possible
+	serviceNsName, _ := ParseNamespacedName(*service)
+
+	conf := config.Config{
+		GatewayCtlrName:     *gatewayCtlrName,
+		Logger:              logger,
+		GatewayClassName:    *gatewayClassName,
+		ServiceNsName:       serviceNsName,
+		GatewayAddresses:    *gatewayAddresses,
+		EventDebounceWindow: *eventDebounceWindow,
+		MinReloadInterval:   *minReloadInterval,
+	}
+
 	logger.Info("Starting NGINX Kubernetes Gateway",
 		"version", version,
 		"commit", commit,
@@ -3,10 +3,12 @@ package main
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 
 	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -94,6 +96,88 @@ func GatewayClassParam() ValidatorContext {
 	}
 }
 
+func ServiceParam() ValidatorContext {
+	name := "service"
+	return ValidatorContext{
+		name,
+		func(flagset *flag.FlagSet) error {
+			param, err := flagset.GetString(name)
+			if err != nil {
+				return err
+			}
+
+			_, err = ParseNamespacedName(param)
+			return err
+		},
+	}
+}
+
+func GatewayAddressesParam() ValidatorContext {
+	name := "gateway-addresses"
+	return ValidatorContext{
+		name,
+		func(flagset *flag.FlagSet) error {
+			params, err := flagset.GetStringSlice(name)
+			if err != nil {
+				return err
+			}
+
+			for _, param := range params {
+				if net.ParseIP(param) != nil {
+					continue
+				}
+
+				// used by Kubernetes to validate hostnames
+				messages := validation.IsDNS1123Subdomain(param)
+				if len(messages) > 0 {
+					msg := strings.Join(messages, "; ")
+					return fmt.Errorf("invalid address %q: must be an IP address or a hostname: %s", param, msg)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// NonNegativeDurationParam validates that the duration flag with the name is not negative.
+func NonNegativeDurationParam(name string) ValidatorContext {
+	return ValidatorContext{
+		name,
+		func(flagset *flag.FlagSet) error {
+			param, err := flagset.GetDuration(name)
+			if err != nil {
+				return err
+			}
+
+			if param < 0 {
+				return fmt.Errorf("invalid duration %s: must not be negative", param)
+			}
+
+			return nil
+		},
+	}
+}
+
+// ParseNamespacedName parses a namespaced name of the form NAMESPACE/NAME.
+func ParseNamespacedName(value string) (types.NamespacedName, error) {
+	fields := strings.Split(value, "/")
+	if len(fields) != 2 {
+		return types.NamespacedName{}, errors.New("unsupported format, must be form NAMESPACE/NAME")
+	}
+
+	for _, f := range fields {
+		// used by Kubernetes to validate namespaces and the names of Services
+		messages := validation.IsDNS1123Label(f)
+		if len(messages) > 0 {
+			msg := strings.Join(messages, "; ")
+			return types.NamespacedName{}, fmt.Errorf("invalid format: %s", msg)
+		}
+	}
+
+	return types.NamespacedName{Namespace: fields[0], Name: fields[1]}, nil
+}
+
 func ValidateArguments(flagset *flag.FlagSet, validators ...ValidatorContext) []string {
 	var msgs []string
 	for _, v := range validators {
@@ -2,6 +2,7 @@ package main_test
 
 import (
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -268,5 +269,130 @@ var _ = Describe("Main", func() {
 				tester(t)
 			}) // should fail with invalid name"
 		}) // gatewayclass validation
+
+		Describe("service validation", func() {
+			prepareTestCase := func(value string, expError bool) testCase {
+				return testCase{
+					Flag:             "service",
+					Value:            value,
+					ValidatorContext: ServiceParam(),
+					ExpError:         expError,
+				}
+			}
+
+			BeforeEach(func() {
+				mockFlags = flag.NewFlagSet("mock", flag.PanicOnError)
+				_ = mockFlags.String("service", "", "mock service")
+				err := mockFlags.Parse([]string{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			AfterEach(func() {
+				mockFlags = nil
+			})
+
+			It("should succeed on valid namespaced name", func() {
+				t := prepareTestCase(
+					"nginx-gateway/nginx-gateway",
+					expectSuccess,
+				)
+				tester(t)
+			}) // should succeed on valid namespaced name
+
+			It("should fail with invalid namespaced name", func() {
+				table := []testCase{
+					prepareTestCase(
+						// no namespace
+						"nginx-gateway",
+						expectError,
+					),
+					prepareTestCase(
+						// too many path elements
+						"nginx-gateway/nginx-gateway/broken",
+						expectError,
+					),
+					prepareTestCase(
+						// bad namespace
+						"/nginx-gateway",
+						expectError,
+					),
+					prepareTestCase(
+						// bad name
+						"nginx-gateway/$nginx",
+						expectError,
+					),
+				}
+
+				runner(table)
+			}) // should fail with invalid namespaced name
+		}) // service validation
+
+		Describe("gateway-addresses validation", func() {
+			prepareTestCase := func(value string, expError bool) testCase {
+				return testCase{
+					Flag:             "gateway-addresses",
+					Value:            value,
+					ValidatorContext: GatewayAddressesParam(),
+					ExpError:         expError,
+				}
+			}
+
+			BeforeEach(func() {
+				mockFlags = flag.NewFlagSet("mock", flag.PanicOnError)
+				_ = mockFlags.StringSlice("gateway-addresses", nil, "mock gateway-addresses")
+				err := mockFlags.Parse([]string{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			AfterEach(func() {
+				mockFlags = nil
+			})
+
+			It("should succeed on valid addresses", func() {
+				t := prepareTestCase(
+					"10.0.0.1,2001:db8::1,gateway.example.com",
+					expectSuccess,
+				)
+				tester(t)
+			}) // should succeed on valid addresses
+
+			It("should fail with invalid address", func() {
+				t := prepareTestCase(
+					"10.0.0.1,$gateway",
+					expectError)
+				tester(t)
+			}) // should fail with invalid address
+		}) // gateway-addresses validation
+
+		Describe("duration validation", func() {
+			prepareTestCase := func(value string, expError bool) testCase {
+				return testCase{
+					Flag:             "min-reload-interval",
+					Value:            value,
+					ValidatorContext: NonNegativeDurationParam("min-reload-interval"),
+					ExpError:         expError,
+				}
+			}
+
+			BeforeEach(func() {
+				mockFlags = flag.NewFlagSet("mock", flag.PanicOnError)
+				_ = mockFlags.Duration("min-reload-interval", time.Second, "mock min-reload-interval")
+				err := mockFlags.Parse([]string{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			AfterEach(func() {
+				mockFlags = nil
+			})
+
+			It("should succeed on non-negative durations", func() {
+				tester(prepareTestCase("0s", expectSuccess))
+				tester(prepareTestCase("500ms", expectSuccess))
+			}) // should succeed on non-negative durations
+
+			It("should fail with negative duration", func() {
+				t := prepareTestCase(
+					"-1s",
+					expectError)
+				tester(t)
+			}) // should fail with negative duration
+		}) // duration validation
 	}) // CLI argument validation
 }) // end Main
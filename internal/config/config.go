@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -13,4 +15,15 @@ type Config struct {
 	GatewayNsName types.NamespacedName
 	// GatewayClassName is the name of the GatewayClass resource that the Gateway will use.
 	GatewayClassName string
+	// ServiceNsName is the namespaced name of the Service of the NGINX data plane. The Gateway reports the addresses
+	// of the Service in the statuses of the Gateway resources.
+	ServiceNsName types.NamespacedName
+	// GatewayAddresses are the static addresses (IP addresses or hostnames) of the NGINX data plane. If set,
+	// the Gateway reports them instead of the addresses of the Service.
+	GatewayAddresses []string
+	// EventDebounceWindow is how long the events are collected into a batch after the first event of the batch comes.
+	EventDebounceWindow time.Duration
+	// MinReloadInterval is the minimum interval between the starts of the handling of two batches of events,
+	// which limits how often NGINX is reloaded.
+	MinReloadInterval time.Duration
 }
@@ -5,9 +5,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Event is an event about a change of a resource or of the validity of a certificate.
+// Only UpsertEvent, DeleteEvent and CertificateValidityChangeEvent implement it.
+type Event interface {
+	event()
+}
+
 // EventBatch is a batch of events to be handled at once.
-// FIXME(pleshakov): think about how to avoid using an interface{} here
-type EventBatch []interface{}
+type EventBatch []Event
 
 // UpsertEvent represents upserting a resource.
 type UpsertEvent struct {
@@ -22,3 +27,13 @@ type DeleteEvent struct {
 	// Type is the resource type. For example, if the event is for *v1beta1.HTTPRoute, pass &v1beta1.HTTPRoute{} as Type.
 	Type client.Object
 }
+
+// CertificateValidityChangeEvent represents a change of the validity of a certificate referenced by a Gateway:
+// a not yet valid certificate becomes valid or a valid certificate expires.
+type CertificateValidityChangeEvent struct{}
+
+func (*UpsertEvent) event() {}
+
+func (*DeleteEvent) event() {}
+
+func (*CertificateValidityChangeEvent) event() {}
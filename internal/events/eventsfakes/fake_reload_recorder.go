@@ -0,0 +1,184 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package eventsfakes
+
+import (
+	"sync"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+)
+
+type FakeReloadRecorder struct {
+	RecordConfigHashStub        func(string)
+	recordConfigHashMutex       sync.RWMutex
+	recordConfigHashArgsForCall []struct {
+		arg1 string
+	}
+	RecordReloadStub        func(bool)
+	recordReloadMutex       sync.RWMutex
+	recordReloadArgsForCall []struct {
+		arg1 bool
+	}
+	RecordRollbackStub        func(bool)
+	recordRollbackMutex       sync.RWMutex
+	recordRollbackArgsForCall []struct {
+		arg1 bool
+	}
+	RecordUnverifiedReloadStub        func()
+	recordUnverifiedReloadMutex       sync.RWMutex
+	recordUnverifiedReloadArgsForCall []struct {
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReloadRecorder) RecordConfigHash(arg1 string) {
+	fake.recordConfigHashMutex.Lock()
+	fake.recordConfigHashArgsForCall = append(fake.recordConfigHashArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RecordConfigHashStub
+	fake.recordInvocation("RecordConfigHash", []interface{}{arg1})
+	fake.recordConfigHashMutex.Unlock()
+	if stub != nil {
+		fake.RecordConfigHashStub(arg1)
+	}
+}
+
+func (fake *FakeReloadRecorder) RecordConfigHashCallCount() int {
+	fake.recordConfigHashMutex.RLock()
+	defer fake.recordConfigHashMutex.RUnlock()
+	return len(fake.recordConfigHashArgsForCall)
+}
+
+func (fake *FakeReloadRecorder) RecordConfigHashCalls(stub func(string)) {
+	fake.recordConfigHashMutex.Lock()
+	defer fake.recordConfigHashMutex.Unlock()
+	fake.RecordConfigHashStub = stub
+}
+
+func (fake *FakeReloadRecorder) RecordConfigHashArgsForCall(i int) string {
+	fake.recordConfigHashMutex.RLock()
+	defer fake.recordConfigHashMutex.RUnlock()
+	argsForCall := fake.recordConfigHashArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReloadRecorder) RecordReload(arg1 bool) {
+	fake.recordReloadMutex.Lock()
+	fake.recordReloadArgsForCall = append(fake.recordReloadArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.RecordReloadStub
+	fake.recordInvocation("RecordReload", []interface{}{arg1})
+	fake.recordReloadMutex.Unlock()
+	if stub != nil {
+		fake.RecordReloadStub(arg1)
+	}
+}
+
+func (fake *FakeReloadRecorder) RecordReloadCallCount() int {
+	fake.recordReloadMutex.RLock()
+	defer fake.recordReloadMutex.RUnlock()
+	return len(fake.recordReloadArgsForCall)
+}
+
+func (fake *FakeReloadRecorder) RecordReloadCalls(stub func(bool)) {
+	fake.recordReloadMutex.Lock()
+	defer fake.recordReloadMutex.Unlock()
+	fake.RecordReloadStub = stub
+}
+
+func (fake *FakeReloadRecorder) RecordReloadArgsForCall(i int) bool {
+	fake.recordReloadMutex.RLock()
+	defer fake.recordReloadMutex.RUnlock()
+	argsForCall := fake.recordReloadArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReloadRecorder) RecordRollback(arg1 bool) {
+	fake.recordRollbackMutex.Lock()
+	fake.recordRollbackArgsForCall = append(fake.recordRollbackArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.RecordRollbackStub
+	fake.recordInvocation("RecordRollback", []interface{}{arg1})
+	fake.recordRollbackMutex.Unlock()
+	if stub != nil {
+		fake.RecordRollbackStub(arg1)
+	}
+}
+
+func (fake *FakeReloadRecorder) RecordRollbackCallCount() int {
+	fake.recordRollbackMutex.RLock()
+	defer fake.recordRollbackMutex.RUnlock()
+	return len(fake.recordRollbackArgsForCall)
+}
+
+func (fake *FakeReloadRecorder) RecordRollbackCalls(stub func(bool)) {
+	fake.recordRollbackMutex.Lock()
+	defer fake.recordRollbackMutex.Unlock()
+	fake.RecordRollbackStub = stub
+}
+
+func (fake *FakeReloadRecorder) RecordRollbackArgsForCall(i int) bool {
+	fake.recordRollbackMutex.RLock()
+	defer fake.recordRollbackMutex.RUnlock()
+	argsForCall := fake.recordRollbackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReloadRecorder) RecordUnverifiedReload() {
+	fake.recordUnverifiedReloadMutex.Lock()
+	fake.recordUnverifiedReloadArgsForCall = append(fake.recordUnverifiedReloadArgsForCall, struct {
+	}{})
+	stub := fake.RecordUnverifiedReloadStub
+	fake.recordInvocation("RecordUnverifiedReload", []interface{}{})
+	fake.recordUnverifiedReloadMutex.Unlock()
+	if stub != nil {
+		fake.RecordUnverifiedReloadStub()
+	}
+}
+
+func (fake *FakeReloadRecorder) RecordUnverifiedReloadCallCount() int {
+	fake.recordUnverifiedReloadMutex.RLock()
+	defer fake.recordUnverifiedReloadMutex.RUnlock()
+	return len(fake.recordUnverifiedReloadArgsForCall)
+}
+
+func (fake *FakeReloadRecorder) RecordUnverifiedReloadCalls(stub func()) {
+	fake.recordUnverifiedReloadMutex.Lock()
+	defer fake.recordUnverifiedReloadMutex.Unlock()
+	fake.RecordUnverifiedReloadStub = stub
+}
+
+func (fake *FakeReloadRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordConfigHashMutex.RLock()
+	defer fake.recordConfigHashMutex.RUnlock()
+	fake.recordReloadMutex.RLock()
+	defer fake.recordReloadMutex.RUnlock()
+	fake.recordRollbackMutex.RLock()
+	defer fake.recordRollbackMutex.RUnlock()
+	fake.recordUnverifiedReloadMutex.RLock()
+	defer fake.recordUnverifiedReloadMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeReloadRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ events.ReloadRecorder = new(FakeReloadRecorder)
@@ -2,10 +2,17 @@ package events
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
@@ -13,8 +20,12 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
+// defaultNginxReadyTimeout is the default timeout for waiting for NGINX to be ready before the initial configuration.
+const defaultNginxReadyTimeout = 30 * time.Second
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . EventHandler
 
 // EventHandler handle events.
@@ -24,6 +35,20 @@ type EventHandler interface {
 	HandleEventBatch(ctx context.Context, batch EventBatch)
 }
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ReloadRecorder
+
+// ReloadRecorder records the outcomes of the NGINX reloads.
+type ReloadRecorder interface {
+	// RecordReload records the outcome of an NGINX reload.
+	RecordReload(success bool)
+	// RecordUnverifiedReload records an NGINX reload when it is unknown whether NGINX applied the configuration.
+	RecordUnverifiedReload()
+	// RecordRollback records the outcome of a rollback to the previous NGINX configuration after a failed reload.
+	RecordRollback(success bool)
+	// RecordConfigHash records the hash of the configuration used by NGINX after a successful reload.
+	RecordConfigHash(hash string)
+}
+
 // EventHandlerConfig holds configuration parameters for EventHandlerImpl.
 type EventHandlerConfig struct {
 	// Processor is the state ChangeProcessor.
@@ -44,6 +69,19 @@ type EventHandlerConfig struct {
 	NginxRuntimeMgr runtime.Manager
 	// StatusUpdater updates statuses on Kubernetes resources.
 	StatusUpdater status.Updater
+	// ReloadRecorder records the outcomes of the NGINX reloads.
+	ReloadRecorder ReloadRecorder
+	// InitialConfigBackoff is the backoff for retrying the initial configuration of NGINX when it fails.
+	InitialConfigBackoff wait.Backoff
+	// NginxReadyTimeout is how long the EventHandler waits for NGINX to be ready before the initial configuration.
+	// If NGINX isn't ready in time, the initial configuration is attempted again on the next event batch.
+	// If zero, defaultNginxReadyTimeout is used.
+	NginxReadyTimeout time.Duration
+	// EventCh is the event channel of the EventLoop. The EventHandler sends a CertificateValidityChangeEvent to it
+	// when the validity of a referenced certificate changes.
+	EventCh chan<- Event
+	// Clock is used to schedule the CertificateValidityChangeEvent. If nil, the real clock is used.
+	Clock Clock
 }
 
 // EventHandlerImpl implements EventHandler.
@@ -52,10 +90,23 @@ type EventHandlerConfig struct {
 // (2) Keeping the statuses of the Gateway API resources updated.
 type EventHandlerImpl struct {
 	cfg EventHandlerConfig
+	// configHash is the hash of the configuration used by NGINX. Empty if NGINX hasn't been configured yet.
+	configHash string
+	// nginxConfigured shows whether NGINX has been successfully configured at least once.
+	nginxConfigured bool
+	// cancelValidityChange cancels the scheduled CertificateValidityChangeEvent. nil if no event is scheduled.
+	cancelValidityChange context.CancelFunc
 }
 
 // NewEventHandlerImpl creates a new EventHandlerImpl.
 func NewEventHandlerImpl(cfg EventHandlerConfig) *EventHandlerImpl {
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
+	if cfg.NginxReadyTimeout == 0 {
+		cfg.NginxReadyTimeout = defaultNginxReadyTimeout
+	}
+
 	return &EventHandlerImpl{
 		cfg: cfg,
 	}
@@ -68,6 +119,8 @@ func (h *EventHandlerImpl) HandleEventBatch(ctx context.Context, batch EventBatc
 			h.propagateUpsert(e)
 		case *DeleteEvent:
 			h.propagateDelete(e)
+		case *CertificateValidityChangeEvent:
+			h.cfg.Processor.CaptureCertificateValidityChange()
 		default:
 			panic(fmt.Errorf("unknown event type %T", e))
 		}
@@ -79,35 +132,123 @@ func (h *EventHandlerImpl) HandleEventBatch(ctx context.Context, batch EventBatc
 		return
 	}
 
-	err := h.updateNginx(ctx, conf)
-	if err != nil {
+	// The next validity change is reset when the requested secrets are written, so it must be scheduled before.
+	h.scheduleCertificateValidityChange(ctx)
+
+	// Write the requested secrets. The secrets that are no longer requested stay on disk until NGINX is reloaded,
+	// so that NGINX never runs with missing files.
+	// The requests are reset once the secrets are written, so the secrets are written only once per batch,
+	// even if the initial configuration is retried.
+	err := h.cfg.SecretMemoryManager.WriteAllRequestedSecrets()
+	if err == nil {
+		if h.nginxConfigured {
+			err = h.updateNginx(ctx, conf)
+		} else {
+			err = h.configureNginx(ctx, conf)
+		}
+	}
+
+	switch {
+	case errors.Is(err, runtime.ErrReloadUnverified):
+		h.cfg.Logger.Info("NGINX configuration was updated, but it is unknown whether NGINX applied it",
+			"error", err.Error())
+	case err != nil:
 		h.cfg.Logger.Error(err, "Failed to update NGINX configuration")
-	} else {
+	default:
 		h.cfg.Logger.Info("NGINX configuration was successfully updated")
 	}
 
 	h.cfg.StatusUpdater.Update(ctx, statuses)
 }
 
-func (h *EventHandlerImpl) updateNginx(ctx context.Context, conf state.Configuration) error {
-	// Write all secrets (nuke and pave).
-	// This will remove all secrets in the secrets directory before writing the requested secrets.
-	// FIXME(kate-osborn): We may want to rethink this approach in the future and write and remove secrets individually.
-	err := h.cfg.SecretMemoryManager.WriteAllRequestedSecrets()
+// scheduleCertificateValidityChange schedules sending a CertificateValidityChangeEvent when the validity of a certificate
+// referenced by the last built configuration changes, so that the configuration is rebuilt even if no resources change.
+// The previously scheduled event is canceled, because the certificates are requested again on every rebuild.
+func (h *EventHandlerImpl) scheduleCertificateValidityChange(ctx context.Context) {
+	if h.cancelValidityChange != nil {
+		h.cancelValidityChange()
+		h.cancelValidityChange = nil
+	}
+
+	next := h.cfg.SecretMemoryManager.NextCertificateValidityChange()
+	if next.IsZero() || h.cfg.EventCh == nil {
+		return
+	}
+
+	h.cfg.Logger.Info("Scheduling the revalidation of the certificates", "time", next)
+
+	timerCtx, cancel := context.WithCancel(ctx)
+	h.cancelValidityChange = cancel
+
+	timer := h.cfg.Clock.After(next.Sub(h.cfg.Clock.Now()))
+
+	go func() {
+		select {
+		case <-timerCtx.Done():
+			return
+		case <-timer:
+		}
+
+		select {
+		case <-timerCtx.Done():
+		case h.cfg.EventCh <- &CertificateValidityChangeEvent{}:
+		}
+	}()
+}
+
+// configureNginx configures NGINX for the first time. It waits until NGINX is running and retries the failed
+// updates with backoff, so that the configuration is applied without waiting for the next event.
+// The wait is bounded by NginxReadyTimeout, so that the event loop isn't blocked if NGINX never starts.
+func (h *EventHandlerImpl) configureNginx(ctx context.Context, conf state.Configuration) error {
+	readyCtx, cancel := context.WithTimeout(ctx, h.cfg.NginxReadyTimeout)
+	defer cancel()
+
+	err := h.cfg.NginxRuntimeMgr.WaitForReady(readyCtx)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to wait for NGINX to be ready: %w", err)
 	}
 
-	cfg, warnings := h.cfg.Generator.Generate(conf)
+	backoff := h.cfg.InitialConfigBackoff
+	// at least one attempt is required
+	if backoff.Steps < 1 {
+		backoff.Steps = 1
+	}
+
+	var updateErr error
+
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		updateErr = h.updateNginx(ctx, conf)
+		// retrying doesn't help if it is unknown whether NGINX applied the configuration
+		if updateErr != nil && !errors.Is(updateErr, runtime.ErrReloadUnverified) {
+			h.cfg.Logger.Error(updateErr, "Failed to apply the initial NGINX configuration")
+			return false, nil
+		}
 
-	// For now, we keep all http servers in one config
-	// We might rethink that. For example, we can write each server to its file
-	// or group servers in some way.
-	err = h.cfg.NginxFileMgr.WriteHTTPServersConfig("http-servers", cfg)
+		return true, nil
+	})
 	if err != nil {
+		if updateErr != nil {
+			return updateErr
+		}
 		return err
 	}
 
+	h.nginxConfigured = true
+
+	// the error is either nil or ErrReloadUnverified
+	return updateErr
+}
+
+// updateNginx updates NGINX configuration. The secrets of the configuration must be written before.
+func (h *EventHandlerImpl) updateNginx(ctx context.Context, conf state.Configuration) error {
+	mainCfg := h.cfg.Generator.GenerateMain(conf)
+
+	// Each server has its own config, so that only the configs of the changed servers are rewritten.
+	cfgs, warnings := h.cfg.Generator.Generate(conf)
+
+	streamCfg, streamWarnings := h.cfg.Generator.GenerateStream(conf)
+	warnings.Add(streamWarnings)
+
 	for obj, objWarnings := range warnings {
 		for _, w := range objWarnings {
 			// FIXME(pleshakov): report warnings via Object status
@@ -119,7 +260,124 @@ func (h *EventHandlerImpl) updateNginx(ctx context.Context, conf state.Configura
 		}
 	}
 
-	return h.cfg.NginxRuntimeMgr.Reload(ctx)
+	// The configs reference the secret files by the paths that include the hashes of their contents,
+	// so the hash also changes when the contents of the referenced secrets change.
+	hash := hashConfigs(mainCfg, cfgs, streamCfg)
+	if hash == h.configHash {
+		h.cfg.Logger.Info("NGINX configuration is unchanged, skipping reload", "hash", hash)
+		return nil
+	}
+
+	err := h.cfg.NginxFileMgr.WriteMainConfig(mainCfg)
+	if err != nil {
+		return err
+	}
+
+	// The config with the hash is not part of the hashed configs, so the hash doesn't depend on itself.
+	httpCfgs := make(map[string][]byte, len(cfgs)+1)
+	for name, cfg := range cfgs {
+		httpCfgs[name] = cfg
+	}
+	httpCfgs[config.ConfigHashConfigName] = h.cfg.Generator.GenerateConfigHash(hash)
+
+	err = h.cfg.NginxFileMgr.WriteHTTPConfigs(httpCfgs)
+	if err != nil {
+		return err
+	}
+
+	err = h.cfg.NginxFileMgr.WriteStreamServersConfig("stream-servers", streamCfg)
+	if err != nil {
+		return err
+	}
+
+	// NGINX keeps running with the previous configuration if the new one is invalid.
+	err = h.cfg.NginxRuntimeMgr.Validate(ctx, file.StagedMainConfigPath)
+	if err != nil {
+		return err
+	}
+
+	err = h.cfg.NginxFileMgr.ApplyStagedConfigs()
+	if err != nil {
+		// NGINX isn't reloaded, so it still uses the previous configuration. However, some of its configs might be
+		// already replaced, so they are restored in case NGINX is reloaded or restarted before the next update.
+		if restoreErr := h.cfg.NginxFileMgr.RestorePreviousConfigs(); restoreErr != nil {
+			h.cfg.Logger.Error(restoreErr, "Failed to restore the previous NGINX configuration")
+		}
+		return err
+	}
+
+	err = h.cfg.NginxRuntimeMgr.Reload(ctx)
+
+	unverified := errors.Is(err, runtime.ErrReloadUnverified)
+	if unverified {
+		h.cfg.ReloadRecorder.RecordUnverifiedReload()
+	} else {
+		h.cfg.ReloadRecorder.RecordReload(err == nil)
+	}
+
+	if err != nil && !unverified {
+		h.rollback(ctx)
+		return err
+	}
+
+	// NGINX most likely applied the configuration if the reload is unverified, so the configuration is kept.
+	h.configHash = hash
+	h.cfg.ReloadRecorder.RecordConfigHash(hash)
+
+	if unverified {
+		// the secrets of the previous configuration are kept in case NGINX still uses it
+		return err
+	}
+
+	return h.cfg.SecretMemoryManager.RemoveUnusedSecrets()
+}
+
+// hashConfigs returns the hash of the main, the http and the stream configs.
+func hashConfigs(mainCfg []byte, cfgs map[string][]byte, streamCfg []byte) string {
+	names := make([]string, 0, len(cfgs))
+	for name := range cfgs {
+		names = append(names, name)
+	}
+
+	// sort the names, because the order of map iteration is random
+	sort.Strings(names)
+
+	h := sha256.New()
+
+	// each config is preceded by its name and length, so that different sets of configs don't produce
+	// the same input for the hash
+	write := func(name string, cfg []byte) {
+		fmt.Fprintf(h, "%s:%d:", name, len(cfg))
+		h.Write(cfg)
+	}
+
+	write("main", mainCfg)
+	for _, name := range names {
+		write("http/"+name, cfgs[name])
+	}
+	write("stream", streamCfg)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// rollback restores the previous NGINX configuration after a failed reload. The previous configuration is the
+// last known good one, because it was either successfully reloaded or restored.
+// The secrets of the previous configuration are still on disk, because the unused secrets are removed only after
+// a successful reload.
+func (h *EventHandlerImpl) rollback(ctx context.Context) {
+	err := h.cfg.NginxFileMgr.RestorePreviousConfigs()
+	if err == nil {
+		err = h.cfg.NginxRuntimeMgr.Reload(ctx)
+	}
+
+	h.cfg.ReloadRecorder.RecordRollback(err == nil)
+
+	if err != nil {
+		h.cfg.Logger.Error(err, "Failed to roll back to the previous NGINX configuration")
+		return
+	}
+
+	h.cfg.Logger.Info("Rolled back to the previous NGINX configuration")
 }
 
 func (h *EventHandlerImpl) propagateUpsert(e *UpsertEvent) {
@@ -130,12 +388,26 @@ func (h *EventHandlerImpl) propagateUpsert(e *UpsertEvent) {
 		h.cfg.Processor.CaptureUpsertChange(r)
 	case *v1beta1.HTTPRoute:
 		h.cfg.Processor.CaptureUpsertChange(r)
+	case *v1alpha2.GRPCRoute:
+		h.cfg.Processor.CaptureUpsertChange(r)
+	case *v1alpha2.TLSRoute:
+		h.cfg.Processor.CaptureUpsertChange(r)
+	case *v1alpha2.TCPRoute:
+		h.cfg.Processor.CaptureUpsertChange(r)
+	case *v1alpha2.UDPRoute:
+		h.cfg.Processor.CaptureUpsertChange(r)
+	case *nginxgwv1alpha1.GatewayConfig:
+		h.cfg.Processor.CaptureUpsertChange(r)
 	case *apiv1.Service:
-		// FIXME(pleshakov): make sure the affected hosts are updated
+		// the ServiceStore must be up-to-date before the Processor rebuilds the configuration
 		h.cfg.ServiceStore.Upsert(r)
+		// the Processor tracks the Service of the data plane to report its addresses and the Services referenced
+		// by the backends to update the affected upstreams
+		h.cfg.Processor.CaptureUpsertChange(r)
 	case *apiv1.Secret:
-		// FIXME(kate-osborn): need to handle certificate rotation
+		// the SecretStore must be up-to-date before the Processor rebuilds the configuration
 		h.cfg.SecretStore.Upsert(r)
+		h.cfg.Processor.CaptureUpsertChange(r)
 	default:
 		panic(fmt.Errorf("unknown resource type %T", e.Resource))
 	}
@@ -149,12 +421,22 @@ func (h *EventHandlerImpl) propagateDelete(e *DeleteEvent) {
 		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	case *v1beta1.HTTPRoute:
 		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.GRPCRoute:
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.TLSRoute:
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.TCPRoute:
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.UDPRoute:
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *nginxgwv1alpha1.GatewayConfig:
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	case *apiv1.Service:
-		// FIXME(pleshakov): make sure the affected hosts are updated
 		h.cfg.ServiceStore.Delete(e.NamespacedName)
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	case *apiv1.Secret:
-		// FIXME(kate-osborn): make sure that affected servers are updated
 		h.cfg.SecretStore.Delete(e.NamespacedName)
+		h.cfg.Processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	default:
 		panic(fmt.Errorf("unknown resource type %T", e.Type))
 	}
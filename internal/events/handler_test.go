@@ -2,7 +2,18 @@ package events_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,17 +22,23 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events/eventsfakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config/configfakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/file"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/file/filefakes"
+	ngxruntime "github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime/runtimefakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status/statusfakes"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 type unsupportedResource struct {
@@ -47,20 +64,59 @@ var _ = Describe("EventHandler", func() {
 		fakeNginxFimeMgr        *filefakes.FakeManager
 		fakeNginxRuntimeMgr     *runtimefakes.FakeManager
 		fakeStatusUpdater       *statusfakes.FakeUpdater
+		fakeReloadRecorder      *eventsfakes.FakeReloadRecorder
 	)
 
-	expectReconfig := func(expectedConf state.Configuration, expectedCfg []byte, expectedStatuses state.Statuses) {
+	expectReconfig := func(
+		expectedConf state.Configuration,
+		expectedMainCfg []byte,
+		expectedCfgs map[string][]byte,
+		expectedStreamCfg []byte,
+		expectedStatuses state.Statuses,
+	) {
 		Expect(fakeProcessor.ProcessCallCount()).Should(Equal(1))
 
+		Expect(fakeGenerator.GenerateMainCallCount()).Should(Equal(1))
+		Expect(fakeGenerator.GenerateMainArgsForCall(0)).Should(Equal(expectedConf))
+
+		Expect(fakeNginxFimeMgr.WriteMainConfigCallCount()).Should(Equal(1))
+		Expect(fakeNginxFimeMgr.WriteMainConfigArgsForCall(0)).Should(Equal(expectedMainCfg))
+
 		Expect(fakeGenerator.GenerateCallCount()).Should(Equal(1))
 		Expect(fakeGenerator.GenerateArgsForCall(0)).Should(Equal(expectedConf))
 
-		Expect(fakeNginxFimeMgr.WriteHTTPServersConfigCallCount()).Should(Equal(1))
-		name, cfg := fakeNginxFimeMgr.WriteHTTPServersConfigArgsForCall(0)
-		Expect(name).Should(Equal("http-servers"))
-		Expect(cfg).Should(Equal(expectedCfg))
+		Expect(fakeGenerator.GenerateConfigHashCallCount()).Should(Equal(1))
+
+		Expect(fakeNginxFimeMgr.WriteHTTPConfigsCallCount()).Should(Equal(1))
+		cfgs := fakeNginxFimeMgr.WriteHTTPConfigsArgsForCall(0)
+		Expect(cfgs).Should(HaveLen(len(expectedCfgs) + 1))
+		for name, cfg := range expectedCfgs {
+			Expect(cfgs).Should(HaveKeyWithValue(name, cfg))
+		}
+		Expect(cfgs).Should(HaveKey(config.ConfigHashConfigName))
+
+		Expect(fakeGenerator.GenerateStreamCallCount()).Should(Equal(1))
+		Expect(fakeGenerator.GenerateStreamArgsForCall(0)).Should(Equal(expectedConf))
+
+		Expect(fakeNginxFimeMgr.WriteStreamServersConfigCallCount()).Should(Equal(1))
+		name, streamCfg := fakeNginxFimeMgr.WriteStreamServersConfigArgsForCall(0)
+		Expect(name).Should(Equal("stream-servers"))
+		Expect(streamCfg).Should(Equal(expectedStreamCfg))
 
+		Expect(fakeSecretMemoryManager.WriteAllRequestedSecretsCallCount()).Should(Equal(1))
+
+		Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(1))
+		Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(1))
+		_, mainConfigPath := fakeNginxRuntimeMgr.ValidateArgsForCall(0)
+		Expect(mainConfigPath).Should(Equal(file.StagedMainConfigPath))
+
+		Expect(fakeNginxFimeMgr.ApplyStagedConfigsCallCount()).Should(Equal(1))
 		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordReloadCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordReloadArgsForCall(0)).Should(BeTrue())
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(0))
+		Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(1))
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(1))
 
 		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
 		_, statuses := fakeStatusUpdater.UpdateArgsForCall(0)
@@ -76,6 +132,7 @@ var _ = Describe("EventHandler", func() {
 		fakeNginxFimeMgr = &filefakes.FakeManager{}
 		fakeNginxRuntimeMgr = &runtimefakes.FakeManager{}
 		fakeStatusUpdater = &statusfakes.FakeUpdater{}
+		fakeReloadRecorder = &eventsfakes.FakeReloadRecorder{}
 
 		handler = events.NewEventHandlerImpl(events.EventHandlerConfig{
 			Processor:           fakeProcessor,
@@ -87,21 +144,26 @@ var _ = Describe("EventHandler", func() {
 			NginxFileMgr:        fakeNginxFimeMgr,
 			NginxRuntimeMgr:     fakeNginxRuntimeMgr,
 			StatusUpdater:       fakeStatusUpdater,
+			ReloadRecorder:      fakeReloadRecorder,
 		})
 	})
 
 	Describe("Process the Gateway API resources events", func() {
 		DescribeTable("A batch with one event",
-			func(e interface{}) {
+			func(e events.Event) {
 				fakeConf := state.Configuration{}
 				fakeStatuses := state.Statuses{}
 				changed := true
 				fakeProcessor.ProcessReturns(changed, fakeConf, fakeStatuses)
 
-				fakeCfg := []byte("fake")
-				fakeGenerator.GenerateReturns(fakeCfg, config.Warnings{})
+				fakeMainCfg := []byte("fake main")
+				fakeGenerator.GenerateMainReturns(fakeMainCfg)
+				fakeCfgs := map[string][]byte{"fake": []byte("fake")}
+				fakeGenerator.GenerateReturns(fakeCfgs, config.Warnings{})
+				fakeStreamCfg := []byte("fake stream")
+				fakeGenerator.GenerateStreamReturns(fakeStreamCfg, config.Warnings{})
 
-				batch := []interface{}{e}
+				batch := events.EventBatch{e}
 
 				handler.HandleEventBatch(context.TODO(), batch)
 
@@ -120,22 +182,381 @@ var _ = Describe("EventHandler", func() {
 				}
 
 				// Check that a reconfig happened
-				expectReconfig(fakeConf, fakeCfg, fakeStatuses)
+				expectReconfig(fakeConf, fakeMainCfg, fakeCfgs, fakeStreamCfg, fakeStatuses)
 			},
 			Entry("HTTPRoute upsert", &events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}),
+			Entry("GRPCRoute upsert", &events.UpsertEvent{Resource: &v1alpha2.GRPCRoute{}}),
+			Entry("TLSRoute upsert", &events.UpsertEvent{Resource: &v1alpha2.TLSRoute{}}),
+			Entry("TCPRoute upsert", &events.UpsertEvent{Resource: &v1alpha2.TCPRoute{}}),
+			Entry("UDPRoute upsert", &events.UpsertEvent{Resource: &v1alpha2.UDPRoute{}}),
 			Entry("Gateway upsert", &events.UpsertEvent{Resource: &v1beta1.Gateway{}}),
 			Entry("GatewayClass upsert", &events.UpsertEvent{Resource: &v1beta1.GatewayClass{}}),
+			Entry("GatewayConfig upsert", &events.UpsertEvent{Resource: &nginxgwv1alpha1.GatewayConfig{}}),
 			Entry("HTTPRoute delete", &events.DeleteEvent{Type: &v1beta1.HTTPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("GRPCRoute delete", &events.DeleteEvent{Type: &v1alpha2.GRPCRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "grpc-route"}}),
+			Entry("TLSRoute delete", &events.DeleteEvent{Type: &v1alpha2.TLSRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "tls-route"}}),
+			Entry("TCPRoute delete", &events.DeleteEvent{Type: &v1alpha2.TCPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "tcp-route"}}),
+			Entry("UDPRoute delete", &events.DeleteEvent{Type: &v1alpha2.UDPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "udp-route"}}),
 			Entry("Gateway delete", &events.DeleteEvent{Type: &v1beta1.Gateway{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "gateway"}}),
 			Entry("GatewayClass delete", &events.DeleteEvent{Type: &v1beta1.GatewayClass{}, NamespacedName: types.NamespacedName{Name: "class"}}),
+			Entry("GatewayConfig delete", &events.DeleteEvent{Type: &nginxgwv1alpha1.GatewayConfig{}, NamespacedName: types.NamespacedName{Name: "config"}}),
 		)
 	})
 
+	It("should roll back to the previous config and not remove unused secrets when NGINX fails to reload", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxRuntimeMgr.ReloadReturnsOnCall(0, errors.New("reload failed"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeSecretMemoryManager.WriteAllRequestedSecretsCallCount()).Should(Equal(1))
+		Expect(fakeNginxFimeMgr.ApplyStagedConfigsCallCount()).Should(Equal(1))
+
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(1))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+
+		Expect(fakeReloadRecorder.RecordReloadCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordReloadArgsForCall(0)).Should(BeFalse())
+		Expect(fakeReloadRecorder.RecordRollbackCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordRollbackArgsForCall(0)).Should(BeTrue())
+
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(0))
+		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+	})
+
+	It("should restore the previous config and not reload NGINX when the config can't be applied", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxFimeMgr.ApplyStagedConfigsReturns(errors.New("apply failed"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeNginxFimeMgr.ApplyStagedConfigsCallCount()).Should(Equal(1))
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(1))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+
+		Expect(fakeReloadRecorder.RecordReloadCallCount()).Should(Equal(0))
+		Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(0))
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(0))
+		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+	})
+
+	It("should keep the config and the unused secrets when the reload can't be verified", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxRuntimeMgr.ReloadReturns(fmt.Errorf("%w: no workers", ngxruntime.ErrReloadUnverified))
+
+		batch := events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+		handler.HandleEventBatch(context.TODO(), batch)
+
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(0))
+
+		Expect(fakeReloadRecorder.RecordUnverifiedReloadCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordReloadCallCount()).Should(Equal(0))
+		Expect(fakeReloadRecorder.RecordRollbackCallCount()).Should(Equal(0))
+		Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(1))
+
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(0))
+		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+
+		// the config is kept, so the same config doesn't cause another reload
+		handler.HandleEventBatch(context.TODO(), batch)
+
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+	})
+
+	It("should not update NGINX when the secrets can't be written", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeSecretMemoryManager.WriteAllRequestedSecretsReturns(errors.New("write failed"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(0))
+		Expect(fakeNginxFimeMgr.WriteMainConfigCallCount()).Should(Equal(0))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+	})
+
+	It("should record a failed rollback when NGINX fails to reload the previous config", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxRuntimeMgr.ReloadReturns(errors.New("reload failed"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(1))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+
+		Expect(fakeReloadRecorder.RecordRollbackCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordRollbackArgsForCall(0)).Should(BeFalse())
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(0))
+	})
+
+	It("should not reload NGINX when the previous config can't be restored", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxRuntimeMgr.ReloadReturns(errors.New("reload failed"))
+		fakeNginxFimeMgr.RestorePreviousConfigsReturns(errors.New("restore failed"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeNginxFimeMgr.RestorePreviousConfigsCallCount()).Should(Equal(1))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+
+		Expect(fakeReloadRecorder.RecordRollbackCallCount()).Should(Equal(1))
+		Expect(fakeReloadRecorder.RecordRollbackArgsForCall(0)).Should(BeFalse())
+	})
+
+	It("should not apply the config and reload NGINX when the config is invalid", func() {
+		fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		fakeNginxRuntimeMgr.ValidateReturns(errors.New("invalid config"))
+
+		handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+		Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(1))
+		Expect(fakeNginxFimeMgr.ApplyStagedConfigsCallCount()).Should(Equal(0))
+		Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+		Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(0))
+		Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+	})
+
+	Describe("Unchanged configuration", func() {
+		var batch events.EventBatch
+
+		BeforeEach(func() {
+			fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+			fakeGenerator.GenerateMainReturns([]byte("fake main"))
+			fakeGenerator.GenerateReturns(map[string][]byte{"server": []byte("fake server")}, config.Warnings{})
+			fakeGenerator.GenerateStreamReturns([]byte("fake stream"), config.Warnings{})
+
+			batch = events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+			Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(1))
+		})
+
+		It("should not write the config and reload NGINX when the config is unchanged", func() {
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeSecretMemoryManager.WriteAllRequestedSecretsCallCount()).Should(Equal(2))
+			Expect(fakeNginxFimeMgr.WriteMainConfigCallCount()).Should(Equal(1))
+			Expect(fakeNginxFimeMgr.WriteHTTPConfigsCallCount()).Should(Equal(1))
+			Expect(fakeNginxFimeMgr.WriteStreamServersConfigCallCount()).Should(Equal(1))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+			Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(1))
+			Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(2))
+		})
+
+		It("should reload NGINX with a new hash when the config changes", func() {
+			fakeGenerator.GenerateReturns(map[string][]byte{"server": []byte("updated server")}, config.Warnings{})
+
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+			Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(2))
+
+			hash := fakeGenerator.GenerateConfigHashArgsForCall(1)
+			Expect(hash).ShouldNot(Equal(fakeGenerator.GenerateConfigHashArgsForCall(0)))
+			Expect(fakeReloadRecorder.RecordConfigHashArgsForCall(1)).Should(Equal(hash))
+		})
+
+		It("should reload NGINX when the same config is generated after a failed reload", func() {
+			fakeGenerator.GenerateReturns(map[string][]byte{"server": []byte("updated server")}, config.Warnings{})
+			fakeNginxRuntimeMgr.ReloadReturnsOnCall(1, errors.New("reload failed"))
+
+			handler.HandleEventBatch(context.TODO(), batch)
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			// the initial reload, the failed reload, the reload of the rollback and the reload of the retry
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(4))
+			Expect(fakeReloadRecorder.RecordConfigHashCallCount()).Should(Equal(2))
+		})
+	})
+
+	Describe("Initial configuration", func() {
+		BeforeEach(func() {
+			fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		})
+
+		It("should wait for NGINX to be ready only before the initial configuration", func() {
+			batch := events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			fakeGenerator.GenerateMainReturnsOnCall(1, []byte("updated main"))
+
+			handler.HandleEventBatch(context.TODO(), batch)
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(1))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+		})
+
+		It("should not configure NGINX when NGINX is not ready", func() {
+			fakeNginxRuntimeMgr.WaitForReadyReturns(context.Canceled)
+
+			handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+			Expect(fakeGenerator.GenerateCallCount()).Should(Equal(0))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+			Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+		})
+
+		It("should stop waiting for NGINX to be ready after the timeout", func() {
+			handler = events.NewEventHandlerImpl(events.EventHandlerConfig{
+				Processor:           fakeProcessor,
+				ServiceStore:        fakeServiceStore,
+				SecretStore:         fakeSecretStore,
+				SecretMemoryManager: fakeSecretMemoryManager,
+				Generator:           fakeGenerator,
+				Logger:              zap.New(),
+				NginxFileMgr:        fakeNginxFimeMgr,
+				NginxRuntimeMgr:     fakeNginxRuntimeMgr,
+				StatusUpdater:       fakeStatusUpdater,
+				ReloadRecorder:      fakeReloadRecorder,
+				NginxReadyTimeout:   10 * time.Millisecond,
+			})
+
+			// NGINX never becomes ready
+			fakeNginxRuntimeMgr.WaitForReadyStub = func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			batch := events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+			Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+
+			// the next batch waits for NGINX again
+			fakeNginxRuntimeMgr.WaitForReadyStub = nil
+			handler.HandleEventBatch(context.TODO(), batch)
+
+			Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(2))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+		})
+
+		When("the backoff allows multiple attempts", func() {
+			BeforeEach(func() {
+				handler = events.NewEventHandlerImpl(events.EventHandlerConfig{
+					Processor:            fakeProcessor,
+					ServiceStore:         fakeServiceStore,
+					SecretStore:          fakeSecretStore,
+					SecretMemoryManager:  fakeSecretMemoryManager,
+					Generator:            fakeGenerator,
+					Logger:               zap.New(),
+					NginxFileMgr:         fakeNginxFimeMgr,
+					NginxRuntimeMgr:      fakeNginxRuntimeMgr,
+					StatusUpdater:        fakeStatusUpdater,
+					ReloadRecorder:       fakeReloadRecorder,
+					InitialConfigBackoff: wait.Backoff{Duration: time.Millisecond, Steps: 3},
+				})
+			})
+
+			It("should retry the failed initial configuration", func() {
+				fakeNginxRuntimeMgr.ValidateReturnsOnCall(0, errors.New("invalid config"))
+
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(2))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+				Expect(fakeSecretMemoryManager.RemoveUnusedSecretsCallCount()).Should(Equal(1))
+				Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+			})
+
+			It("should not retry the initial configuration when the reload can't be verified", func() {
+				fakeNginxRuntimeMgr.ReloadReturns(fmt.Errorf("%w: no workers", ngxruntime.ErrReloadUnverified))
+
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+				Expect(fakeReloadRecorder.RecordUnverifiedReloadCallCount()).Should(Equal(1))
+
+				// NGINX is considered configured, so the next batch doesn't wait for NGINX to be ready
+				fakeGenerator.GenerateMainReturns([]byte("updated main"))
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(1))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+			})
+
+			It("should stop retrying after the last attempt", func() {
+				fakeNginxRuntimeMgr.ValidateReturns(errors.New("invalid config"))
+
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(3))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
+				Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+
+				// NGINX hasn't been configured yet, so the next batch is handled as the initial configuration
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.WaitForReadyCallCount()).Should(Equal(2))
+				Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(6))
+			})
+
+			It("should keep the secrets of the configuration when the first attempt fails", func() {
+				secretNsName := types.NamespacedName{Namespace: "test", Name: "secret"}
+
+				secretStore := state.NewSecretStore()
+				secretStore.Upsert(createTLSSecret(secretNsName))
+
+				secretDir, err := os.MkdirTemp("", "secrets")
+				Expect(err).ShouldNot(HaveOccurred())
+				DeferCleanup(os.RemoveAll, secretDir)
+
+				unusedFile := filepath.Join(secretDir, "unused")
+				Expect(os.WriteFile(unusedFile, []byte("unused"), 0o600)).Should(Succeed())
+
+				secretMemoryMgr := state.NewSecretDiskMemoryManager(secretDir, secretStore)
+
+				handler = events.NewEventHandlerImpl(events.EventHandlerConfig{
+					Processor:            fakeProcessor,
+					ServiceStore:         fakeServiceStore,
+					SecretStore:          fakeSecretStore,
+					SecretMemoryManager:  secretMemoryMgr,
+					Generator:            fakeGenerator,
+					Logger:               zap.New(),
+					NginxFileMgr:         fakeNginxFimeMgr,
+					NginxRuntimeMgr:      fakeNginxRuntimeMgr,
+					StatusUpdater:        fakeStatusUpdater,
+					ReloadRecorder:       fakeReloadRecorder,
+					InitialConfigBackoff: wait.Backoff{Duration: time.Millisecond, Steps: 3},
+				})
+
+				// the secret is requested when the configuration is built
+				files, err := secretMemoryMgr.Request(secretNsName)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				fakeNginxRuntimeMgr.ValidateReturnsOnCall(0, errors.New("invalid config"))
+
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(2))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+
+				Expect(files.Certificate).Should(BeAnExistingFile())
+				Expect(files.Key).Should(BeAnExistingFile())
+				Expect(unusedFile).ShouldNot(BeAnExistingFile())
+			})
+
+			It("should not retry the failed updates after the initial configuration", func() {
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				fakeNginxRuntimeMgr.ValidateReturns(errors.New("invalid config"))
+				fakeGenerator.GenerateMainReturns([]byte("updated main"))
+
+				handler.HandleEventBatch(context.TODO(), events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}})
+
+				Expect(fakeNginxRuntimeMgr.ValidateCallCount()).Should(Equal(2))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
 	Describe("Process Kubernetes resources events", func() {
 		expectNoReconfig := func() {
 			Expect(fakeProcessor.ProcessCallCount()).Should(Equal(1))
 			Expect(fakeGenerator.GenerateCallCount()).Should(Equal(0))
-			Expect(fakeNginxFimeMgr.WriteHTTPServersConfigCallCount()).Should(Equal(0))
+			Expect(fakeNginxFimeMgr.WriteHTTPConfigsCallCount()).Should(Equal(0))
 			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(0))
 			Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(0))
 		}
@@ -144,7 +565,7 @@ var _ = Describe("EventHandler", func() {
 			It("should process upsert event", func() {
 				svc := &apiv1.Service{}
 
-				batch := []interface{}{&events.UpsertEvent{
+				batch := events.EventBatch{&events.UpsertEvent{
 					Resource: svc,
 				}}
 
@@ -153,13 +574,16 @@ var _ = Describe("EventHandler", func() {
 				Expect(fakeServiceStore.UpsertCallCount()).Should(Equal(1))
 				Expect(fakeServiceStore.UpsertArgsForCall(0)).Should(Equal(svc))
 
+				Expect(fakeProcessor.CaptureUpsertChangeCallCount()).Should(Equal(1))
+				Expect(fakeProcessor.CaptureUpsertChangeArgsForCall(0)).Should(Equal(svc))
+
 				expectNoReconfig()
 			})
 
 			It("should process delete event", func() {
 				nsname := types.NamespacedName{Namespace: "test", Name: "service"}
 
-				batch := []interface{}{&events.DeleteEvent{
+				batch := events.EventBatch{&events.DeleteEvent{
 					NamespacedName: nsname,
 					Type:           &apiv1.Service{},
 				}}
@@ -169,6 +593,11 @@ var _ = Describe("EventHandler", func() {
 				Expect(fakeServiceStore.DeleteCallCount()).Should(Equal(1))
 				Expect(fakeServiceStore.DeleteArgsForCall(0)).Should(Equal(nsname))
 
+				Expect(fakeProcessor.CaptureDeleteChangeCallCount()).Should(Equal(1))
+				passedObj, passedNsName := fakeProcessor.CaptureDeleteChangeArgsForCall(0)
+				Expect(passedObj).Should(Equal(&apiv1.Service{}))
+				Expect(passedNsName).Should(Equal(nsname))
+
 				expectNoReconfig()
 			})
 		})
@@ -177,7 +606,7 @@ var _ = Describe("EventHandler", func() {
 			It("should process upsert event", func() {
 				secret := &apiv1.Secret{}
 
-				batch := []interface{}{&events.UpsertEvent{
+				batch := events.EventBatch{&events.UpsertEvent{
 					Resource: secret,
 				}}
 
@@ -186,13 +615,16 @@ var _ = Describe("EventHandler", func() {
 				Expect(fakeSecretStore.UpsertCallCount()).Should(Equal(1))
 				Expect(fakeSecretStore.UpsertArgsForCall(0)).Should(Equal(secret))
 
+				Expect(fakeProcessor.CaptureUpsertChangeCallCount()).Should(Equal(1))
+				Expect(fakeProcessor.CaptureUpsertChangeArgsForCall(0)).Should(Equal(secret))
+
 				expectNoReconfig()
 			})
 
 			It("should process delete event", func() {
 				nsname := types.NamespacedName{Namespace: "test", Name: "secret"}
 
-				batch := []interface{}{&events.DeleteEvent{
+				batch := events.EventBatch{&events.DeleteEvent{
 					NamespacedName: nsname,
 					Type:           &apiv1.Secret{},
 				}}
@@ -202,6 +634,11 @@ var _ = Describe("EventHandler", func() {
 				Expect(fakeSecretStore.DeleteCallCount()).Should(Equal(1))
 				Expect(fakeSecretStore.DeleteArgsForCall(0)).Should(Equal(nsname))
 
+				Expect(fakeProcessor.CaptureDeleteChangeCallCount()).Should(Equal(1))
+				passedObj, passedNsName := fakeProcessor.CaptureDeleteChangeArgsForCall(0)
+				Expect(passedObj).Should(Equal(&apiv1.Secret{}))
+				Expect(passedNsName).Should(Equal(nsname))
+
 				expectNoReconfig()
 			})
 		})
@@ -213,22 +650,32 @@ var _ = Describe("EventHandler", func() {
 		secret := &apiv1.Secret{}
 		secretNsName := types.NamespacedName{Namespace: "test", Name: "secret"}
 
-		upserts := []interface{}{
+		upserts := []events.Event{
 			&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}},
+			&events.UpsertEvent{Resource: &v1alpha2.GRPCRoute{}},
+			&events.UpsertEvent{Resource: &v1alpha2.TLSRoute{}},
+			&events.UpsertEvent{Resource: &v1alpha2.TCPRoute{}},
+			&events.UpsertEvent{Resource: &v1alpha2.UDPRoute{}},
+			&events.UpsertEvent{Resource: &nginxgwv1alpha1.GatewayConfig{}},
 			&events.UpsertEvent{Resource: &v1beta1.Gateway{}},
 			&events.UpsertEvent{Resource: &v1beta1.GatewayClass{}},
 			&events.UpsertEvent{Resource: svc},
 			&events.UpsertEvent{Resource: secret},
 		}
-		deletes := []interface{}{
+		deletes := []events.Event{
 			&events.DeleteEvent{Type: &v1beta1.HTTPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}},
+			&events.DeleteEvent{Type: &v1alpha2.GRPCRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "grpc-route"}},
+			&events.DeleteEvent{Type: &v1alpha2.TLSRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "tls-route"}},
+			&events.DeleteEvent{Type: &v1alpha2.TCPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "tcp-route"}},
+			&events.DeleteEvent{Type: &v1alpha2.UDPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "udp-route"}},
+			&events.DeleteEvent{Type: &nginxgwv1alpha1.GatewayConfig{}, NamespacedName: types.NamespacedName{Name: "config"}},
 			&events.DeleteEvent{Type: &v1beta1.Gateway{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "gateway"}},
 			&events.DeleteEvent{Type: &v1beta1.GatewayClass{}, NamespacedName: types.NamespacedName{Name: "class"}},
 			&events.DeleteEvent{Type: &apiv1.Service{}, NamespacedName: svcNsName},
 			&events.DeleteEvent{Type: &apiv1.Secret{}, NamespacedName: secretNsName},
 		}
 
-		batch := make([]interface{}, 0, len(upserts)+len(deletes))
+		batch := make(events.EventBatch, 0, len(upserts)+len(deletes))
 		batch = append(batch, upserts...)
 		batch = append(batch, deletes...)
 
@@ -237,22 +684,24 @@ var _ = Describe("EventHandler", func() {
 		fakeStatuses := state.Statuses{}
 		fakeProcessor.ProcessReturns(changed, fakeConf, fakeStatuses)
 
-		fakeCfg := []byte("fake")
-		fakeGenerator.GenerateReturns(fakeCfg, config.Warnings{})
+		fakeMainCfg := []byte("fake main")
+		fakeGenerator.GenerateMainReturns(fakeMainCfg)
+		fakeCfgs := map[string][]byte{"fake": []byte("fake")}
+		fakeGenerator.GenerateReturns(fakeCfgs, config.Warnings{})
+		fakeStreamCfg := []byte("fake stream")
+		fakeGenerator.GenerateStreamReturns(fakeStreamCfg, config.Warnings{})
 
 		handler.HandleEventBatch(context.TODO(), batch)
 
 		// Check that the events for Gateway API resources were captured
 
-		// 3, not 5, because the last 2 do not result into CaptureUpsertChange() call
-		Expect(fakeProcessor.CaptureUpsertChangeCallCount()).Should(Equal(3))
-		for i := 0; i < 3; i++ {
+		Expect(fakeProcessor.CaptureUpsertChangeCallCount()).Should(Equal(len(upserts)))
+		for i := range upserts {
 			Expect(fakeProcessor.CaptureUpsertChangeArgsForCall(i)).Should(Equal(upserts[i].(*events.UpsertEvent).Resource))
 		}
-		Expect(fakeProcessor.CaptureDeleteChangeCallCount()).Should(Equal(3))
 
-		// 3, not 5, because the last 2 do not result into CaptureDeleteChange() call
-		for i := 0; i < 3; i++ {
+		Expect(fakeProcessor.CaptureDeleteChangeCallCount()).Should(Equal(len(deletes)))
+		for i := range deletes {
 			d := deletes[i].(*events.DeleteEvent)
 			passedObj, passedNsName := fakeProcessor.CaptureDeleteChangeArgsForCall(i)
 			Expect(passedObj).Should(Equal(d.Type))
@@ -274,21 +723,106 @@ var _ = Describe("EventHandler", func() {
 		Expect(fakeSecretStore.DeleteArgsForCall(0)).Should(Equal(secretNsName))
 
 		// Check that a reconfig happened
-		expectReconfig(fakeConf, fakeCfg, fakeStatuses)
+		expectReconfig(fakeConf, fakeMainCfg, fakeCfgs, fakeStreamCfg, fakeStatuses)
+	})
+
+	Describe("Certificate validity changes", func() {
+		var (
+			eventCh   chan events.Event
+			fakeClock *eventsfakes.FakeClock
+			now       time.Time
+			ctx       context.Context
+		)
+
+		BeforeEach(func() {
+			eventCh = make(chan events.Event, 1)
+			fakeClock = &eventsfakes.FakeClock{}
+
+			now = time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+			fakeClock.NowReturns(now)
+
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			handler = events.NewEventHandlerImpl(events.EventHandlerConfig{
+				Processor:           fakeProcessor,
+				ServiceStore:        fakeServiceStore,
+				SecretStore:         fakeSecretStore,
+				SecretMemoryManager: fakeSecretMemoryManager,
+				Generator:           fakeGenerator,
+				Logger:              zap.New(),
+				NginxFileMgr:        fakeNginxFimeMgr,
+				NginxRuntimeMgr:     fakeNginxRuntimeMgr,
+				StatusUpdater:       fakeStatusUpdater,
+				ReloadRecorder:      fakeReloadRecorder,
+				EventCh:             eventCh,
+				Clock:               fakeClock,
+			})
+
+			fakeProcessor.ProcessReturns(true, state.Configuration{}, state.Statuses{})
+		})
+
+		It("should send an event when the next certificate validity change is reached", func() {
+			next := now.Add(time.Hour)
+			fakeSecretMemoryManager.NextCertificateValidityChangeReturns(next)
+
+			timer := make(chan time.Time, 1)
+			fakeClock.AfterReturns(timer)
+
+			handler.HandleEventBatch(ctx, events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.Gateway{}}})
+
+			Expect(fakeClock.AfterCallCount()).Should(Equal(1))
+			Expect(fakeClock.AfterArgsForCall(0)).Should(Equal(time.Hour))
+			Consistently(eventCh).ShouldNot(Receive())
+
+			timer <- next
+			Eventually(eventCh).Should(Receive(Equal(&events.CertificateValidityChangeEvent{})))
+		})
+
+		It("should cancel the scheduled event when the configuration is rebuilt", func() {
+			fakeSecretMemoryManager.NextCertificateValidityChangeReturns(now.Add(time.Hour))
+
+			firstTimer := make(chan time.Time, 1)
+			fakeClock.AfterReturnsOnCall(0, firstTimer)
+			fakeClock.AfterReturnsOnCall(1, make(chan time.Time))
+
+			batch := events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.Gateway{}}}
+			handler.HandleEventBatch(ctx, batch)
+			handler.HandleEventBatch(ctx, batch)
+
+			Expect(fakeClock.AfterCallCount()).Should(Equal(2))
+
+			firstTimer <- now.Add(time.Hour)
+			Consistently(eventCh).ShouldNot(Receive())
+		})
+
+		It("should not schedule an event when no certificates are referenced", func() {
+			handler.HandleEventBatch(ctx, events.EventBatch{&events.UpsertEvent{Resource: &v1beta1.Gateway{}}})
+
+			Expect(fakeClock.AfterCallCount()).Should(Equal(0))
+		})
+
+		It("should capture the certificate validity change", func() {
+			handler.HandleEventBatch(ctx, events.EventBatch{&events.CertificateValidityChangeEvent{}})
+
+			Expect(fakeProcessor.CaptureCertificateValidityChangeCallCount()).Should(Equal(1))
+			Expect(fakeProcessor.ProcessCallCount()).Should(Equal(1))
+		})
 	})
 
 	Describe("Edge cases", func() {
 		DescribeTable("Edge cases for events",
-			func(e interface{}) {
+			func(e events.Event) {
 				handle := func() {
-					batch := []interface{}{e}
+					batch := events.EventBatch{e}
 					handler.HandleEventBatch(context.TODO(), batch)
 				}
 
 				Expect(handle).Should(Panic())
 			},
-			Entry("should panic for an unknown event type",
-				&struct{}{}),
+			Entry("should panic for a nil event",
+				nil),
 			Entry("should panic for an unknown type of resource in upsert event",
 				&events.UpsertEvent{
 					Resource: &unsupportedResource{},
@@ -300,3 +834,34 @@ var _ = Describe("EventHandler", func() {
 		)
 	})
 })
+
+// createTLSSecret creates a TLS Secret with a self-signed certificate, which is valid for a day.
+func createTLSSecret(nsname types.NamespacedName) *apiv1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nsname.Namespace,
+			Name:      nsname.Name,
+		},
+		Type: apiv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+			apiv1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
@@ -3,10 +3,59 @@ package events
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 )
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Clock
+
+// Clock provides the time and the timers for the EventLoop.
+// Used to inject a fake clock for unit tests.
+type Clock interface {
+	// Now returns the current local time.
+	Now() time.Time
+	// After returns a channel that receives the current time after the duration elapses.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// EventLoopOption is a function that modifies the configuration of the EventLoop.
+type EventLoopOption func(*EventLoop)
+
+// WithDebounceWindow sets how long the EventLoop collects the events into a batch after the first event of the batch
+// comes, so that the events that come together, like the events caused by a rolling update, are handled at once.
+func WithDebounceWindow(window time.Duration) EventLoopOption {
+	return func(el *EventLoop) {
+		el.debounceWindow = window
+	}
+}
+
+// WithMinBatchInterval sets the minimum interval between the starts of the handling of two batches.
+// It limits how often NGINX is reloaded.
+func WithMinBatchInterval(interval time.Duration) EventLoopOption {
+	return func(el *EventLoop) {
+		el.minBatchInterval = interval
+	}
+}
+
+// WithEventLoopClock sets the clock of the EventLoop.
+// Used to inject a fake clock for unit tests.
+func WithEventLoopClock(clock Clock) EventLoopOption {
+	return func(el *EventLoop) {
+		el.clock = clock
+	}
+}
+
 // EventLoop is the main event loop of the Gateway. It handles events coming through the event channel.
 //
 // When a new event comes, there are two cases:
@@ -21,27 +70,45 @@ import (
 // (2) A reload can have side-effects for the data plane traffic.
 // FIXME(pleshakov): better document the side effects and how to prevent and mitigate them.
 // So when the EventLoop have 100 saved events, it is better to process them at once rather than one by one.
+//
+// To batch more events and to limit the rate of the reloads, the handling of a batch can be delayed:
+// - The first event that comes when no events are saved or being handled opens a debounce window. The events that
+// come during the window are batched with it.
+// - The handling of a batch starts no earlier than the minimum batch interval after the start of the previous one.
+// The first batch is handled immediately.
 type EventLoop struct {
-	eventCh <-chan interface{}
+	eventCh <-chan Event
 	logger  logr.Logger
 	handler EventHandler
+	clock   Clock
 
 	preparer FirstEventBatchPreparer
+
+	debounceWindow   time.Duration
+	minBatchInterval time.Duration
 }
 
 // NewEventLoop creates a new EventLoop.
 func NewEventLoop(
-	eventCh <-chan interface{},
+	eventCh <-chan Event,
 	logger logr.Logger,
 	handler EventHandler,
 	preparer FirstEventBatchPreparer,
+	options ...EventLoopOption,
 ) *EventLoop {
-	return &EventLoop{
+	el := &EventLoop{
 		eventCh:  eventCh,
 		logger:   logger,
 		handler:  handler,
+		clock:    realClock{},
 		preparer: preparer,
 	}
+
+	for _, o := range options {
+		o(el)
+	}
+
+	return el
 }
 
 // Start starts the EventLoop.
@@ -53,8 +120,16 @@ func (el *EventLoop) Start(ctx context.Context) error {
 	var handling bool
 	// handlingDone is used to signal the completion of handling a batch.
 	handlingDone := make(chan struct{})
+	// delayDone receives when the delayed handling of the current batch must start. It is nil if the handling of
+	// the current batch is not delayed.
+	var delayDone <-chan time.Time
+	// lastHandlingStart is the time when the handling of the last batch started.
+	var lastHandlingStart time.Time
 
 	handleAndResetBatch := func() {
+		handling = true
+		lastHandlingStart = el.clock.Now()
+
 		go func(batch EventBatch) {
 			el.logger.Info("Handling events from the batch", "total", len(batch))
 
@@ -67,7 +142,23 @@ func (el *EventLoop) Start(ctx context.Context) error {
 		// FIXME(pleshakov): Making an entirely new buffer is inefficient and multiplies memory operations.
 		// Use a double-buffer approach - create two buffers and exchange them between the producer and consumer
 		// routines. NOTE: pass-by-reference, and reset buffer to length 0, but retain capacity.
-		batch = make([]interface{}, 0)
+		batch = make(EventBatch, 0)
+	}
+
+	// handleBatchAfter handles the current batch after the delay, which is extended so that the handling starts no
+	// earlier than the minimum batch interval after the start of the previous one.
+	handleBatchAfter := func(delay time.Duration) {
+		if d := lastHandlingStart.Add(el.minBatchInterval).Sub(el.clock.Now()); d > delay {
+			delay = d
+		}
+
+		if delay <= 0 {
+			handleAndResetBatch()
+			return
+		}
+
+		el.logger.Info("Delaying the handling of the batch", "delay", delay)
+		delayDone = el.clock.After(delay)
 	}
 
 	// Prepare the fist event batch, which includes the UpsertEvents for all relevant cluster resources.
@@ -89,7 +180,6 @@ func (el *EventLoop) Start(ctx context.Context) error {
 
 	// Handle the first batch
 	handleAndResetBatch()
-	handling = true
 
 	// Note: at any point of time, no more than one batch is currently being handled.
 
@@ -113,18 +203,21 @@ func (el *EventLoop) Start(ctx context.Context) error {
 				"total", len(batch),
 			)
 
-			// Handle the current batch if no batch is being handled.
-			if !handling {
-				handleAndResetBatch()
-				handling = true
+			// Handle the current batch if no batch is being handled and its handling is not delayed yet.
+			// The event is the first event of the batch, so it opens the debounce window.
+			if !handling && delayDone == nil {
+				handleBatchAfter(el.debounceWindow)
 			}
+		case <-delayDone:
+			delayDone = nil
+			handleAndResetBatch()
 		case <-handlingDone:
 			handling = false
 
-			// Handle the current batch if it has at least one event.
+			// Handle the current batch if it has at least one event. The events of the batch have been waiting
+			// while the previous batch was being handled, so no debounce window is needed.
 			if len(batch) > 0 {
-				handleAndResetBatch()
-				handling = true
+				handleBatchAfter(0)
 			}
 		}
 	}
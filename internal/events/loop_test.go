@@ -3,19 +3,37 @@ package events_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events/eventsfakes"
 )
 
+func newEvent(name string) events.Event {
+	return &events.UpsertEvent{
+		Resource: &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      name,
+			},
+		},
+	}
+}
+
 var _ = Describe("EventLoop", func() {
+	event0 := newEvent("event0")
+	event1 := newEvent("event1")
+	event2 := newEvent("event2")
+
 	var (
 		fakeHandler  *eventsfakes.FakeEventHandler
-		eventCh      chan interface{}
+		eventCh      chan events.Event
 		fakePreparer *eventsfakes.FakeFirstEventBatchPreparer
 		eventLoop    *events.EventLoop
 		ctx          context.Context
@@ -25,7 +43,7 @@ var _ = Describe("EventLoop", func() {
 
 	BeforeEach(func() {
 		fakeHandler = &eventsfakes.FakeEventHandler{}
-		eventCh = make(chan interface{})
+		eventCh = make(chan events.Event)
 		fakePreparer = &eventsfakes.FakeFirstEventBatchPreparer{}
 
 		eventLoop = events.NewEventLoop(eventCh, zap.New(), fakeHandler, fakePreparer)
@@ -37,7 +55,7 @@ var _ = Describe("EventLoop", func() {
 	Describe("Normal processing", func() {
 		BeforeEach(func() {
 			batch := events.EventBatch{
-				"event0",
+				event0,
 			}
 			fakePreparer.PrepareReturns(batch, nil)
 
@@ -49,7 +67,7 @@ var _ = Describe("EventLoop", func() {
 			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(1))
 			_, batch = fakeHandler.HandleEventBatchArgsForCall(0)
 
-			var expectedBatch events.EventBatch = []interface{}{"event0"}
+			expectedBatch := events.EventBatch{event0}
 			Expect(batch).Should(Equal(expectedBatch))
 		})
 
@@ -65,14 +83,14 @@ var _ = Describe("EventLoop", func() {
 		// HandleEventBatchCallCount() is already 1.
 
 		It("should process a single event", func() {
-			e := "event"
+			e := newEvent("event")
 
 			eventCh <- e
 
 			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
 			_, batch := fakeHandler.HandleEventBatchArgsForCall(1)
 
-			var expectedBatch events.EventBatch = []interface{}{e}
+			expectedBatch := events.EventBatch{e}
 			Expect(batch).Should(Equal(expectedBatch))
 		})
 
@@ -87,9 +105,9 @@ var _ = Describe("EventLoop", func() {
 				<-sentSecondAndThirdEvents
 			})
 
-			e1 := "event1"
-			e2 := "event2"
-			e3 := "event3"
+			e1 := newEvent("event1")
+			e2 := newEvent("event2")
+			e3 := newEvent("event3")
 
 			eventCh <- e1
 
@@ -108,19 +126,134 @@ var _ = Describe("EventLoop", func() {
 			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(3))
 			_, batch := fakeHandler.HandleEventBatchArgsForCall(1)
 
-			var expectedBatch events.EventBatch = []interface{}{e1}
+			expectedBatch := events.EventBatch{e1}
 
 			// the first HandleEventBatch() call must have handled a batch with e1
 			Expect(batch).Should(Equal(expectedBatch))
 
 			_, batch = fakeHandler.HandleEventBatchArgsForCall(2)
 
-			expectedBatch = []interface{}{e2, e3}
+			expectedBatch = events.EventBatch{e2, e3}
 			// the second HandleEventBatch() call must have handled a batch with e2 and e3
 			Expect(batch).Should(Equal(expectedBatch))
 		})
 	})
 
+	Describe("Delayed processing", func() {
+		const (
+			debounceWindow   = 100 * time.Millisecond
+			minBatchInterval = time.Second
+		)
+
+		var (
+			fakeClock *eventsfakes.FakeClock
+			delayCh   chan time.Time
+			start     time.Time
+		)
+
+		BeforeEach(func() {
+			start = time.Now()
+
+			fakeClock = &eventsfakes.FakeClock{}
+			// the first call is made when the first batch is handled
+			fakeClock.NowReturnsOnCall(0, start)
+			delayCh = make(chan time.Time, 1)
+			fakeClock.AfterReturns(delayCh)
+
+			eventLoop = events.NewEventLoop(
+				eventCh,
+				zap.New(),
+				fakeHandler,
+				fakePreparer,
+				events.WithDebounceWindow(debounceWindow),
+				events.WithMinBatchInterval(minBatchInterval),
+				events.WithEventLoopClock(fakeClock),
+			)
+
+			fakePreparer.PrepareReturns(events.EventBatch{event0}, nil)
+
+			go func() {
+				errorCh <- eventLoop.Start(ctx)
+			}()
+
+			// the first batch is not delayed
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(1))
+			Expect(fakeClock.AfterCallCount()).Should(Equal(0))
+		})
+
+		AfterEach(func() {
+			cancel()
+
+			var err error
+			Eventually(errorCh).Should(Receive(&err))
+			Expect(err).To(BeNil())
+		})
+
+		It("should batch the events that come during the debounce window", func() {
+			// the minimum batch interval has passed
+			fakeClock.NowReturns(start.Add(2 * minBatchInterval))
+
+			eventCh <- event1
+			eventCh <- event2
+
+			Eventually(fakeClock.AfterCallCount).Should(Equal(1))
+			Expect(fakeClock.AfterArgsForCall(0)).Should(Equal(debounceWindow))
+			Consistently(fakeHandler.HandleEventBatchCallCount).Should(Equal(1))
+
+			delayCh <- time.Now()
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
+			_, batch := fakeHandler.HandleEventBatchArgsForCall(1)
+			Expect(batch).Should(Equal(events.EventBatch{event1, event2}))
+		})
+
+		It("should delay the handling of a batch until the minimum batch interval passes", func() {
+			fakeClock.NowReturns(start.Add(minBatchInterval / 4))
+
+			eventCh <- event1
+
+			Eventually(fakeClock.AfterCallCount).Should(Equal(1))
+			Expect(fakeClock.AfterArgsForCall(0)).Should(Equal(3 * minBatchInterval / 4))
+			Consistently(fakeHandler.HandleEventBatchCallCount).Should(Equal(1))
+
+			delayCh <- time.Now()
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
+		})
+
+		It("should not apply the debounce window to the events that come while a batch is being handled", func() {
+			handlingInProgress := make(chan struct{})
+			finishHandling := make(chan struct{})
+
+			fakeHandler.HandleEventBatchCalls(func(ctx context.Context, batch events.EventBatch) {
+				close(handlingInProgress)
+				<-finishHandling
+			})
+
+			fakeClock.NowReturns(start.Add(2 * minBatchInterval))
+
+			eventCh <- event1
+			delayCh <- time.Now()
+
+			<-handlingInProgress
+
+			eventCh <- event2
+
+			fakeHandler.HandleEventBatchCalls(nil)
+			close(finishHandling)
+
+			// the handling of the batch with event1 started at the same time that Now returns
+			Eventually(fakeClock.AfterCallCount).Should(Equal(2))
+			Expect(fakeClock.AfterArgsForCall(1)).Should(Equal(minBatchInterval))
+
+			delayCh <- time.Now()
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(3))
+			_, batch := fakeHandler.HandleEventBatchArgsForCall(2)
+			Expect(batch).Should(Equal(events.EventBatch{event2}))
+		})
+	})
+
 	Describe("Edge cases", func() {
 		It("should return error when preparer returns error without blocking", func() {
 			preparerError := errors.New("test")
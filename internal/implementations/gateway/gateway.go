@@ -12,10 +12,10 @@ import (
 
 type gatewayImplementation struct {
 	logger  logr.Logger
-	eventCh chan<- interface{}
+	eventCh chan<- events.Event
 }
 
-func NewGatewayImplementation(conf config.Config, eventCh chan<- interface{}) sdk.GatewayImpl {
+func NewGatewayImplementation(conf config.Config, eventCh chan<- events.Event) sdk.GatewayImpl {
 	return &gatewayImplementation{
 		logger:  conf.Logger,
 		eventCh: eventCh,
@@ -16,12 +16,12 @@ import (
 
 var _ = Describe("GatewayImplementation", func() {
 	var (
-		eventCh chan interface{}
+		eventCh chan events.Event
 		impl    sdk.GatewayImpl
 	)
 
 	BeforeEach(func() {
-		eventCh = make(chan interface{})
+		eventCh = make(chan events.Event)
 
 		impl = implementation.NewGatewayImplementation(config.Config{
 			Logger: zap.New(),
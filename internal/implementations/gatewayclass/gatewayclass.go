@@ -15,10 +15,10 @@ import (
 type gatewayClassImplementation struct {
 	logger           logr.Logger
 	gatewayClassName string
-	eventCh          chan<- interface{}
+	eventCh          chan<- events.Event
 }
 
-func NewGatewayClassImplementation(conf config.Config, eventCh chan<- interface{}) sdk.GatewayClassImpl {
+func NewGatewayClassImplementation(conf config.Config, eventCh chan<- events.Event) sdk.GatewayClassImpl {
 	return &gatewayClassImplementation{
 		logger:           conf.Logger,
 		gatewayClassName: conf.GatewayClassName,
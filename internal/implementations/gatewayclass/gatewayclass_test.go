@@ -16,7 +16,7 @@ import (
 
 var _ = Describe("GatewayClassImplementation", func() {
 	var (
-		eventCh chan interface{}
+		eventCh chan events.Event
 		impl    sdk.GatewayClassImpl
 	)
 
@@ -26,7 +26,7 @@ var _ = Describe("GatewayClassImplementation", func() {
 	)
 
 	BeforeEach(func() {
-		eventCh = make(chan interface{})
+		eventCh = make(chan events.Event)
 
 		impl = implementation.NewGatewayClassImplementation(config.Config{
 			Logger:           zap.New(),
@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type grpcRouteImplementation struct {
+	conf    config.Config
+	eventCh chan<- events.Event
+}
+
+// NewGRPCRouteImplementation creates a new GRPCRouteImplementation.
+func NewGRPCRouteImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.GRPCRouteImpl {
+	return &grpcRouteImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *grpcRouteImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *grpcRouteImplementation) ControllerName() string {
+	return impl.conf.GatewayCtlrName
+}
+
+func (impl *grpcRouteImplementation) Upsert(gr *v1alpha2.GRPCRoute) {
+	impl.Logger().Info("GRPCRoute was upserted",
+		"namespace", gr.Namespace, "name", gr.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: gr,
+	}
+}
+
+func (impl *grpcRouteImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("GRPCRoute resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &v1alpha2.GRPCRoute{},
+	}
+}
@@ -12,11 +12,11 @@ import (
 
 type httpRouteImplementation struct {
 	conf    config.Config
-	eventCh chan<- interface{}
+	eventCh chan<- events.Event
 }
 
 // NewHTTPRouteImplementation creates a new HTTPRouteImplementation.
-func NewHTTPRouteImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.HTTPRouteImpl {
+func NewHTTPRouteImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.HTTPRouteImpl {
 	return &httpRouteImplementation{
 		conf:    cfg,
 		eventCh: eventCh,
@@ -12,11 +12,11 @@ import (
 
 type secretImplementation struct {
 	conf    config.Config
-	eventCh chan<- interface{}
+	eventCh chan<- events.Event
 }
 
 // NewSecretImplementation creates a new SecretImplementation.
-func NewSecretImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.SecretImpl {
+func NewSecretImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.SecretImpl {
 	return &secretImplementation{
 		conf:    cfg,
 		eventCh: eventCh,
@@ -16,12 +16,12 @@ import (
 
 var _ = Describe("SecretImplementation", func() {
 	var (
-		eventCh chan interface{}
+		eventCh chan events.Event
 		impl    sdk.SecretImpl
 	)
 
 	BeforeEach(func() {
-		eventCh = make(chan interface{})
+		eventCh = make(chan events.Event)
 
 		impl = implementation.NewSecretImplementation(config.Config{
 			Logger: zap.New(),
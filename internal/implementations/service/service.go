@@ -12,14 +12,14 @@ import (
 
 type serviceImplementation struct {
 	conf    config.Config
-	eventCh chan<- interface{}
+	eventCh chan<- events.Event
 }
 
 // FIXME(pleshakov): serviceImplementation looks similar to httpRouteImplemenation
 // consider if it is possible to reduce the amount of code.
 
 // NewServiceImplementation creates a new ServiceImplementation.
-func NewServiceImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.ServiceImpl {
+func NewServiceImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.ServiceImpl {
 	return &serviceImplementation{
 		conf:    cfg,
 		eventCh: eventCh,
@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type tlsRouteImplementation struct {
+	conf    config.Config
+	eventCh chan<- events.Event
+}
+
+// NewTLSRouteImplementation creates a new TLSRouteImplementation.
+func NewTLSRouteImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.TLSRouteImpl {
+	return &tlsRouteImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *tlsRouteImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *tlsRouteImplementation) ControllerName() string {
+	return impl.conf.GatewayCtlrName
+}
+
+func (impl *tlsRouteImplementation) Upsert(tr *v1alpha2.TLSRoute) {
+	impl.Logger().Info("TLSRoute was upserted",
+		"namespace", tr.Namespace, "name", tr.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: tr,
+	}
+}
+
+func (impl *tlsRouteImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("TLSRoute resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &v1alpha2.TLSRoute{},
+	}
+}
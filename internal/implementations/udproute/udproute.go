@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type udpRouteImplementation struct {
+	conf    config.Config
+	eventCh chan<- events.Event
+}
+
+// NewUDPRouteImplementation creates a new UDPRouteImplementation.
+func NewUDPRouteImplementation(cfg config.Config, eventCh chan<- events.Event) sdk.UDPRouteImpl {
+	return &udpRouteImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *udpRouteImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *udpRouteImplementation) ControllerName() string {
+	return impl.conf.GatewayCtlrName
+}
+
+func (impl *udpRouteImplementation) Upsert(ur *v1alpha2.UDPRoute) {
+	impl.Logger().Info("UDPRoute was upserted",
+		"namespace", ur.Namespace, "name", ur.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: ur,
+	}
+}
+
+func (impl *udpRouteImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("UDPRoute resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &v1alpha2.UDPRoute{},
+	}
+}
@@ -2,29 +2,40 @@ package manager
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctlr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctlrmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
 	gw "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gateway"
 	gc "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gatewayclass"
+	gcfg "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gatewayconfig"
+	grpcr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/grpcroute"
 	hr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/httproute"
 	secret "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/secret"
 	svc "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/service"
+	tcpr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/tcproute"
+	tr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/tlsroute"
+	udpr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/udproute"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/metrics"
 	ngxcfg "github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/file"
 	ngxruntime "github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
 )
 
@@ -40,7 +51,37 @@ var scheme = runtime.NewScheme()
 
 func init() {
 	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(nginxgwv1alpha1.AddToScheme(scheme))
+}
+
+// InitializeNginxConfig writes the initial NGINX configuration, so that NGINX can start before the Gateway
+// configures it. It creates the folders for the configs and the secrets and writes the main config with
+// the default settings.
+func InitializeNginxConfig() error {
+	nginxFileMgr := file.NewManagerImpl()
+
+	err := nginxFileMgr.CreateFolders()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(secretsFolder, 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create folder %s: %w", secretsFolder, err)
+	}
+
+	configGenerator := ngxcfg.NewGeneratorImpl(state.NewServiceStore())
+
+	err = nginxFileMgr.WriteMainConfig(configGenerator.GenerateMain(state.Configuration{}))
+	if err != nil {
+		return err
+	}
+
+	// NGINX is not running yet, so the config can't be validated. It is valid, because it doesn't depend on
+	// any resources.
+	return nginxFileMgr.ApplyStagedConfigs()
 }
 
 func Start(cfg config.Config) error {
@@ -50,7 +91,7 @@ func Start(cfg config.Config) error {
 		Scheme: scheme,
 	}
 
-	eventCh := make(chan interface{})
+	eventCh := make(chan events.Event)
 
 	clusterCfg := ctlr.GetConfigOrDie()
 	clusterCfg.Timeout = clusterTimeout
@@ -64,6 +105,10 @@ func Start(cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("cannot register gatewayclass implementation: %w", err)
 	}
+	err = sdk.RegisterGatewayConfigController(mgr, gcfg.NewGatewayConfigImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register gatewayconfig implementation: %w", err)
+	}
 	err = sdk.RegisterGatewayController(mgr, gw.NewGatewayImplementation(cfg, eventCh))
 	if err != nil {
 		return fmt.Errorf("cannot register gateway implementation: %w", err)
@@ -72,6 +117,22 @@ func Start(cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("cannot register httproute implementation: %w", err)
 	}
+	err = sdk.RegisterGRPCRouteController(mgr, grpcr.NewGRPCRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register grpcroute implementation: %w", err)
+	}
+	err = sdk.RegisterTLSRouteController(mgr, tr.NewTLSRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register tlsroute implementation: %w", err)
+	}
+	err = sdk.RegisterTCPRouteController(mgr, tcpr.NewTCPRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register tcproute implementation: %w", err)
+	}
+	err = sdk.RegisterUDPRouteController(mgr, udpr.NewUDPRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register udproute implementation: %w", err)
+	}
 	err = sdk.RegisterServiceController(mgr, svc.NewServiceImplementation(cfg, eventCh))
 	if err != nil {
 		return fmt.Errorf("cannot register service implementation: %w", err)
@@ -81,19 +142,44 @@ func Start(cfg config.Config) error {
 		return fmt.Errorf("cannot register secret implementation: %w", err)
 	}
 
+	clock := status.NewRealClock()
+
+	certExpiryCollector := metrics.NewCertificateExpiryCollector(clock)
+	// the metrics are served by the manager
+	if err := ctlrmetrics.Registry.Register(certExpiryCollector); err != nil {
+		return fmt.Errorf("cannot register certificate expiry metrics: %w", err)
+	}
+
+	reloadCollector := metrics.NewNginxReloadCollector()
+	if err := ctlrmetrics.Registry.Register(reloadCollector); err != nil {
+		return fmt.Errorf("cannot register NGINX reload metrics: %w", err)
+	}
+
 	secretStore := state.NewSecretStore()
-	secretMemoryMgr := state.NewSecretDiskMemoryManager(secretsFolder, secretStore)
+	secretMemoryMgr := state.NewSecretDiskMemoryManager(
+		secretsFolder,
+		secretStore,
+		state.WithCertificateExpiryRecorder(certExpiryCollector),
+		state.WithSecretNowFunc(clock.Now),
+	)
+
+	staticAddresses := make([]gatewayv1beta1.GatewayAddress, 0, len(cfg.GatewayAddresses))
+	for _, a := range cfg.GatewayAddresses {
+		staticAddresses = append(staticAddresses, state.NewGatewayAddress(a))
+	}
 
 	processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
 		GatewayCtlrName:     cfg.GatewayCtlrName,
 		GatewayClassName:    cfg.GatewayClassName,
 		SecretMemoryManager: secretMemoryMgr,
+		ServiceNsName:       cfg.ServiceNsName,
+		StaticAddresses:     staticAddresses,
 	})
 
 	serviceStore := state.NewServiceStore()
 	configGenerator := ngxcfg.NewGeneratorImpl(serviceStore)
 	nginxFileMgr := file.NewManagerImpl()
-	nginxRuntimeMgr := ngxruntime.NewManagerImpl()
+	nginxRuntimeMgr := ngxruntime.NewManagerImpl(ngxruntime.NewCommandExecutorImpl())
 	statusUpdater := status.NewUpdater(status.UpdaterConfig{
 		GatewayCtlrName:  cfg.GatewayCtlrName,
 		GatewayClassName: cfg.GatewayClassName,
@@ -102,7 +188,7 @@ func Start(cfg config.Config) error {
 		// (1) Has a dedicated named logger.
 		// (2) Get it from the Manager (the WithName is done here for all components).
 		Logger: cfg.Logger.WithName("statusUpdater"),
-		Clock:  status.NewRealClock(),
+		Clock:  clock,
 	})
 
 	eventHandler := events.NewEventHandlerImpl(events.EventHandlerConfig{
@@ -115,6 +201,15 @@ func Start(cfg config.Config) error {
 		NginxFileMgr:        nginxFileMgr,
 		NginxRuntimeMgr:     nginxRuntimeMgr,
 		StatusUpdater:       statusUpdater,
+		ReloadRecorder:      reloadCollector,
+		EventCh:             eventCh,
+		InitialConfigBackoff: wait.Backoff{
+			Duration: 500 * time.Millisecond,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    6,
+		},
+		NginxReadyTimeout: 30 * time.Second,
 	})
 
 	firstBatchPreparer := events.NewFirstEventBatchPreparerImpl(
@@ -125,8 +220,13 @@ func Start(cfg config.Config) error {
 		[]client.ObjectList{
 			&apiv1.ServiceList{},
 			&apiv1.SecretList{},
+			&nginxgwv1alpha1.GatewayConfigList{},
 			&gatewayv1beta1.GatewayList{},
 			&gatewayv1beta1.HTTPRouteList{},
+			&gatewayv1alpha2.GRPCRouteList{},
+			&gatewayv1alpha2.TLSRouteList{},
+			&gatewayv1alpha2.TCPRouteList{},
+			&gatewayv1alpha2.UDPRouteList{},
 		},
 	)
 
@@ -134,7 +234,10 @@ func Start(cfg config.Config) error {
 		eventCh,
 		cfg.Logger.WithName("eventLoop"),
 		eventHandler,
-		firstBatchPreparer)
+		firstBatchPreparer,
+		events.WithDebounceWindow(cfg.EventDebounceWindow),
+		events.WithMinBatchInterval(cfg.MinReloadInterval),
+	)
 
 	err = mgr.Add(eventLoop)
 	if err != nil {
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status"
+)
+
+const metricsNamespace = "nginx_kubernetes_gateway"
+
+// CertificateExpiryCollector is a Prometheus collector that reports the number of seconds until the certificates
+// of the Secrets referenced by the Gateway expire.
+// It implements state.CertificateExpiryRecorder.
+// The value is computed when the metric is collected, so that it doesn't go stale between the reconfigurations.
+type CertificateExpiryCollector struct {
+	expiries map[types.NamespacedName]time.Time
+	clock    status.Clock
+	desc     *prometheus.Desc
+
+	lock sync.Mutex
+}
+
+// NewCertificateExpiryCollector creates a new CertificateExpiryCollector.
+func NewCertificateExpiryCollector(clock status.Clock) *CertificateExpiryCollector {
+	return &CertificateExpiryCollector{
+		expiries: make(map[types.NamespacedName]time.Time),
+		clock:    clock,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "certificate_expiry_seconds"),
+			"Number of seconds until the certificate of a Secret referenced by the Gateway expires.",
+			[]string{"namespace", "name"},
+			nil,
+		),
+	}
+}
+
+// RecordCertificateExpiries replaces the previously recorded expiry times with the passed ones.
+func (c *CertificateExpiryCollector) RecordCertificateExpiries(expiries map[types.NamespacedName]time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.expiries = make(map[types.NamespacedName]time.Time, len(expiries))
+	for nsname, expiry := range expiries {
+		c.expiries[nsname] = expiry
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *CertificateExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *CertificateExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now().Time
+
+	for nsname, expiry := range c.expiries {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc,
+			prometheus.GaugeValue,
+			expiry.Sub(now).Seconds(),
+			nsname.Namespace,
+			nsname.Name,
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status/statusfakes"
+)
+
+func TestCertificateExpiryCollector(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	fakeClock := &statusfakes.FakeClock{}
+	fakeClock.NowReturns(metav1.NewTime(now))
+
+	collector := NewCertificateExpiryCollector(fakeClock)
+
+	collector.RecordCertificateExpiries(map[types.NamespacedName]time.Time{
+		{Namespace: "test", Name: "secret"}: now.Add(time.Hour),
+		{Namespace: "test", Name: "other"}:  now.Add(-time.Minute),
+	})
+
+	expected := `
+# HELP nginx_kubernetes_gateway_certificate_expiry_seconds Number of seconds until the certificate of a Secret referenced by the Gateway expires.
+# TYPE nginx_kubernetes_gateway_certificate_expiry_seconds gauge
+nginx_kubernetes_gateway_certificate_expiry_seconds{name="other",namespace="test"} -60
+nginx_kubernetes_gateway_certificate_expiry_seconds{name="secret",namespace="test"} 3600
+`
+
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Errorf("CertificateExpiryCollector collected unexpected metrics: %v", err)
+	}
+
+	// recording replaces the previous expiries
+	collector.RecordCertificateExpiries(map[types.NamespacedName]time.Time{})
+
+	if count := testutil.CollectAndCount(collector); count != 0 {
+		t.Errorf("CertificateExpiryCollector collected %d metrics but expected 0", count)
+	}
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+	// outcomeUnverified is the outcome of a reload when it is unknown whether NGINX applied the configuration.
+	outcomeUnverified = "unverified"
+)
+
+// NginxReloadCollector is a Prometheus collector that reports the outcomes of the NGINX reloads, of the
+// rollbacks to the previous configuration after the failed reloads and the hash of the current configuration.
+// It implements events.ReloadRecorder.
+type NginxReloadCollector struct {
+	reloads              *prometheus.CounterVec
+	rollbacks            *prometheus.CounterVec
+	lastReloadSuccessful prometheus.Gauge
+	configInfo           *prometheus.GaugeVec
+}
+
+// NewNginxReloadCollector creates a new NginxReloadCollector.
+func NewNginxReloadCollector() *NginxReloadCollector {
+	return &NginxReloadCollector{
+		reloads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "nginx_reloads_total",
+				Help:      "Number of NGINX reloads by outcome.",
+			},
+			[]string{"outcome"},
+		),
+		rollbacks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "nginx_config_rollbacks_total",
+				Help:      "Number of rollbacks to the previous NGINX configuration after a failed reload by outcome.",
+			},
+			[]string{"outcome"},
+		),
+		lastReloadSuccessful: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "nginx_last_reload_successful",
+				Help:      "Whether the last NGINX reload was successful (1) or not (0).",
+			},
+		),
+		configInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "nginx_config_info",
+				Help:      "Information about the NGINX configuration used by NGINX. The value is always 1.",
+			},
+			[]string{"hash"},
+		),
+	}
+}
+
+// RecordReload records the outcome of an NGINX reload.
+func (c *NginxReloadCollector) RecordReload(success bool) {
+	c.reloads.WithLabelValues(getOutcome(success)).Inc()
+
+	if success {
+		c.lastReloadSuccessful.Set(1)
+	} else {
+		c.lastReloadSuccessful.Set(0)
+	}
+}
+
+// RecordUnverifiedReload records an NGINX reload when it is unknown whether NGINX applied the configuration.
+// The last reload successful gauge is not changed, because the outcome is unknown.
+func (c *NginxReloadCollector) RecordUnverifiedReload() {
+	c.reloads.WithLabelValues(outcomeUnverified).Inc()
+}
+
+// RecordRollback records the outcome of a rollback to the previous NGINX configuration.
+func (c *NginxReloadCollector) RecordRollback(success bool) {
+	c.rollbacks.WithLabelValues(getOutcome(success)).Inc()
+}
+
+// RecordConfigHash records the hash of the configuration used by NGINX. It replaces the previously recorded hash.
+func (c *NginxReloadCollector) RecordConfigHash(hash string) {
+	c.configInfo.Reset()
+	c.configInfo.WithLabelValues(hash).Set(1)
+}
+
+// Describe implements prometheus.Collector.
+func (c *NginxReloadCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.reloads.Describe(ch)
+	c.rollbacks.Describe(ch)
+	c.lastReloadSuccessful.Describe(ch)
+	c.configInfo.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *NginxReloadCollector) Collect(ch chan<- prometheus.Metric) {
+	c.reloads.Collect(ch)
+	c.rollbacks.Collect(ch)
+	c.lastReloadSuccessful.Collect(ch)
+	c.configInfo.Collect(ch)
+}
+
+func getOutcome(success bool) string {
+	if success {
+		return outcomeSuccess
+	}
+	return outcomeFailure
+}
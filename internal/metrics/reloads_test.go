@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNginxReloadCollector(t *testing.T) {
+	collector := NewNginxReloadCollector()
+
+	collector.RecordReload(true)
+	collector.RecordReload(true)
+	collector.RecordReload(false)
+	collector.RecordUnverifiedReload()
+	collector.RecordRollback(true)
+	collector.RecordConfigHash("abc")
+	collector.RecordConfigHash("def")
+
+	expected := `
+# HELP nginx_kubernetes_gateway_nginx_config_info Information about the NGINX configuration used by NGINX. The value is always 1.
+# TYPE nginx_kubernetes_gateway_nginx_config_info gauge
+nginx_kubernetes_gateway_nginx_config_info{hash="def"} 1
+# HELP nginx_kubernetes_gateway_nginx_config_rollbacks_total Number of rollbacks to the previous NGINX configuration after a failed reload by outcome.
+# TYPE nginx_kubernetes_gateway_nginx_config_rollbacks_total counter
+nginx_kubernetes_gateway_nginx_config_rollbacks_total{outcome="success"} 1
+# HELP nginx_kubernetes_gateway_nginx_last_reload_successful Whether the last NGINX reload was successful (1) or not (0).
+# TYPE nginx_kubernetes_gateway_nginx_last_reload_successful gauge
+nginx_kubernetes_gateway_nginx_last_reload_successful 0
+# HELP nginx_kubernetes_gateway_nginx_reloads_total Number of NGINX reloads by outcome.
+# TYPE nginx_kubernetes_gateway_nginx_reloads_total counter
+nginx_kubernetes_gateway_nginx_reloads_total{outcome="failure"} 1
+nginx_kubernetes_gateway_nginx_reloads_total{outcome="success"} 2
+nginx_kubernetes_gateway_nginx_reloads_total{outcome="unverified"} 1
+`
+
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Errorf("NginxReloadCollector collected unexpected metrics: %v", err)
+	}
+}
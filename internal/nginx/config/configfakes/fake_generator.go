@@ -9,16 +9,51 @@ import (
 )
 
 type FakeGenerator struct {
-	GenerateStub        func(state.Configuration) ([]byte, config.Warnings)
+	GenerateStub        func(state.Configuration) (map[string][]byte, config.Warnings)
 	generateMutex       sync.RWMutex
 	generateArgsForCall []struct {
 		arg1 state.Configuration
 	}
 	generateReturns struct {
-		result1 []byte
+		result1 map[string][]byte
 		result2 config.Warnings
 	}
 	generateReturnsOnCall map[int]struct {
+		result1 map[string][]byte
+		result2 config.Warnings
+	}
+	GenerateConfigHashStub        func(string) []byte
+	generateConfigHashMutex       sync.RWMutex
+	generateConfigHashArgsForCall []struct {
+		arg1 string
+	}
+	generateConfigHashReturns struct {
+		result1 []byte
+	}
+	generateConfigHashReturnsOnCall map[int]struct {
+		result1 []byte
+	}
+	GenerateMainStub        func(state.Configuration) []byte
+	generateMainMutex       sync.RWMutex
+	generateMainArgsForCall []struct {
+		arg1 state.Configuration
+	}
+	generateMainReturns struct {
+		result1 []byte
+	}
+	generateMainReturnsOnCall map[int]struct {
+		result1 []byte
+	}
+	GenerateStreamStub        func(state.Configuration) ([]byte, config.Warnings)
+	generateStreamMutex       sync.RWMutex
+	generateStreamArgsForCall []struct {
+		arg1 state.Configuration
+	}
+	generateStreamReturns struct {
+		result1 []byte
+		result2 config.Warnings
+	}
+	generateStreamReturnsOnCall map[int]struct {
 		result1 []byte
 		result2 config.Warnings
 	}
@@ -26,7 +61,7 @@ type FakeGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeGenerator) Generate(arg1 state.Configuration) ([]byte, config.Warnings) {
+func (fake *FakeGenerator) Generate(arg1 state.Configuration) (map[string][]byte, config.Warnings) {
 	fake.generateMutex.Lock()
 	ret, specificReturn := fake.generateReturnsOnCall[len(fake.generateArgsForCall)]
 	fake.generateArgsForCall = append(fake.generateArgsForCall, struct {
@@ -51,7 +86,7 @@ func (fake *FakeGenerator) GenerateCallCount() int {
 	return len(fake.generateArgsForCall)
 }
 
-func (fake *FakeGenerator) GenerateCalls(stub func(state.Configuration) ([]byte, config.Warnings)) {
+func (fake *FakeGenerator) GenerateCalls(stub func(state.Configuration) (map[string][]byte, config.Warnings)) {
 	fake.generateMutex.Lock()
 	defer fake.generateMutex.Unlock()
 	fake.GenerateStub = stub
@@ -64,27 +99,213 @@ func (fake *FakeGenerator) GenerateArgsForCall(i int) state.Configuration {
 	return argsForCall.arg1
 }
 
-func (fake *FakeGenerator) GenerateReturns(result1 []byte, result2 config.Warnings) {
+func (fake *FakeGenerator) GenerateReturns(result1 map[string][]byte, result2 config.Warnings) {
 	fake.generateMutex.Lock()
 	defer fake.generateMutex.Unlock()
 	fake.GenerateStub = nil
 	fake.generateReturns = struct {
-		result1 []byte
+		result1 map[string][]byte
 		result2 config.Warnings
 	}{result1, result2}
 }
 
-func (fake *FakeGenerator) GenerateReturnsOnCall(i int, result1 []byte, result2 config.Warnings) {
+func (fake *FakeGenerator) GenerateReturnsOnCall(i int, result1 map[string][]byte, result2 config.Warnings) {
 	fake.generateMutex.Lock()
 	defer fake.generateMutex.Unlock()
 	fake.GenerateStub = nil
 	if fake.generateReturnsOnCall == nil {
 		fake.generateReturnsOnCall = make(map[int]struct {
-			result1 []byte
+			result1 map[string][]byte
 			result2 config.Warnings
 		})
 	}
 	fake.generateReturnsOnCall[i] = struct {
+		result1 map[string][]byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateConfigHash(arg1 string) []byte {
+	fake.generateConfigHashMutex.Lock()
+	ret, specificReturn := fake.generateConfigHashReturnsOnCall[len(fake.generateConfigHashArgsForCall)]
+	fake.generateConfigHashArgsForCall = append(fake.generateConfigHashArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GenerateConfigHashStub
+	fakeReturns := fake.generateConfigHashReturns
+	fake.recordInvocation("GenerateConfigHash", []interface{}{arg1})
+	fake.generateConfigHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGenerator) GenerateConfigHashCallCount() int {
+	fake.generateConfigHashMutex.RLock()
+	defer fake.generateConfigHashMutex.RUnlock()
+	return len(fake.generateConfigHashArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateConfigHashCalls(stub func(string) []byte) {
+	fake.generateConfigHashMutex.Lock()
+	defer fake.generateConfigHashMutex.Unlock()
+	fake.GenerateConfigHashStub = stub
+}
+
+func (fake *FakeGenerator) GenerateConfigHashArgsForCall(i int) string {
+	fake.generateConfigHashMutex.RLock()
+	defer fake.generateConfigHashMutex.RUnlock()
+	argsForCall := fake.generateConfigHashArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGenerator) GenerateConfigHashReturns(result1 []byte) {
+	fake.generateConfigHashMutex.Lock()
+	defer fake.generateConfigHashMutex.Unlock()
+	fake.GenerateConfigHashStub = nil
+	fake.generateConfigHashReturns = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeGenerator) GenerateConfigHashReturnsOnCall(i int, result1 []byte) {
+	fake.generateConfigHashMutex.Lock()
+	defer fake.generateConfigHashMutex.Unlock()
+	fake.GenerateConfigHashStub = nil
+	if fake.generateConfigHashReturnsOnCall == nil {
+		fake.generateConfigHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+		})
+	}
+	fake.generateConfigHashReturnsOnCall[i] = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeGenerator) GenerateMain(arg1 state.Configuration) []byte {
+	fake.generateMainMutex.Lock()
+	ret, specificReturn := fake.generateMainReturnsOnCall[len(fake.generateMainArgsForCall)]
+	fake.generateMainArgsForCall = append(fake.generateMainArgsForCall, struct {
+		arg1 state.Configuration
+	}{arg1})
+	stub := fake.GenerateMainStub
+	fakeReturns := fake.generateMainReturns
+	fake.recordInvocation("GenerateMain", []interface{}{arg1})
+	fake.generateMainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGenerator) GenerateMainCallCount() int {
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	return len(fake.generateMainArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateMainCalls(stub func(state.Configuration) []byte) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = stub
+}
+
+func (fake *FakeGenerator) GenerateMainArgsForCall(i int) state.Configuration {
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	argsForCall := fake.generateMainArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGenerator) GenerateMainReturns(result1 []byte) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = nil
+	fake.generateMainReturns = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeGenerator) GenerateMainReturnsOnCall(i int, result1 []byte) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = nil
+	if fake.generateMainReturnsOnCall == nil {
+		fake.generateMainReturnsOnCall = make(map[int]struct {
+			result1 []byte
+		})
+	}
+	fake.generateMainReturnsOnCall[i] = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeGenerator) GenerateStream(arg1 state.Configuration) ([]byte, config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	ret, specificReturn := fake.generateStreamReturnsOnCall[len(fake.generateStreamArgsForCall)]
+	fake.generateStreamArgsForCall = append(fake.generateStreamArgsForCall, struct {
+		arg1 state.Configuration
+	}{arg1})
+	stub := fake.GenerateStreamStub
+	fakeReturns := fake.generateStreamReturns
+	fake.recordInvocation("GenerateStream", []interface{}{arg1})
+	fake.generateStreamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGenerator) GenerateStreamCallCount() int {
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
+	return len(fake.generateStreamArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateStreamCalls(stub func(state.Configuration) ([]byte, config.Warnings)) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = stub
+}
+
+func (fake *FakeGenerator) GenerateStreamArgsForCall(i int) state.Configuration {
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
+	argsForCall := fake.generateStreamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGenerator) GenerateStreamReturns(result1 []byte, result2 config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = nil
+	fake.generateStreamReturns = struct {
+		result1 []byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateStreamReturnsOnCall(i int, result1 []byte, result2 config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = nil
+	if fake.generateStreamReturnsOnCall == nil {
+		fake.generateStreamReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 config.Warnings
+		})
+	}
+	fake.generateStreamReturnsOnCall[i] = struct {
 		result1 []byte
 		result2 config.Warnings
 	}{result1, result2}
@@ -95,6 +316,12 @@ func (fake *FakeGenerator) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.generateMutex.RLock()
 	defer fake.generateMutex.RUnlock()
+	fake.generateConfigHashMutex.RLock()
+	defer fake.generateConfigHashMutex.RUnlock()
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
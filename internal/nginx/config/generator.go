@@ -4,23 +4,72 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // nginx502Server is used as a backend for services that cannot be resolved (have no IP address).
 const nginx502Server = "unix:/var/lib/nginx/nginx-502-server.sock"
 
+// nginxStreamCloseServer is used as a backend in the stream context for connections that cannot be routed to a
+// service. Nothing listens on the socket, so NGINX closes such connections.
+const nginxStreamCloseServer = "unix:/var/lib/nginx/nginx-stream-close-server.sock"
+
+// The variables that hold the HTTPRoute and the backend of a request. They are available in the access logs.
+const (
+	routeVariable   = "$gateway_route"
+	backendVariable = "$gateway_backend"
+)
+
+// configHashVariable holds the hash of the NGINX configuration. It is defined in the config hash config.
+const configHashVariable = "$gateway_config_hash"
+
+// configHashHeader is the response header with the hash of the NGINX configuration.
+const configHashHeader = "X-Gateway-Config-Hash"
+
+// jsonLogFormatName is the name of the log format of the json built-in format of an access log.
+const jsonLogFormatName = "gateway_json"
+
+// jsonLogFormat includes the request, the response, the HTTPRoute and the backend of a request.
+const jsonLogFormat = `{"time":"$time_iso8601","remote_addr":"$remote_addr","request_id":"$request_id",` +
+	`"host":"$host","request":"$request","status":"$status","body_bytes_sent":"$body_bytes_sent",` +
+	`"request_time":"$request_time","http_referer":"$http_referer","http_user_agent":"$http_user_agent",` +
+	`"route":"` + routeVariable + `","backend":"` + backendVariable + `","upstream_addr":"$upstream_addr",` +
+	`"upstream_status":"$upstream_status","upstream_response_time":"$upstream_response_time"}`
+
+// mapsConfigName is the name of the config with the maps of the http context.
+const mapsConfigName = "maps"
+
+// ConfigHashConfigName is the name of the http config generated by GenerateConfigHash. It doesn't conflict with
+// the names of the configs generated by Generate.
+const ConfigHashConfigName = "config-hash"
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Generator
 
 // Generator generates NGINX configuration.
 type Generator interface {
-	// Generate generates NGINX configuration from internal representation.
-	Generate(configuration state.Configuration) ([]byte, Warnings)
+	// GenerateMain generates the main NGINX configuration from internal representation.
+	GenerateMain(configuration state.Configuration) []byte
+	// Generate generates NGINX configuration for the http context from internal representation.
+	// The configuration is split into multiple configs: one per server and one for the maps.
+	// The keys of the returned map are the names of the configs, which are unique and stable across calls.
+	Generate(configuration state.Configuration) (map[string][]byte, Warnings)
+	// GenerateStream generates NGINX configuration for the stream context from internal representation.
+	GenerateStream(configuration state.Configuration) ([]byte, Warnings)
+	// GenerateConfigHash generates NGINX configuration for the http context that defines the variable with the
+	// hash of the rest of the configuration. The hash is used in the config hash header and can be used in the
+	// access logs.
+	GenerateConfigHash(hash string) []byte
 }
 
 // GeneratorImpl is an implementation of Generator
@@ -37,70 +86,481 @@ func NewGeneratorImpl(serviceStore state.ServiceStore) *GeneratorImpl {
 	}
 }
 
-func (g *GeneratorImpl) Generate(conf state.Configuration) ([]byte, Warnings) {
+func (g *GeneratorImpl) GenerateMain(conf state.Configuration) []byte {
+	return g.executor.ExecuteForMain(generateMainConfig(conf.Settings))
+}
+
+func (g *GeneratorImpl) Generate(conf state.Configuration) (map[string][]byte, Warnings) {
 	warnings := newWarnings()
 
-	confServers := append(conf.HTTPServers, conf.SSLServers...)
+	httpPorts := getPorts(conf.HTTPServers)
+	sslPorts := getPorts(conf.SSLServers)
+
+	// the configs for all the conf servers + default ssl & http servers for every port + the maps
+	cfgs := make(map[string][]byte, len(conf.HTTPServers)+len(conf.SSLServers)+len(httpPorts)+len(sslPorts)+1)
 
-	servers := httpServers{
-		// capacity is all the conf servers + default ssl & http servers
-		Servers: make([]server, 0, len(confServers)+2),
+	addServer := func(name string, s server) {
+		cfgs[getUniqueConfigName(cfgs, name)] = g.executor.ExecuteForHTTPServers(httpServers{Servers: []server{s}})
 	}
 
-	if len(conf.HTTPServers) > 0 {
-		defaultHTTPServer := generateDefaultHTTPServer()
+	for _, port := range httpPorts {
+		addServer(fmt.Sprintf("default-http-%d", port), generateDefaultHTTPServer(port))
+	}
 
-		servers.Servers = append(servers.Servers, defaultHTTPServer)
+	for _, port := range sslPorts {
+		s := generateDefaultSSLServer(port, getTLSOptionsForPort(conf.SSLServers, port), conf.Settings)
+		addServer(fmt.Sprintf("default-ssl-%d", port), s)
 	}
 
-	if len(conf.SSLServers) > 0 {
-		defaultSSLServer := generateDefaultSSLServer()
+	for _, servers := range []struct {
+		prefix  string
+		servers []state.VirtualServer
+	}{
+		{prefix: "http", servers: conf.HTTPServers},
+		{prefix: "ssl", servers: conf.SSLServers},
+	} {
+		for _, s := range servers.servers {
+			cfg, warns := generate(s, conf.Settings, g.serviceStore)
+
+			addServer(getServerConfigName(servers.prefix, s), cfg)
+			warnings.Add(warns)
+		}
+	}
+
+	cfgs[mapsConfigName] = g.executor.ExecuteForMaps(generateMaps())
+
+	return cfgs, warnings
+}
 
-		servers.Servers = append(servers.Servers, defaultSSLServer)
+func (g *GeneratorImpl) GenerateConfigHash(hash string) []byte {
+	return g.executor.ExecuteForMaps([]nginxMap{
+		{
+			Source:   "$host",
+			Variable: configHashVariable,
+			Parameters: []mapParameter{
+				{Value: "default", Result: fmt.Sprintf("%q", hash)},
+			},
+		},
+	})
+}
+
+func (g *GeneratorImpl) GenerateStream(conf state.Configuration) ([]byte, Warnings) {
+	warnings := newWarnings()
+
+	ports := getTLSPassthroughPorts(conf.TLSPassthroughServers)
+
+	maps := make([]nginxMap, 0, len(ports))
+	servers := streamServers{
+		Upstreams: make([]streamUpstream, 0, len(conf.TCPServers)+len(conf.UDPServers)),
+		Servers:   make([]streamServer, 0, len(ports)+len(conf.TCPServers)+len(conf.UDPServers)),
 	}
 
-	for _, s := range confServers {
-		cfg, warns := generate(s, g.serviceStore)
+	for _, port := range ports {
+		m, warns := generateTLSPassthroughMap(port, conf.TLSPassthroughServers, g.serviceStore)
 
-		servers.Servers = append(servers.Servers, cfg)
+		maps = append(maps, m)
 		warnings.Add(warns)
+
+		servers.Servers = append(servers.Servers, streamServer{
+			Port:       port,
+			SSLPreread: true,
+			ProxyPass:  m.Variable,
+		})
+	}
+
+	for _, s := range conf.TCPServers {
+		u, warns := generateTCPUpstream(s, g.serviceStore)
+
+		servers.Upstreams = append(servers.Upstreams, u)
+		warnings.Add(warns)
+
+		servers.Servers = append(servers.Servers, streamServer{
+			Port:      s.Port,
+			ProxyPass: u.Name,
+		})
+	}
+
+	for _, s := range conf.UDPServers {
+		u, warns := generateUDPUpstream(s, g.serviceStore)
+
+		servers.Upstreams = append(servers.Upstreams, u)
+		warnings.Add(warns)
+
+		servers.Servers = append(servers.Servers, generateUDPServer(s.Port, u.Name, conf.Settings))
+	}
+
+	return append(g.executor.ExecuteForMaps(maps), g.executor.ExecuteForStreamServers(servers)...), warnings
+}
+
+// getTLSPassthroughPorts returns the unique ports of the servers in the order of their first appearance.
+func getTLSPassthroughPorts(tlsServers []state.TLSPassthroughServer) []int32 {
+	var ports []int32
+	seen := make(map[int32]struct{})
+
+	for _, s := range tlsServers {
+		if _, exist := seen[s.Port]; exist {
+			continue
+		}
+
+		seen[s.Port] = struct{}{}
+		ports = append(ports, s.Port)
 	}
 
-	return g.executor.ExecuteForHTTPServers(servers), warnings
+	return ports
 }
 
-func generateDefaultSSLServer() server {
-	return server{IsDefaultSSL: true}
+// generateTLSPassthroughMap generates the map that chooses the backend address for a connection on the port
+// based on the SNI. The connections with an unknown SNI are closed.
+func generateTLSPassthroughMap(
+	port int32,
+	tlsServers []state.TLSPassthroughServer,
+	serviceStore state.ServiceStore,
+) (nginxMap, Warnings) {
+	warnings := newWarnings()
+
+	m := nginxMap{
+		Source:    "$ssl_preread_server_name",
+		Variable:  fmt.Sprintf("$tls_passthrough_backend_%d", port),
+		Hostnames: true,
+		Parameters: []mapParameter{
+			{Value: "default", Result: nginxStreamCloseServer},
+		},
+	}
+
+	for _, s := range tlsServers {
+		if s.Port != port {
+			continue
+		}
+
+		var refs []v1alpha2.BackendRef
+		if len(s.Source.Spec.Rules) > 0 {
+			// FIXME(pleshakov): for now, we only support a single rule
+			refs = s.Source.Spec.Rules[0].BackendRefs
+		}
+
+		address, err := getStreamBackendAddress(refs, s.Source.Namespace, serviceStore)
+		if err != nil {
+			warnings.AddWarning(s.Source, err.Error())
+			address = nginxStreamCloseServer
+		}
+
+		m.Parameters = append(m.Parameters, mapParameter{Value: s.Hostname, Result: address})
+	}
+
+	return m, warnings
 }
 
-func generateDefaultHTTPServer() server {
-	return server{IsDefaultHTTP: true}
+// generateTCPUpstream generates the upstream with the backends of the TCPRoute of the server.
+func generateTCPUpstream(tcpServer state.TCPServer, serviceStore state.ServiceStore) (streamUpstream, Warnings) {
+	var refs []v1alpha2.BackendRef
+	if len(tcpServer.Source.Spec.Rules) > 0 {
+		// FIXME(pleshakov): for now, we only support a single rule
+		refs = tcpServer.Source.Spec.Rules[0].BackendRefs
+	}
+
+	return generateStreamUpstream(fmt.Sprintf("tcp_backend_%d", tcpServer.Port), refs, tcpServer.Source, serviceStore)
 }
 
-func generate(virtualServer state.VirtualServer, serviceStore state.ServiceStore) (server, Warnings) {
+// generateUDPUpstream generates the upstream with the backends of the UDPRoute of the server.
+func generateUDPUpstream(udpServer state.UDPServer, serviceStore state.ServiceStore) (streamUpstream, Warnings) {
+	var refs []v1alpha2.BackendRef
+	if len(udpServer.Source.Spec.Rules) > 0 {
+		// FIXME(pleshakov): for now, we only support a single rule
+		refs = udpServer.Source.Spec.Rules[0].BackendRefs
+	}
+
+	return generateStreamUpstream(fmt.Sprintf("udp_backend_%d", udpServer.Port), refs, udpServer.Source, serviceStore)
+}
+
+// generateStreamUpstream generates the upstream with the backends of a route.
+// The backends that cannot be resolved are skipped. If no backend is left, the connections are closed.
+func generateStreamUpstream(
+	name string,
+	refs []v1alpha2.BackendRef,
+	route client.Object,
+	serviceStore state.ServiceStore,
+) (streamUpstream, Warnings) {
+	warnings := newWarnings()
+
+	u := streamUpstream{
+		Name: name,
+	}
+
+	for _, ref := range refs {
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+
+		// a backend with zero weight must not receive any connections
+		if weight == 0 {
+			continue
+		}
+
+		backendRef := convertBackendObjectReference(ref.BackendObjectReference)
+
+		address, err := resolveBackendRef(backendRef, route.GetNamespace(), serviceStore)
+		if err != nil {
+			warnings.AddWarning(route, err.Error())
+			continue
+		}
+
+		u.Servers = append(u.Servers, upstreamServer{Address: address, Weight: weight})
+	}
+
+	if len(u.Servers) == 0 {
+		u.Servers = []upstreamServer{{Address: nginxStreamCloseServer, Weight: 1}}
+	}
+
+	return u, warnings
+}
+
+// generateUDPServer generates the server that proxies UDP datagrams on the port to the upstream.
+func generateUDPServer(port int32, upstreamName string, settings state.Settings) streamServer {
+	s := streamServer{
+		Port:         port,
+		UDP:          true,
+		ProxyPass:    upstreamName,
+		ProxyTimeout: generateTime(settings.UDPProxyTimeout),
+	}
+
+	if settings.UDPProxyResponses != nil {
+		s.ProxyResponses = strconv.Itoa(int(*settings.UDPProxyResponses))
+	}
+
+	return s
+}
+
+// generateMainConfig generates the main config, which holds the global NGINX settings.
+func generateMainConfig(settings state.Settings) mainConfig {
+	var cfg mainConfig
+
+	if settings.WorkerProcesses != nil {
+		cfg.WorkerProcesses = strconv.Itoa(*settings.WorkerProcesses)
+	}
+
+	cfg.LogFormats, cfg.AccessLogs = generateAccessLogs(settings.AccessLogs)
+
+	if settings.ConfigHashHeader {
+		cfg.ConfigHashHeader = &header{Name: configHashHeader, Value: configHashVariable}
+	}
+
+	return cfg
+}
+
+// generateAccessLogs generates the access logs and the log formats they use.
+// The access logs are expected to be validated.
+func generateAccessLogs(logs []state.AccessLog) ([]logFormat, []accessLog) {
+	if len(logs) == 0 {
+		return nil, nil
+	}
+
+	var formats []logFormat
+	accessLogs := make([]accessLog, 0, len(logs))
+
+	jsonFormatAdded := false
+
+	for i, l := range logs {
+		var name string
+
+		switch l.Format {
+		case nginxgwv1alpha1.AccessLogFormatCombined:
+			// combined is predefined by NGINX
+			name = l.Format
+		case nginxgwv1alpha1.AccessLogFormatJSON:
+			name = jsonLogFormatName
+			if !jsonFormatAdded {
+				formats = append(formats, logFormat{Name: name, Escape: "json", Format: jsonLogFormat})
+				jsonFormatAdded = true
+			}
+		default:
+			name = fmt.Sprintf("gateway_custom_%d", i)
+			formats = append(formats, logFormat{Name: name, Format: l.Format})
+		}
+
+		accessLogs = append(accessLogs, accessLog{
+			Destination: generateAccessLogDestination(l.Destination),
+			FormatName:  name,
+		})
+	}
+
+	return formats, accessLogs
+}
+
+func generateAccessLogDestination(dest string) string {
+	switch dest {
+	case state.AccessLogDestinationStdout:
+		return "/dev/stdout"
+	case state.AccessLogDestinationStderr:
+		return "/dev/stderr"
+	default:
+		return dest
+	}
+}
+
+// generateMaps generates the maps for the http context.
+func generateMaps() []nginxMap {
+	return []nginxMap{
+		// The connection upgrade map allows proxying WebSocket and other upgraded connections.
+		// See https://nginx.org/en/docs/http/websocket.html
+		{
+			Source:   "$http_upgrade",
+			Variable: "$connection_upgrade",
+			Parameters: []mapParameter{
+				{Value: "default", Result: "upgrade"},
+				{Value: "''", Result: "close"},
+			},
+		},
+		// The route and backend variables are set in the locations generated from HTTPRoutes.
+		// The maps define them as empty for the other requests, so that logging them doesn't cause warnings.
+		{
+			Source:   "$host",
+			Variable: routeVariable,
+			Parameters: []mapParameter{
+				{Value: "default", Result: "''"},
+			},
+		},
+		{
+			Source:   "$host",
+			Variable: backendVariable,
+			Parameters: []mapParameter{
+				{Value: "default", Result: "''"},
+			},
+		},
+	}
+}
+
+// getPorts returns the unique ports of the servers in the order of their first appearance.
+func getPorts(virtualServers []state.VirtualServer) []int32 {
+	var ports []int32
+	seen := make(map[int32]struct{})
+
+	for _, s := range virtualServers {
+		if _, exist := seen[s.Port]; exist {
+			continue
+		}
+
+		seen[s.Port] = struct{}{}
+		ports = append(ports, s.Port)
+	}
+
+	return ports
+}
+
+// getTLSOptionsForPort returns the TLS options of the first SSL server on the port.
+// All SSL servers on the same port have the same TLS protocols and ciphers: the listeners with conflicting
+// ones are invalid.
+func getTLSOptionsForPort(sslServers []state.VirtualServer, port int32) state.TLSOptions {
+	for _, s := range sslServers {
+		if s.Port == port && s.SSL != nil {
+			return s.SSL.Options
+		}
+	}
+
+	return state.TLSOptions{}
+}
+
+// generateDefaultSSLServer generates the default SSL server of the port. NGINX negotiates the TLS protocol and
+// the cipher in the default server before it selects the server by SNI, so the default server gets the TLS
+// protocols and ciphers of the port.
+func generateDefaultSSLServer(port int32, opts state.TLSOptions, settings state.Settings) server {
+	return server{
+		IsDefaultSSL: true,
+		Port:         port,
+		HTTP2:        !settings.DisableHTTP2,
+		SSL: &ssl{
+			Protocols: strings.Join(opts.Protocols, " "),
+			Ciphers:   opts.Ciphers,
+		},
+	}
+}
+
+func generateSSL(certs []sslCertificate, opts state.TLSOptions) *ssl {
+	s := &ssl{
+		Certificates:   certs,
+		Protocols:      strings.Join(opts.Protocols, " "),
+		Ciphers:        opts.Ciphers,
+		SessionCache:   opts.SessionCache,
+		SessionTimeout: opts.SessionTimeout,
+	}
+
+	if opts.ClientCertificatePath != "" {
+		s.ClientCertificate = opts.ClientCertificatePath
+		s.VerifyClient = opts.VerifyClient
+		s.ForwardClientCertificate = opts.ForwardClientCertificate
+
+		if opts.VerifyDepth != nil {
+			s.VerifyDepth = strconv.Itoa(int(*opts.VerifyDepth))
+		}
+	}
+
+	if opts.PreferServerCiphers != nil {
+		s.PreferServerCiphers = "off"
+		if *opts.PreferServerCiphers {
+			s.PreferServerCiphers = "on"
+		}
+	}
+
+	return s
+}
+
+func generateDefaultHTTPServer(port int32) server {
+	return server{IsDefaultHTTP: true, Port: port}
+}
+
+func generate(
+	virtualServer state.VirtualServer,
+	settings state.Settings,
+	serviceStore state.ServiceStore,
+) (server, Warnings) {
 	warnings := newWarnings()
 
-	s := server{ServerName: virtualServer.Hostname}
+	s := server{
+		ServerName: virtualServer.Hostname,
+		Port:       virtualServer.Port,
+	}
 
-	listenerPort := 80
+	listenerPort := int(virtualServer.Port)
 
 	if virtualServer.SSL != nil {
-		s.SSL = &ssl{
-			Certificate:    virtualServer.SSL.CertificatePath,
-			CertificateKey: virtualServer.SSL.CertificatePath,
+		certs := make([]sslCertificate, 0, len(virtualServer.SSL.Certificates))
+		for _, c := range virtualServer.SSL.Certificates {
+			certs = append(certs, sslCertificate{Certificate: c.Certificate, CertificateKey: c.Key})
 		}
 
-		listenerPort = 443
+		s.SSL = generateSSL(certs, virtualServer.SSL.Options)
+		// the setting applies to all SSL servers, so the servers on the same port always agree on it
+		s.HTTP2 = !settings.DisableHTTP2
 	}
 
 	if len(virtualServer.PathRules) == 0 {
 		// generate default "/" 404 location
 		s.Locations = []location{{Path: "/", Return: &returnVal{Code: statusNotFound}}}
-		return s, warnings
+	} else {
+		locs, warns := generateLocations(virtualServer.PathRules, listenerPort, settings, serviceStore)
+
+		s.Locations = locs
+		warnings.Add(warns)
 	}
 
-	locs := make([]location, 0, len(virtualServer.PathRules)) // FIXME(pleshakov): expand with rule.Routes
-	for _, rule := range virtualServer.PathRules {
+	grpcLocs, warns := generateGRPCLocations(virtualServer.GRPCMethodRules, settings, serviceStore)
+
+	s.Locations = append(s.Locations, grpcLocs...)
+	warnings.Add(warns)
+
+	return s, warnings
+}
+
+func generateLocations(
+	pathRules []state.PathRule,
+	listenerPort int,
+	settings state.Settings,
+	serviceStore state.ServiceStore,
+) ([]location, Warnings) {
+	warnings := newWarnings()
+
+	locs := make([]location, 0, len(pathRules)) // FIXME(pleshakov): expand with rule.Routes
+	for _, rule := range pathRules {
 		matches := make([]httpMatch, 0, len(rule.MatchRules))
 
 		for ruleIdx, r := range rule.MatchRules {
@@ -127,39 +587,112 @@ func generate(virtualServer state.VirtualServer, serviceStore state.ServiceStore
 			// Consider reporting an error. But that should be done in a separate validation layer.
 
 			// RequestRedirect and proxying are mutually exclusive.
+			loc.Route = fmt.Sprintf("%s/%s", r.Source.Namespace, r.Source.Name)
+
 			if r.Filters.RequestRedirect != nil {
 				loc.Return = generateReturnValForRedirectFilter(r.Filters.RequestRedirect, listenerPort)
 			} else {
-				address, err := getBackendAddress(r.Source.Spec.Rules[r.RuleIdx].BackendRefs, r.Source.Namespace, serviceStore)
+				refs := r.Source.Spec.Rules[r.RuleIdx].BackendRefs
+
+				address, err := getBackendAddress(refs, r.Source.Namespace, serviceStore)
 				if err != nil {
 					warnings.AddWarning(r.Source, err.Error())
 				}
 
+				loc.Backend = generateBackendName(refs, r.Source.Namespace)
+
 				loc.ProxyPass = generateProxyPass(address)
+				loc.ProxyReadTimeout = generateTime(settings.ProxyReadTimeout)
+				loc.ProxySendTimeout = generateTime(settings.ProxySendTimeout)
 			}
 
 			locs = append(locs, loc)
 		}
 
 		if len(matches) > 0 {
-			b, err := json.Marshal(matches)
-			if err != nil {
-				// panic is safe here because we should never fail to marshal the match unless we constructed it incorrectly.
-				panic(fmt.Errorf("could not marshal http match: %w", err))
-			}
-
 			pathLoc := location{
 				Path:         rule.Path,
-				HTTPMatchVar: string(b),
+				HTTPMatchVar: marshalHTTPMatches(matches),
 			}
 
 			locs = append(locs, pathLoc)
 		}
 	}
 
-	s.Locations = locs
+	return locs, warnings
+}
 
-	return s, warnings
+// generateGRPCLocations generates the locations for the gRPC method rules. A gRPC request is an HTTP/2 POST request
+// to /SERVICE/METHOD, so a rule with both the service and the method becomes an exact location, while a rule that
+// matches any service or any method becomes a regex location. The header matches are handled by the httpmatches
+// module. Unlike for the HTTP routing rules, it redirects a request to a named location, because NGINX passes
+// the URI of a named location, which is the original gRPC method, to the backend.
+func generateGRPCLocations(
+	rules []state.GRPCMethodRule,
+	settings state.Settings,
+	serviceStore state.ServiceStore,
+) ([]location, Warnings) {
+	warnings := newWarnings()
+
+	locs := make([]location, 0, len(rules))
+	for methodIdx, rule := range rules {
+		path := createGRPCLocationPath(rule.Service, rule.Method)
+		matches := make([]httpMatch, 0, len(rule.MatchRules))
+
+		for matchIdx, r := range rule.MatchRules {
+			m := r.GetMatch()
+
+			var loc location
+
+			// generate a standard location block without http_matches for the only rule without header matches
+			if len(rule.MatchRules) == 1 && len(m.Headers) == 0 {
+				loc = location{
+					Path: path,
+				}
+			} else {
+				name := createNamedLocationForGRPCMatch(methodIdx, matchIdx)
+				loc = location{
+					Path: name,
+				}
+				matches = append(matches, createGRPCMatch(m, name))
+			}
+
+			refs := convertGRPCBackendRefs(r.Source.Spec.Rules[r.RuleIdx].BackendRefs)
+
+			address, err := getBackendAddress(refs, r.Source.Namespace, serviceStore)
+			if err != nil {
+				warnings.AddWarning(r.Source, err.Error())
+			}
+
+			loc.Route = fmt.Sprintf("%s/%s", r.Source.Namespace, r.Source.Name)
+			loc.Backend = generateBackendName(refs, r.Source.Namespace)
+
+			loc.GRPCPass = generateGRPCPass(address)
+			loc.ProxyReadTimeout = generateTime(settings.ProxyReadTimeout)
+			loc.ProxySendTimeout = generateTime(settings.ProxySendTimeout)
+
+			locs = append(locs, loc)
+		}
+
+		if len(matches) > 0 {
+			locs = append(locs, location{
+				Path:         path,
+				HTTPMatchVar: marshalHTTPMatches(matches),
+			})
+		}
+	}
+
+	return locs, warnings
+}
+
+func marshalHTTPMatches(matches []httpMatch) string {
+	b, err := json.Marshal(matches)
+	if err != nil {
+		// panic is safe here because we should never fail to marshal the match unless we constructed it incorrectly.
+		panic(fmt.Errorf("could not marshal http match: %w", err))
+	}
+
+	return string(b)
 }
 
 func generateProxyPass(address string) string {
@@ -169,6 +702,28 @@ func generateProxyPass(address string) string {
 	return "http://" + address
 }
 
+func generateGRPCPass(address string) string {
+	if address == "" {
+		return "grpc://" + nginx502Server
+	}
+	return "grpc://" + address
+}
+
+// generateTime converts a duration to the NGINX time format. For example, 1m30s becomes 90s.
+// Zero is converted to an empty string, which means the corresponding directive is not generated.
+func generateTime(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+
+	// round up, so that a sub-millisecond duration doesn't become zero.
+	return fmt.Sprintf("%dms", (d+time.Millisecond-1)/time.Millisecond)
+}
+
 func generateReturnValForRedirectFilter(filter *v1beta1.HTTPRequestRedirectFilter, listenerPort int) *returnVal {
 	if filter == nil {
 		return nil
@@ -214,8 +769,62 @@ func getBackendAddress(
 	}
 
 	// FIXME(pleshakov): for now, we only support a single backend reference
-	ref := refs[0].BackendRef
+	return resolveBackendRef(refs[0].BackendObjectReference, parentNS, serviceStore)
+}
+
+// generateBackendName generates the name of the backend in the form NAMESPACE/NAME:PORT.
+// It returns an empty string if there are no backend refs.
+func generateBackendName(refs []v1beta1.HTTPBackendRef, parentNS string) string {
+	if len(refs) == 0 {
+		return ""
+	}
+
+	// FIXME(pleshakov): for now, we only support a single backend reference
+	ref := refs[0].BackendObjectReference
 
+	ns := parentNS
+	if ref.Namespace != nil {
+		ns = string(*ref.Namespace)
+	}
+
+	name := fmt.Sprintf("%s/%s", ns, ref.Name)
+	if ref.Port != nil {
+		name = fmt.Sprintf("%s:%d", name, *ref.Port)
+	}
+
+	return name
+}
+
+func getStreamBackendAddress(
+	refs []v1alpha2.BackendRef,
+	parentNS string,
+	serviceStore state.ServiceStore,
+) (string, error) {
+	if len(refs) == 0 {
+		return "", errors.New("empty backend refs")
+	}
+
+	// FIXME(pleshakov): for now, we only support a single backend reference
+	return resolveBackendRef(convertBackendObjectReference(refs[0].BackendObjectReference), parentNS, serviceStore)
+}
+
+// convertBackendObjectReference converts a v1alpha2.BackendObjectReference, which has the same fields as
+// v1beta1.BackendObjectReference.
+func convertBackendObjectReference(ref v1alpha2.BackendObjectReference) v1beta1.BackendObjectReference {
+	return v1beta1.BackendObjectReference{
+		Group:     (*v1beta1.Group)(ref.Group),
+		Kind:      (*v1beta1.Kind)(ref.Kind),
+		Name:      v1beta1.ObjectName(ref.Name),
+		Namespace: (*v1beta1.Namespace)(ref.Namespace),
+		Port:      (*v1beta1.PortNumber)(ref.Port),
+	}
+}
+
+func resolveBackendRef(
+	ref v1beta1.BackendObjectReference,
+	parentNS string,
+	serviceStore state.ServiceStore,
+) (string, error) {
 	if ref.Kind != nil && *ref.Kind != "Service" {
 		return "", fmt.Errorf("unsupported kind %s", *ref.Kind)
 	}
@@ -237,6 +846,22 @@ func getBackendAddress(
 	return fmt.Sprintf("%s:%d", address, *ref.Port), nil
 }
 
+// convertGRPCBackendRefs converts the backend refs of a GRPCRoute rule to the backend refs of an HTTPRoute rule.
+// The filters of the backend refs are not supported, so they are dropped.
+func convertGRPCBackendRefs(refs []v1alpha2.GRPCBackendRef) []v1beta1.HTTPBackendRef {
+	if refs == nil {
+		return nil
+	}
+
+	result := make([]v1beta1.HTTPBackendRef, 0, len(refs))
+
+	for _, ref := range refs {
+		result = append(result, v1beta1.HTTPBackendRef{BackendRef: ref.BackendRef})
+	}
+
+	return result
+}
+
 func generateMatchLocation(path string) location {
 	return location{
 		Path:     path,
@@ -248,6 +873,30 @@ func createPathForMatch(path string, routeIdx int) string {
 	return fmt.Sprintf("%s_route%d", path, routeIdx)
 }
 
+// createGRPCLocationPath creates the location path for a gRPC service and method. An empty service or method
+// matches any service or method.
+func createGRPCLocationPath(service, method string) string {
+	if service != "" && method != "" {
+		return fmt.Sprintf("= /%s/%s", service, method)
+	}
+
+	serviceRegex := "[^/]+"
+	if service != "" {
+		serviceRegex = regexp.QuoteMeta(service)
+	}
+
+	methodRegex := "[^/]+"
+	if method != "" {
+		methodRegex = regexp.QuoteMeta(method)
+	}
+
+	return fmt.Sprintf("~ ^/%s/%s$", serviceRegex, methodRegex)
+}
+
+func createNamedLocationForGRPCMatch(methodIdx int, matchIdx int) string {
+	return fmt.Sprintf("@grpc%d_route%d", methodIdx, matchIdx)
+}
+
 // httpMatch is an internal representation of an HTTPRouteMatch.
 // This struct is marshaled into a string and stored as a variable in the nginx location block for the route's path.
 // The NJS httpmatches module will lookup this variable on the request object and compare the request against the Method, Headers, and QueryParams contained in httpMatch.
@@ -313,6 +962,40 @@ func createHTTPMatch(match v1beta1.HTTPRouteMatch, redirectPath string) httpMatc
 	return hm
 }
 
+// createGRPCMatch creates the httpMatch for a GRPCRouteMatch. The service and the method are matched by
+// the location, so only the headers are matched by the httpmatches module.
+func createGRPCMatch(match v1alpha2.GRPCRouteMatch, redirectPath string) httpMatch {
+	hm := httpMatch{
+		RedirectPath: redirectPath,
+	}
+
+	if len(match.Headers) == 0 {
+		hm.Any = true
+		return hm
+	}
+
+	headers := make([]string, 0, len(match.Headers))
+	headerNames := make(map[string]struct{})
+
+	// FIXME(kate-osborn): For now we only support type "Exact".
+	for _, h := range match.Headers {
+		if h.Type != nil && *h.Type != v1beta1.HeaderMatchExact {
+			continue
+		}
+
+		// duplicate header names are not permitted by the spec
+		// only configure the first entry for every header name (case-insensitive)
+		lowerName := strings.ToLower(string(h.Name))
+		if _, ok := headerNames[lowerName]; !ok {
+			headers = append(headers, string(h.Name)+":"+h.Value)
+			headerNames[lowerName] = struct{}{}
+		}
+	}
+	hm.Headers = headers
+
+	return hm
+}
+
 // The name and values are delimited by "=". A name and value can always be recovered using strings.SplitN(arg,"=", 2).
 // Query Parameters are case-sensitive so case is preserved.
 func createQueryParamKeyValString(p v1beta1.HTTPQueryParamMatch) string {
@@ -329,3 +1012,32 @@ func createHeaderKeyValString(h v1beta1.HTTPHeaderMatch) string {
 func isPathOnlyMatch(match v1beta1.HTTPRouteMatch) bool {
 	return match.Method == nil && match.Headers == nil && match.QueryParams == nil
 }
+
+// getServerConfigName returns the name of the config of the server. The name includes the Gateway of the server,
+// because each Gateway has its own servers on a port. The characters of the hostname that are not
+// allowed in the name (for example, '*' of a wildcard hostname) are replaced with '_'.
+func getServerConfigName(prefix string, s state.VirtualServer) string {
+	hostname := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(s.Hostname))
+
+	return fmt.Sprintf("%s-%s_%s-%s-%d", prefix, s.Gateway.Namespace, s.Gateway.Name, hostname, s.Port)
+}
+
+// getUniqueConfigName returns the name if no config has it. Otherwise, it returns the name with the lowest
+// numeric suffix that no config has.
+func getUniqueConfigName(cfgs map[string][]byte, name string) string {
+	if _, exist := cfgs[name]; !exist {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		n := fmt.Sprintf("%s-%d", name, i)
+		if _, exist := cfgs[n]; !exist {
+			return n
+		}
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
@@ -17,6 +20,74 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
 )
 
+// joinConfigs joins the configs in the order of their names, so that a test can check them as a whole.
+func joinConfigs(cfgs map[string][]byte) []byte {
+	names := make([]string, 0, len(cfgs))
+	for name := range cfgs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var result []byte
+	for _, name := range names {
+		result = append(result, cfgs[name]...)
+	}
+
+	return result
+}
+
+func TestGenerateConfigNames(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
+	conf := state.Configuration{
+		HTTPServers: []state.VirtualServer{
+			{Gateway: gwNsName, Hostname: "example.com", Port: 80},
+			{Gateway: otherGwNsName, Hostname: "*.example.com", Port: 8080},
+		},
+		SSLServers: []state.VirtualServer{
+			{Gateway: gwNsName, Hostname: "example.com", Port: 443},
+			{Gateway: otherGwNsName, Hostname: "foo.example.com", Port: 443},
+			{Gateway: gwNsName, Hostname: "~^", Port: 443},
+			{Gateway: gwNsName, Hostname: "~^", Port: 443},
+		},
+	}
+
+	cfgs, _ := generator.Generate(conf)
+
+	expected := map[string]string{
+		"maps":                             "map $http_upgrade $connection_upgrade {",
+		"default-http-80":                  "listen 80 default_server;",
+		"default-http-8080":                "listen 8080 default_server;",
+		"default-ssl-443":                  "listen 443 ssl http2 default_server;",
+		"http-test_gateway-example.com-80": "server_name example.com;",
+		"http-test_other-gateway-_.example.com-8080": "server_name *.example.com;",
+		"ssl-test_gateway-example.com-443":           "server_name example.com;",
+		"ssl-test_other-gateway-foo.example.com-443": "server_name foo.example.com;",
+		"ssl-test_gateway-__-443":                    "server_name ~^;",
+		"ssl-test_gateway-__-443-2":                  "server_name ~^;",
+	}
+
+	if len(cfgs) != len(expected) {
+		t.Errorf("Generate() returned %d configs but expected %d", len(cfgs), len(expected))
+	}
+
+	for name, directive := range expected {
+		cfg, exist := cfgs[name]
+		if !exist {
+			t.Errorf("Generate() didn't generate the config %q", name)
+			continue
+		}
+
+		if !strings.Contains(string(cfg), directive) {
+			t.Errorf("Generate() generated the config %q without %q:\n%s", name, directive, cfg)
+		}
+	}
+}
+
 func TestGenerateForHost(t *testing.T) {
 	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
 
@@ -37,6 +108,7 @@ func TestGenerateForHost(t *testing.T) {
 				HTTPServers: []state.VirtualServer{
 					{
 						Hostname: "example.com",
+						Port:     80,
 					},
 				},
 			},
@@ -49,6 +121,7 @@ func TestGenerateForHost(t *testing.T) {
 				SSLServers: []state.VirtualServer{
 					{
 						Hostname: "example.com",
+						Port:     443,
 					},
 				},
 			},
@@ -61,11 +134,13 @@ func TestGenerateForHost(t *testing.T) {
 				HTTPServers: []state.VirtualServer{
 					{
 						Hostname: "example.com",
+						Port:     80,
 					},
 				},
 				SSLServers: []state.VirtualServer{
 					{
 						Hostname: "example.com",
+						Port:     443,
 					},
 				},
 			},
@@ -76,9 +151,10 @@ func TestGenerateForHost(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		cfg, warnings := generator.Generate(tc.conf)
+		cfgs, warnings := generator.Generate(tc.conf)
+		cfg := joinConfigs(cfgs)
 
-		defaultSSLExists := strings.Contains(string(cfg), "listen 443 ssl default_server")
+		defaultSSLExists := strings.Contains(string(cfg), "listen 443 ssl http2 default_server")
 		defaultHTTPExists := strings.Contains(string(cfg), "listen 80 default_server")
 
 		if tc.sslDefault && !defaultSSLExists {
@@ -106,6 +182,344 @@ func TestGenerateForHost(t *testing.T) {
 	}
 }
 
+func TestGenerateMultipleCertificates(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	conf := state.Configuration{
+		SSLServers: []state.VirtualServer{
+			{
+				Hostname: "example.com",
+				Port:     443,
+				SSL: &state.SSL{
+					Certificates: []state.CertificateFiles{
+						{Certificate: "/etc/nginx/secrets/test_rsa.crt", Key: "/etc/nginx/secrets/test_rsa.key"},
+						{Certificate: "/etc/nginx/secrets/test_ecdsa.crt", Key: "/etc/nginx/secrets/test_ecdsa.key"},
+					},
+				},
+			},
+		},
+	}
+
+	cfgs, _ := generator.Generate(conf)
+	cfg := joinConfigs(cfgs)
+
+	expected := []string{
+		"ssl_certificate /etc/nginx/secrets/test_rsa.crt;",
+		"ssl_certificate_key /etc/nginx/secrets/test_rsa.key;",
+		"ssl_certificate /etc/nginx/secrets/test_ecdsa.crt;",
+		"ssl_certificate_key /etc/nginx/secrets/test_ecdsa.key;",
+	}
+
+	for _, d := range expected {
+		if !strings.Contains(string(cfg), d) {
+			t.Errorf("Generate() didn't generate %q", d)
+		}
+	}
+}
+
+func TestGenerateTLSOptions(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	conf := state.Configuration{
+		SSLServers: []state.VirtualServer{
+			{
+				Gateway:  gwNsName,
+				Hostname: "example.com",
+				Port:     443,
+				SSL: &state.SSL{
+					Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}},
+					Options: state.TLSOptions{
+						Protocols:           []string{"TLSv1.2", "TLSv1.3"},
+						Ciphers:             "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256",
+						PreferServerCiphers: helpers.GetBoolPointer(false),
+						SessionCache:        "shared:SSL:10m",
+						SessionTimeout:      "10m",
+					},
+				},
+			},
+			{
+				Gateway:  gwNsName,
+				Hostname: "no-options.example.com",
+				Port:     8443,
+				SSL: &state.SSL{
+					Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}},
+				},
+			},
+		},
+	}
+
+	cfgs, _ := generator.Generate(conf)
+	cfg := joinConfigs(cfgs)
+
+	expected := []string{
+		"ssl_prefer_server_ciphers off;",
+		"ssl_session_cache shared:SSL:10m;",
+		"ssl_session_timeout 10m;",
+	}
+
+	for _, d := range expected {
+		if c := strings.Count(string(cfg), d); c != 1 {
+			t.Errorf("Generate() generated %q %d times but expected once", d, c)
+		}
+	}
+
+	// the TLS protocols and ciphers are negotiated in the default server of the port
+	expectedHandshakeOptions := []string{
+		"ssl_protocols TLSv1.2 TLSv1.3;",
+		"ssl_ciphers ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256;",
+	}
+
+	for _, d := range expectedHandshakeOptions {
+		for _, name := range []string{"default-ssl-443", "ssl-test_gateway-example.com-443"} {
+			if !strings.Contains(string(cfgs[name]), d) {
+				t.Errorf("Generate() didn't generate %q in config %q", d, name)
+			}
+		}
+
+		if c := strings.Count(string(cfg), d); c != 2 {
+			t.Errorf("Generate() generated %q %d times but expected twice", d, c)
+		}
+	}
+
+	for _, name := range []string{"default-ssl-8443", "ssl-test_gateway-no-options.example.com-8443"} {
+		for _, d := range []string{"ssl_protocols", "ssl_ciphers"} {
+			if strings.Contains(string(cfgs[name]), d) {
+				t.Errorf("Generate() generated %q in config %q", d, name)
+			}
+		}
+	}
+}
+
+func TestGenerateClientCertificateVerification(t *testing.T) {
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	generator := NewGeneratorImpl(fakeServiceStore)
+
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "hr",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+						},
+					},
+					BackendRefs: []v1beta1.HTTPBackendRef{
+						{
+							BackendRef: v1beta1.BackendRef{
+								BackendObjectReference: v1beta1.BackendObjectReference{
+									Name: "service",
+									Port: (*v1beta1.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	createServer := func(hostname string, opts state.TLSOptions) state.VirtualServer {
+		return state.VirtualServer{
+			Hostname: hostname,
+			Port:     443,
+			SSL: &state.SSL{
+				Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}},
+				Options:      opts,
+			},
+			PathRules: []state.PathRule{
+				{
+					Path: "/",
+					MatchRules: []state.MatchRule{
+						{
+							MatchIdx: 0,
+							RuleIdx:  0,
+							Source:   hr,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf := state.Configuration{
+		SSLServers: []state.VirtualServer{
+			createServer("mtls.example.com", state.TLSOptions{
+				ClientCertificateSecret:  "ca",
+				ClientCertificatePath:    "/etc/nginx/secrets/test_ca_ca.crt",
+				VerifyClient:             "optional",
+				VerifyDepth:              helpers.GetInt32Pointer(2),
+				ForwardClientCertificate: true,
+			}),
+			createServer("example.com", state.TLSOptions{}),
+		},
+	}
+
+	cfgs, _ := generator.Generate(conf)
+	cfg := joinConfigs(cfgs)
+
+	expected := []string{
+		"ssl_client_certificate /etc/nginx/secrets/test_ca_ca.crt;",
+		"ssl_verify_client optional;",
+		"ssl_verify_depth 2;",
+		"proxy_set_header X-SSL-Client-Verify $ssl_client_verify;",
+		"proxy_set_header X-SSL-Client-S-DN $ssl_client_s_dn;",
+		"proxy_set_header X-SSL-Client-Cert $ssl_client_escaped_cert;",
+	}
+
+	for _, d := range expected {
+		if c := strings.Count(string(cfg), d); c != 1 {
+			t.Errorf("Generate() generated %q %d times but expected once", d, c)
+		}
+	}
+}
+
+func TestGenerateListenDirectivesForPorts(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	conf := state.Configuration{
+		HTTPServers: []state.VirtualServer{
+			{
+				Hostname: "bar.example.com",
+				Port:     80,
+			},
+			{
+				Hostname: "foo.example.com",
+				Port:     80,
+			},
+			{
+				Hostname: "foo.example.com",
+				Port:     8080,
+			},
+		},
+		SSLServers: []state.VirtualServer{
+			{
+				Hostname: "foo.example.com",
+				Port:     443,
+				SSL:      &state.SSL{Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}}},
+			},
+			{
+				Hostname: "foo.example.com",
+				Port:     8443,
+				SSL:      &state.SSL{Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		settings        state.Settings
+		expectedListens map[string]int
+		msg             string
+	}{
+		{
+			settings: state.Settings{},
+			expectedListens: map[string]int{
+				"listen 80 default_server;":             1,
+				"listen 8080 default_server;":           1,
+				"listen 443 ssl http2 default_server;":  1,
+				"listen 8443 ssl http2 default_server;": 1,
+				"listen 80;":                            2,
+				"listen 8080;":                          1,
+				"listen 443 ssl http2;":                 1,
+				"listen 8443 ssl http2;":                1,
+			},
+			msg: "http2 enabled by default",
+		},
+		{
+			settings: state.Settings{DisableHTTP2: true},
+			expectedListens: map[string]int{
+				"listen 80 default_server;":       1,
+				"listen 8080 default_server;":     1,
+				"listen 443 ssl default_server;":  1,
+				"listen 8443 ssl default_server;": 1,
+				"listen 80;":                      2,
+				"listen 8080;":                    1,
+				"listen 443 ssl;":                 1,
+				"listen 8443 ssl;":                1,
+				"http2":                           0,
+			},
+			msg: "http2 disabled",
+		},
+	}
+
+	for _, test := range tests {
+		conf.Settings = test.settings
+
+		cfgs, _ := generator.Generate(conf)
+		cfg := joinConfigs(cfgs)
+		result := string(cfg)
+
+		for listen, count := range test.expectedListens {
+			if c := strings.Count(result, listen); c != count {
+				t.Errorf("Generate() generated %q %d times but expected %d times for the case of %q", listen, c, count, test.msg)
+			}
+		}
+	}
+}
+
+func TestGetPorts(t *testing.T) {
+	servers := []state.VirtualServer{
+		{Hostname: "bar.example.com", Port: 80},
+		{Hostname: "foo.example.com", Port: 80},
+		{Hostname: "foo.example.com", Port: 8080},
+	}
+
+	expected := []int32{80, 8080}
+
+	result := getPorts(servers)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("getPorts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetTLSOptionsForPort(t *testing.T) {
+	opts := state.TLSOptions{Protocols: []string{"TLSv1.3"}}
+
+	servers := []state.VirtualServer{
+		{Hostname: "bar.example.com", Port: 443},
+		{Hostname: "foo.example.com", Port: 443, SSL: &state.SSL{Options: opts}},
+		{Hostname: "foo.example.com", Port: 8443, SSL: &state.SSL{}},
+	}
+
+	tests := []struct {
+		expected state.TLSOptions
+		port     int32
+		msg      string
+	}{
+		{
+			port:     443,
+			expected: opts,
+			msg:      "port with options",
+		},
+		{
+			port:     8443,
+			expected: state.TLSOptions{},
+			msg:      "port without options",
+		},
+		{
+			port:     9443,
+			expected: state.TLSOptions{},
+			msg:      "port without servers",
+		},
+	}
+
+	for _, test := range tests {
+		result := getTLSOptionsForPort(servers, test.port)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("getTLSOptionsForPort() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	hr := &v1beta1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
@@ -266,6 +680,9 @@ func TestGenerate(t *testing.T) {
 	const (
 		backendAddr = "http://10.0.0.1:80"
 		certPath    = "/etc/nginx/secrets/cert"
+		keyPath     = "/etc/nginx/secrets/key"
+		readTimeout = "3600s"
+		route       = "test/route1"
 		http        = false
 		https       = true
 	)
@@ -273,11 +690,17 @@ func TestGenerate(t *testing.T) {
 	getExpectedHost := func(isHTTPS bool) state.VirtualServer {
 		var ssl *state.SSL
 		if isHTTPS {
-			ssl = &state.SSL{CertificatePath: certPath}
+			ssl = &state.SSL{Certificates: []state.CertificateFiles{{Certificate: certPath, Key: keyPath}}}
+		}
+
+		port := int32(80)
+		if isHTTPS {
+			port = 8443
 		}
 
 		return state.VirtualServer{
 			Hostname: "example.com",
+			Port:     port,
 			SSL:      ssl,
 			PathRules: []state.PathRule{
 				{
@@ -336,122 +759,940 @@ func TestGenerate(t *testing.T) {
 					},
 				},
 				{
-					Path: "/redirect-explicit-port",
-					MatchRules: []state.MatchRule{
+					Path: "/redirect-explicit-port",
+					MatchRules: []state.MatchRule{
+						{
+							MatchIdx: 0,
+							RuleIdx:  4,
+							Source:   hr,
+							Filters: state.Filters{
+								RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+									Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("bar.example.com")),
+									Port:     (*v1beta1.PortNumber)(helpers.GetInt32Pointer(8080)),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	getExpectedHTTPServer := func(isHTTPS bool) server {
+		var sslCfg *ssl
+		port := 80
+		if isHTTPS {
+			sslCfg = &ssl{
+				Certificates: []sslCertificate{
+					{
+						Certificate:    certPath,
+						CertificateKey: keyPath,
+					},
+				},
+			}
+			port = 8443
+		}
+
+		return server{
+			ServerName: "example.com",
+			Port:       int32(port),
+			SSL:        sslCfg,
+			HTTP2:      isHTTPS,
+			Locations: []location{
+				{
+					Path:             "/_route0",
+					Internal:         true,
+					ProxyPass:        backendAddr,
+					ProxyReadTimeout: readTimeout,
+					Route:            route,
+					Backend:          "test/service1:80",
+				},
+				{
+					Path:             "/_route1",
+					Internal:         true,
+					ProxyPass:        backendAddr,
+					ProxyReadTimeout: readTimeout,
+					Route:            route,
+					Backend:          "test/service1:80",
+				},
+				{
+					Path:             "/_route2",
+					Internal:         true,
+					ProxyPass:        backendAddr,
+					ProxyReadTimeout: readTimeout,
+					Route:            route,
+					Backend:          "test/service1:80",
+				},
+				{
+					Path:         "/",
+					HTTPMatchVar: expectedMatchString(slashMatches),
+				},
+				{
+					Path:             "/test_route0",
+					Internal:         true,
+					ProxyPass:        "http://" + nginx502Server,
+					ProxyReadTimeout: readTimeout,
+					Route:            route,
+				},
+				{
+					Path:         "/test",
+					HTTPMatchVar: expectedMatchString(testMatches),
+				},
+				{
+					Path:             "/path-only",
+					ProxyPass:        backendAddr,
+					ProxyReadTimeout: readTimeout,
+					Route:            route,
+					Backend:          "test/service2:80",
+				},
+				{
+					Path: "/redirect-implicit-port",
+					Return: &returnVal{
+						Code: 302,
+						URL:  fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+					},
+					Route: route,
+				},
+				{
+					Path: "/redirect-explicit-port",
+					Return: &returnVal{
+						Code: 302,
+						URL:  "$scheme://bar.example.com:8080$request_uri",
+					},
+					Route: route,
+				},
+			},
+		}
+	}
+
+	expectedWarnings := Warnings{
+		hr: []string{"empty backend refs"},
+	}
+
+	settings := state.Settings{
+		ProxyReadTimeout: time.Hour,
+	}
+
+	testcases := []struct {
+		host        state.VirtualServer
+		expWarnings Warnings
+		expResult   server
+		msg         string
+	}{
+		{
+			host:        getExpectedHost(http),
+			expWarnings: expectedWarnings,
+			expResult:   getExpectedHTTPServer(http),
+			msg:         "http server",
+		},
+		{
+			host:        getExpectedHost(https),
+			expWarnings: expectedWarnings,
+			expResult:   getExpectedHTTPServer(https),
+			msg:         "https server",
+		},
+	}
+
+	for _, tc := range testcases {
+		result, warnings := generate(tc.host, settings, fakeServiceStore)
+
+		if diff := cmp.Diff(tc.expResult, result); diff != "" {
+			t.Errorf("generate() '%s' mismatch (-want +got):\n%s", tc.msg, diff)
+		}
+		if diff := cmp.Diff(tc.expWarnings, warnings); diff != "" {
+			t.Errorf("generate() '%s' mismatch on warnings (-want +got):\n%s", tc.msg, diff)
+		}
+	}
+}
+
+func TestGenerateForGRPCMethodRules(t *testing.T) {
+	gr := &v1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "grpc-route",
+		},
+		Spec: v1alpha2.GRPCRouteSpec{
+			Hostnames: []v1alpha2.Hostname{
+				"grpc.example.com",
+			},
+			Rules: []v1alpha2.GRPCRouteRule{
+				{
+					// a match with the service and the method and a header
+					Matches: []v1alpha2.GRPCRouteMatch{
+						{
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("helloworld.Greeter"),
+								Method:  helpers.GetStringPointer("SayHello"),
+							},
+							Headers: []v1alpha2.GRPCHeaderMatch{
+								{
+									Name:  "version",
+									Value: "v2",
+								},
+							},
+						},
+						{
+							// should generate an "any" httpmatch since other matches exist for the method
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("helloworld.Greeter"),
+								Method:  helpers.GetStringPointer("SayHello"),
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.GRPCBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(50051)),
+								},
+							},
+						},
+					},
+				},
+				{
+					// a match with the service only
+					Matches: []v1alpha2.GRPCRouteMatch{
+						{
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("helloworld.Greeter"),
+							},
+						},
+					},
+					BackendRefs: nil, // no backend refs will cause warnings
+				},
+			},
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	host := state.VirtualServer{
+		Hostname: "grpc.example.com",
+		Port:     443,
+		GRPCMethodRules: []state.GRPCMethodRule{
+			{
+				Service: "helloworld.Greeter",
+				Method:  "SayHello",
+				MatchRules: []state.GRPCMatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  0,
+						Source:   gr,
+					},
+					{
+						MatchIdx: 1,
+						RuleIdx:  0,
+						Source:   gr,
+					},
+				},
+			},
+			{
+				Service: "helloworld.Greeter",
+				MatchRules: []state.GRPCMatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  1,
+						Source:   gr,
+					},
+				},
+			},
+		},
+	}
+
+	settings := state.Settings{
+		ProxyReadTimeout: time.Hour,
+	}
+
+	const (
+		route       = "test/grpc-route"
+		readTimeout = "3600s"
+	)
+
+	matches := []httpMatch{
+		{Headers: []string{"version:v2"}, RedirectPath: "@grpc0_route0"},
+		{Any: true, RedirectPath: "@grpc0_route1"},
+	}
+
+	b, err := json.Marshal(matches)
+	if err != nil {
+		t.Fatalf("error marshaling test match: %v", err)
+	}
+
+	expectedServer := server{
+		ServerName: "grpc.example.com",
+		Port:       443,
+		Locations: []location{
+			{
+				Path:   "/",
+				Return: &returnVal{Code: statusNotFound},
+			},
+			{
+				Path:             "@grpc0_route0",
+				GRPCPass:         "grpc://10.0.0.1:50051",
+				ProxyReadTimeout: readTimeout,
+				Route:            route,
+				Backend:          "test/service1:50051",
+			},
+			{
+				Path:             "@grpc0_route1",
+				GRPCPass:         "grpc://10.0.0.1:50051",
+				ProxyReadTimeout: readTimeout,
+				Route:            route,
+				Backend:          "test/service1:50051",
+			},
+			{
+				Path:         "= /helloworld.Greeter/SayHello",
+				HTTPMatchVar: string(b),
+			},
+			{
+				Path:             `~ ^/helloworld\.Greeter/[^/]+$`,
+				GRPCPass:         "grpc://" + nginx502Server,
+				ProxyReadTimeout: readTimeout,
+				Route:            route,
+			},
+		},
+	}
+
+	expectedWarnings := Warnings{
+		gr: []string{"empty backend refs"},
+	}
+
+	result, warnings := generate(host, settings, fakeServiceStore)
+
+	if diff := cmp.Diff(expectedServer, result); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generate() mismatch on warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateUpgradeForProxyingLocations(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Hostnames: []v1beta1.Hostname{
+				"example.com",
+			},
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+						},
+					},
+				},
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/path-only"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	vs := state.VirtualServer{
+		Hostname: "example.com",
+		Port:     80,
+		PathRules: []state.PathRule{
+			{
+				Path: "/",
+				MatchRules: []state.MatchRule{
+					{MatchIdx: 0, RuleIdx: 0, Source: hr},
+					{MatchIdx: 1, RuleIdx: 0, Source: hr},
+				},
+			},
+			{
+				Path: "/path-only",
+				MatchRules: []state.MatchRule{
+					{MatchIdx: 0, RuleIdx: 1, Source: hr},
+				},
+			},
+		},
+	}
+
+	conf := state.Configuration{
+		HTTPServers: []state.VirtualServer{vs},
+		SSLServers:  []state.VirtualServer{withSSL(vs)},
+		Settings: state.Settings{
+			ProxyReadTimeout: time.Hour,
+			ProxySendTimeout: 90 * time.Second,
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	generator := NewGeneratorImpl(fakeServiceStore)
+
+	cfgs, _ := generator.Generate(conf)
+	cfg := joinConfigs(cfgs)
+	result := string(cfg)
+
+	if !strings.Contains(result, "map $http_upgrade $connection_upgrade {") {
+		t.Errorf("Generate() didn't generate the connection upgrade map:\n%s", result)
+	}
+
+	expectedDirectives := []string{
+		"proxy_http_version 1.1;",
+		"proxy_set_header Upgrade $http_upgrade;",
+		"proxy_set_header Connection $connection_upgrade;",
+		"proxy_read_timeout 3600s;",
+		"proxy_send_timeout 90s;",
+	}
+
+	// Each location block ends with the first closing brace after its start, since locations don't have nested blocks.
+	proxyingLocations := 0
+	internalLocations := 0
+
+	for _, block := range strings.Split(result, "location ")[1:] {
+		block = block[:strings.Index(block, "}")]
+
+		if !strings.Contains(block, "proxy_pass") {
+			continue
+		}
+
+		proxyingLocations++
+		if strings.Contains(block, "internal;") {
+			internalLocations++
+		}
+
+		for _, d := range expectedDirectives {
+			if !strings.Contains(block, d) {
+				t.Errorf("Generate() didn't generate %q for location:\n%s", d, block)
+			}
+		}
+	}
+
+	// 2 servers with 2 internal match locations and 1 path-only location each
+	if proxyingLocations != 6 {
+		t.Errorf("Generate() generated %d proxying locations but expected 6", proxyingLocations)
+	}
+	if internalLocations != 4 {
+		t.Errorf("Generate() generated %d internal proxying locations but expected 4", internalLocations)
+	}
+}
+
+func withSSL(vs state.VirtualServer) state.VirtualServer {
+	vs.Port = 443
+	vs.SSL = &state.SSL{Certificates: []state.CertificateFiles{{Certificate: "/etc/nginx/secrets/cert", Key: "/etc/nginx/secrets/key"}}}
+	return vs
+}
+
+func TestGenerateGRPCPass(t *testing.T) {
+	expected := "grpc://10.0.0.1:50051"
+
+	result := generateGRPCPass("10.0.0.1:50051")
+	if result != expected {
+		t.Errorf("generateGRPCPass() returned %s but expected %s", result, expected)
+	}
+
+	expected = "grpc://" + nginx502Server
+
+	result = generateGRPCPass("")
+	if result != expected {
+		t.Errorf("generateGRPCPass() returned %s but expected %s", result, expected)
+	}
+}
+
+func createTLSRoute(name string, serviceName string) *v1alpha2.TLSRoute {
+	tr := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      name,
+		},
+	}
+
+	if serviceName != "" {
+		tr.Spec.Rules = []v1alpha2.TLSRouteRule{
+			{
+				BackendRefs: []v1alpha2.BackendRef{
+					{
+						BackendObjectReference: v1alpha2.BackendObjectReference{
+							Name: v1alpha2.ObjectName(serviceName),
+							Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(8443)),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return tr
+}
+
+func createTCPRoute(name string, serviceName string) *v1alpha2.TCPRoute {
+	return &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      name,
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			Rules: []v1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []v1alpha2.BackendRef{
+						{
+							BackendObjectReference: v1alpha2.BackendObjectReference{
+								Name: v1alpha2.ObjectName(serviceName),
+								Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(5432)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateTLSPassthroughMap(t *testing.T) {
+	tr1 := createTLSRoute("tr-1", "service1")
+	tr2 := createTLSRoute("tr-2", "") // no backend
+
+	tlsServers := []state.TLSPassthroughServer{
+		{Hostname: "foo.example.com", Port: 443, Source: tr1},
+		{Hostname: "*.example.com", Port: 443, Source: tr2},
+		{Hostname: "bar.example.com", Port: 8443, Source: tr1},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	expected := nginxMap{
+		Source:    "$ssl_preread_server_name",
+		Variable:  "$tls_passthrough_backend_443",
+		Hostnames: true,
+		Parameters: []mapParameter{
+			{Value: "default", Result: nginxStreamCloseServer},
+			{Value: "foo.example.com", Result: "10.0.0.1:8443"},
+			{Value: "*.example.com", Result: nginxStreamCloseServer},
+		},
+	}
+
+	result, warnings := generateTLSPassthroughMap(443, tlsServers, fakeServiceStore)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateTLSPassthroughMap() mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedWarnings := Warnings{
+		tr2: []string{"empty backend refs"},
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateTLSPassthroughMap() mismatch on warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateTCPUpstream(t *testing.T) {
+	createBackendRef := func(serviceName string, weight *int32) v1alpha2.BackendRef {
+		return v1alpha2.BackendRef{
+			BackendObjectReference: v1alpha2.BackendObjectReference{
+				Name: v1alpha2.ObjectName(serviceName),
+				Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(5432)),
+			},
+			Weight: weight,
+		}
+	}
+
+	tcpr1 := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "tcpr-1",
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			Rules: []v1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []v1alpha2.BackendRef{
+						createBackendRef("service1", nil),
+						createBackendRef("service2", helpers.GetInt32Pointer(3)),
+						createBackendRef("service3", helpers.GetInt32Pointer(0)),
+						createBackendRef("missing", nil),
+					},
+				},
+			},
+		},
+	}
+
+	tcpr2 := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "tcpr-2",
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName) (string, error) {
+		switch nsname.Name {
+		case "service1":
+			return "10.0.0.1", nil
+		case "service2":
+			return "10.0.0.2", nil
+		case "service3":
+			return "10.0.0.3", nil
+		default:
+			return "", errors.New("not found")
+		}
+	}
+
+	tests := []struct {
+		server           state.TCPServer
+		expected         streamUpstream
+		expectedWarnings Warnings
+		msg              string
+	}{
+		{
+			server: state.TCPServer{Port: 5432, Source: tcpr1},
+			expected: streamUpstream{
+				Name: "tcp_backend_5432",
+				Servers: []upstreamServer{
+					{Address: "10.0.0.1:5432", Weight: 1},
+					{Address: "10.0.0.2:5432", Weight: 3},
+				},
+			},
+			expectedWarnings: Warnings{
+				tcpr1: []string{"service test/missing cannot be resolved: not found"},
+			},
+			msg: "multiple backends",
+		},
+		{
+			server: state.TCPServer{Port: 6379, Source: tcpr2},
+			expected: streamUpstream{
+				Name: "tcp_backend_6379",
+				Servers: []upstreamServer{
+					{Address: nginxStreamCloseServer, Weight: 1},
+				},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "no backends",
+		},
+	}
+
+	for _, test := range tests {
+		result, warnings := generateTCPUpstream(test.server, fakeServiceStore)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTCPUpstream() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings); diff != "" {
+			t.Errorf("generateTCPUpstream() %q mismatch on warnings (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateUDPUpstream(t *testing.T) {
+	ur := &v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "ur-1",
+		},
+		Spec: v1alpha2.UDPRouteSpec{
+			Rules: []v1alpha2.UDPRouteRule{
+				{
+					BackendRefs: []v1alpha2.BackendRef{
 						{
-							MatchIdx: 0,
-							RuleIdx:  4,
-							Source:   hr,
-							Filters: state.Filters{
-								RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-									Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("bar.example.com")),
-									Port:     (*v1beta1.PortNumber)(helpers.GetInt32Pointer(8080)),
-								},
+							BackendObjectReference: v1alpha2.BackendObjectReference{
+								Name: "dns",
+								Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(53)),
 							},
 						},
 					},
 				},
 			},
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	expected := streamUpstream{
+		Name: "udp_backend_53",
+		Servers: []upstreamServer{
+			{Address: "10.0.0.1:53", Weight: 1},
+		},
+	}
+
+	result, warnings := generateUDPUpstream(state.UDPServer{Port: 53, Source: ur}, fakeServiceStore)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateUDPUpstream() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateUDPUpstream() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateUDPServer(t *testing.T) {
+	tests := []struct {
+		settings state.Settings
+		expected streamServer
+		msg      string
+	}{
+		{
+			settings: state.Settings{},
+			expected: streamServer{
+				Port:      53,
+				UDP:       true,
+				ProxyPass: "udp_backend_53",
+			},
+			msg: "default settings",
+		},
+		{
+			settings: state.Settings{
+				UDPProxyResponses: helpers.GetInt32Pointer(0),
+				UDPProxyTimeout:   30 * time.Second,
+			},
+			expected: streamServer{
+				Port:           53,
+				UDP:            true,
+				ProxyPass:      "udp_backend_53",
+				ProxyResponses: "0",
+				ProxyTimeout:   "30s",
+			},
+			msg: "custom settings",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateUDPServer(53, "udp_backend_53", test.settings)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateUDPServer() %q mismatch (-want +got):\n%s", test.msg, diff)
 		}
 	}
+}
 
-	getExpectedHTTPServer := func(isHTTPS bool) server {
-		var sslCfg *ssl
-		port := 80
-		if isHTTPS {
-			sslCfg = &ssl{
-				Certificate:    certPath,
-				CertificateKey: certPath,
-			}
-			port = 443
+func TestGenerateStream(t *testing.T) {
+	tr := createTLSRoute("tr-1", "service1")
+
+	conf := state.Configuration{
+		TLSPassthroughServers: []state.TLSPassthroughServer{
+			{Hostname: "foo.example.com", Port: 443, Source: tr},
+			{Hostname: "bar.example.com", Port: 443, Source: tr},
+			{Hostname: "foo.example.com", Port: 8443, Source: tr},
+		},
+		TCPServers: []state.TCPServer{
+			{Port: 5432, Source: createTCPRoute("tcpr-1", "service1")},
+		},
+		UDPServers: []state.UDPServer{
+			{Port: 5432, Source: &v1alpha2.UDPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "ur-1"}}},
+		},
+		Settings: state.Settings{
+			UDPProxyResponses: helpers.GetInt32Pointer(1),
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
+
+	generator := NewGeneratorImpl(fakeServiceStore)
+
+	cfg, warnings := generator.GenerateStream(conf)
+	result := string(cfg)
+
+	if len(warnings) != 0 {
+		t.Errorf("GenerateStream() returned unexpected warnings: %v", warnings)
+	}
+
+	expectedSubStrings := map[string]int{
+		"map $ssl_preread_server_name $tls_passthrough_backend_443 {":  1,
+		"map $ssl_preread_server_name $tls_passthrough_backend_8443 {": 1,
+		"hostnames;":                                2,
+		"foo.example.com 10.0.0.1:8443;":            2,
+		"bar.example.com 10.0.0.1:8443;":            1,
+		"listen 443;":                               1,
+		"listen 8443;":                              1,
+		"ssl_preread on;":                           2,
+		"proxy_pass $tls_passthrough_backend_443;":  1,
+		"proxy_pass $tls_passthrough_backend_8443;": 1,
+		"upstream tcp_backend_5432 {":               1,
+		"server 10.0.0.1:5432 weight=1;":            1,
+		"listen 5432;":                              1,
+		"proxy_pass tcp_backend_5432;":              1,
+		"upstream udp_backend_5432 {":               1,
+		"server " + nginxStreamCloseServer:          1,
+		"listen 5432 udp;":                          1,
+		"proxy_responses 1;":                        1,
+		"proxy_pass udp_backend_5432;":              1,
+	}
+
+	for sub, count := range expectedSubStrings {
+		if c := strings.Count(result, sub); c != count {
+			t.Errorf("GenerateStream() generated %q %d times but expected %d times:\n%s", sub, c, count, result)
 		}
+	}
 
-		return server{
-			ServerName: "example.com",
-			SSL:        sslCfg,
-			Locations: []location{
-				{
-					Path:      "/_route0",
-					Internal:  true,
-					ProxyPass: backendAddr,
-				},
-				{
-					Path:      "/_route1",
-					Internal:  true,
-					ProxyPass: backendAddr,
-				},
-				{
-					Path:      "/_route2",
-					Internal:  true,
-					ProxyPass: backendAddr,
-				},
-				{
-					Path:         "/",
-					HTTPMatchVar: expectedMatchString(slashMatches),
-				},
-				{
-					Path:      "/test_route0",
-					Internal:  true,
-					ProxyPass: "http://" + nginx502Server,
+	cfg, _ = generator.GenerateStream(state.Configuration{})
+	if strings.TrimSpace(string(cfg)) != "" {
+		t.Errorf("GenerateStream() generated non-empty config for empty configuration:\n%s", cfg)
+	}
+}
+
+func TestGenerateConfigHash(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	cfg := string(generator.GenerateConfigHash("abc123"))
+
+	expected := []string{
+		"map $host $gateway_config_hash {",
+		`default "abc123";`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(cfg, e) {
+			t.Errorf("GenerateConfigHash() didn't generate %q:\n%s", e, cfg)
+		}
+	}
+}
+
+func TestGenerateMainConfig(t *testing.T) {
+	tests := []struct {
+		settings state.Settings
+		expected mainConfig
+		msg      string
+	}{
+		{
+			settings: state.Settings{},
+			expected: mainConfig{},
+			msg:      "default settings",
+		},
+		{
+			settings: state.Settings{WorkerProcesses: helpers.GetIntPointer(4)},
+			expected: mainConfig{WorkerProcesses: "4"},
+			msg:      "worker processes",
+		},
+		{
+			settings: state.Settings{
+				AccessLogs: []state.AccessLog{
+					{Format: "combined", Destination: "stdout"},
+					{Format: "json", Destination: "stderr"},
+					{Format: "$remote_addr $status", Destination: "/var/log/nginx/access.log"},
+					{Format: "json", Destination: "syslog:server=10.0.0.1:514"},
 				},
-				{
-					Path:         "/test",
-					HTTPMatchVar: expectedMatchString(testMatches),
+			},
+			expected: mainConfig{
+				LogFormats: []logFormat{
+					{Name: jsonLogFormatName, Escape: "json", Format: jsonLogFormat},
+					{Name: "gateway_custom_2", Format: "$remote_addr $status"},
 				},
-				{
-					Path:      "/path-only",
-					ProxyPass: backendAddr,
+				AccessLogs: []accessLog{
+					{Destination: "/dev/stdout", FormatName: "combined"},
+					{Destination: "/dev/stderr", FormatName: jsonLogFormatName},
+					{Destination: "/var/log/nginx/access.log", FormatName: "gateway_custom_2"},
+					{Destination: "syslog:server=10.0.0.1:514", FormatName: jsonLogFormatName},
 				},
+			},
+			msg: "access logs",
+		},
+		{
+			settings: state.Settings{ConfigHashHeader: true},
+			expected: mainConfig{
+				ConfigHashHeader: &header{Name: "X-Gateway-Config-Hash", Value: "$gateway_config_hash"},
+			},
+			msg: "config hash header",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateMainConfig(test.settings)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateMainConfig() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateBackendName(t *testing.T) {
+	tests := []struct {
+		refs     []v1beta1.HTTPBackendRef
+		expected string
+		msg      string
+	}{
+		{
+			refs:     nil,
+			expected: "",
+			msg:      "no refs",
+		},
+		{
+			refs: []v1beta1.HTTPBackendRef{
 				{
-					Path: "/redirect-implicit-port",
-					Return: &returnVal{
-						Code: 302,
-						URL:  fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+					BackendRef: v1beta1.BackendRef{
+						BackendObjectReference: v1beta1.BackendObjectReference{
+							Name: "service1",
+							Port: (*v1beta1.PortNumber)(helpers.GetInt32Pointer(80)),
+						},
 					},
 				},
+			},
+			expected: "test/service1:80",
+			msg:      "namespace of the route",
+		},
+		{
+			refs: []v1beta1.HTTPBackendRef{
 				{
-					Path: "/redirect-explicit-port",
-					Return: &returnVal{
-						Code: 302,
-						URL:  "$scheme://bar.example.com:8080$request_uri",
+					BackendRef: v1beta1.BackendRef{
+						BackendObjectReference: v1beta1.BackendObjectReference{
+							Name:      "service1",
+							Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer("other")),
+						},
 					},
 				},
 			},
-		}
+			expected: "other/service1",
+			msg:      "explicit namespace and no port",
+		},
 	}
 
-	expectedWarnings := Warnings{
-		hr: []string{"empty backend refs"},
+	for _, test := range tests {
+		result := generateBackendName(test.refs, "test")
+		if result != test.expected {
+			t.Errorf("generateBackendName() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
+		}
 	}
+}
 
-	testcases := []struct {
-		host        state.VirtualServer
-		expWarnings Warnings
-		expResult   server
-		msg         string
+func TestGenerateTime(t *testing.T) {
+	tests := []struct {
+		expected string
+		msg      string
+		d        time.Duration
 	}{
 		{
-			host:        getExpectedHost(http),
-			expWarnings: expectedWarnings,
-			expResult:   getExpectedHTTPServer(http),
-			msg:         "http server",
+			d:        0,
+			expected: "",
+			msg:      "zero",
 		},
 		{
-			host:        getExpectedHost(https),
-			expWarnings: expectedWarnings,
-			expResult:   getExpectedHTTPServer(https),
-			msg:         "https server",
+			d:        90 * time.Second,
+			expected: "90s",
+			msg:      "whole seconds",
+		},
+		{
+			d:        1500 * time.Millisecond,
+			expected: "1500ms",
+			msg:      "milliseconds",
+		},
+		{
+			d:        500 * time.Microsecond,
+			expected: "1ms",
+			msg:      "sub-millisecond",
 		},
 	}
 
-	for _, tc := range testcases {
-		result, warnings := generate(tc.host, fakeServiceStore)
-
-		if diff := cmp.Diff(tc.expResult, result); diff != "" {
-			t.Errorf("generate() '%s' mismatch (-want +got):\n%s", tc.msg, diff)
-		}
-		if diff := cmp.Diff(tc.expWarnings, warnings); diff != "" {
-			t.Errorf("generate() '%s' mismatch on warnings (-want +got):\n%s", tc.msg, diff)
+	for _, test := range tests {
+		result := generateTime(test.d)
+		if result != test.expected {
+			t.Errorf("generateTime() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
 		}
 	}
 }
@@ -736,6 +1977,52 @@ func TestCreatePathForMatch(t *testing.T) {
 	}
 }
 
+func TestCreateGRPCLocationPath(t *testing.T) {
+	tests := []struct {
+		service  string
+		method   string
+		expected string
+		msg      string
+	}{
+		{
+			service:  "helloworld.Greeter",
+			method:   "SayHello",
+			expected: "= /helloworld.Greeter/SayHello",
+			msg:      "service and method",
+		},
+		{
+			service:  "helloworld.Greeter",
+			expected: `~ ^/helloworld\.Greeter/[^/]+$`,
+			msg:      "service only",
+		},
+		{
+			method:   "SayHello",
+			expected: "~ ^/[^/]+/SayHello$",
+			msg:      "method only",
+		},
+		{
+			expected: "~ ^/[^/]+/[^/]+$",
+			msg:      "any service and any method",
+		},
+	}
+
+	for _, test := range tests {
+		result := createGRPCLocationPath(test.service, test.method)
+		if result != test.expected {
+			t.Errorf("createGRPCLocationPath() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestCreateNamedLocationForGRPCMatch(t *testing.T) {
+	expected := "@grpc1_route2"
+
+	result := createNamedLocationForGRPCMatch(1, 2)
+	if result != expected {
+		t.Errorf("createNamedLocationForGRPCMatch() returned %q but expected %q", result, expected)
+	}
+}
+
 func TestCreateArgKeyValString(t *testing.T) {
 	expected := "key=value"
 
@@ -1022,3 +2309,66 @@ func TestCreateHTTPMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateGRPCMatch(t *testing.T) {
+	testPath := "@grpc0_route0"
+
+	testHeaderMatches := []v1alpha2.GRPCHeaderMatch{
+		{
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+			Name:  "header-1",
+			Value: "val-1",
+		},
+		{
+			// the type is Exact by default
+			Name:  "header-2",
+			Value: "val-2",
+		},
+		{
+			// regex type is not supported. This should not be added to the httpMatch headers.
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+			Name:  "ignore-this-header",
+			Value: "val",
+		},
+		{
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+			Name:  "HEADER-1", // header names are case-insensitive
+			Value: "val-1",
+		},
+	}
+
+	tests := []struct {
+		match    v1alpha2.GRPCRouteMatch
+		expected httpMatch
+		msg      string
+	}{
+		{
+			match: v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Service: helpers.GetStringPointer("helloworld.Greeter"),
+				},
+			},
+			expected: httpMatch{
+				Any:          true,
+				RedirectPath: testPath,
+			},
+			msg: "method only match",
+		},
+		{
+			match: v1alpha2.GRPCRouteMatch{
+				Headers: testHeaderMatches,
+			},
+			expected: httpMatch{
+				Headers:      []string{"header-1:val-1", "header-2:val-2"},
+				RedirectPath: testPath,
+			},
+			msg: "headers match",
+		},
+	}
+	for _, tc := range tests {
+		result := createGRPCMatch(tc.match, testPath)
+		if diff := helpers.Diff(result, tc.expected); diff != "" {
+			t.Errorf("createGRPCMatch() returned incorrect httpMatch for test case: %q, diff: %+v", tc.msg, diff)
+		}
+	}
+}
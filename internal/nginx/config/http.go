@@ -8,16 +8,27 @@ type server struct {
 	SSL           *ssl
 	ServerName    string
 	Locations     []location
+	Port          int32
 	IsDefaultHTTP bool
 	IsDefaultSSL  bool
+	// HTTP2 enables HTTP/2 for an SSL server. NGINX requires all servers on the same port to agree on it.
+	HTTP2 bool
 }
 
 type location struct {
-	Return       *returnVal
-	Path         string
-	ProxyPass    string
-	HTTPMatchVar string
-	Internal     bool
+	Return           *returnVal
+	Path             string
+	ProxyPass        string
+	HTTPMatchVar     string
+	ProxyReadTimeout string
+	ProxySendTimeout string
+	// GRPCPass is the address of the gRPC backend. ProxyReadTimeout and ProxySendTimeout apply to it too.
+	GRPCPass string
+	// Route is the namespaced name of the HTTPRoute or the GRPCRoute of the location. It is available in the access logs.
+	Route string
+	// Backend is the backend of the location in the form NAMESPACE/NAME:PORT. It is available in the access logs.
+	Backend  string
+	Internal bool
 }
 
 type returnVal struct {
@@ -26,6 +37,19 @@ type returnVal struct {
 }
 
 type ssl struct {
+	Certificates             []sslCertificate
+	Protocols                string
+	Ciphers                  string
+	PreferServerCiphers      string
+	SessionCache             string
+	SessionTimeout           string
+	ClientCertificate        string
+	VerifyClient             string
+	VerifyDepth              string
+	ForwardClientCertificate bool
+}
+
+type sslCertificate struct {
 	Certificate    string
 	CertificateKey string
 }
@@ -0,0 +1,31 @@
+package config
+
+type mainConfig struct {
+	// WorkerProcesses is the number of worker processes. Empty means the NGINX default is used.
+	WorkerProcesses string
+	// LogFormats are the log formats used by the AccessLogs.
+	LogFormats []logFormat
+	// AccessLogs are the access logs. If empty, the NGINX default access log is used.
+	AccessLogs []accessLog
+	// ConfigHashHeader is the response header with the hash of the configuration. Nil means it is not added.
+	ConfigHashHeader *header
+}
+
+// header is a header added to all responses.
+type header struct {
+	Name  string
+	Value string
+}
+
+type logFormat struct {
+	Name string
+	// Escape is the escaping of the values of the variables. Empty means the NGINX default escaping.
+	Escape string
+	Format string
+}
+
+type accessLog struct {
+	Destination string
+	// FormatName is the name of a logFormat or of an NGINX predefined format.
+	FormatName string
+}
@@ -0,0 +1,18 @@
+package config
+
+// nginxMap is an NGINX map. The same map syntax is used in the http and stream contexts.
+type nginxMap struct {
+	// Source is the source string or variable of the map. For example, $http_upgrade.
+	Source string
+	// Variable is the variable created by the map. For example, $connection_upgrade.
+	Variable string
+	// Parameters are the value-result pairs of the map.
+	Parameters []mapParameter
+	// Hostnames indicates that the values are hostnames, which can include a wildcard (e.g. *.example.com).
+	Hostnames bool
+}
+
+type mapParameter struct {
+	Value  string
+	Result string
+}
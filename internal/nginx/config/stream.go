@@ -0,0 +1,28 @@
+package config
+
+type streamServers struct {
+	Upstreams []streamUpstream
+	Servers   []streamServer
+}
+
+type streamServer struct {
+	// ProxyPass is the address or the variable with the address of the backend.
+	ProxyPass string
+	Port      int32
+	// ProxyResponses is the number of datagrams expected from the backend. Empty means the directive is not generated.
+	ProxyResponses string
+	ProxyTimeout   string
+	// SSLPreread enables extracting the SNI from the TLS ClientHello into $ssl_preread_server_name.
+	SSLPreread bool
+	UDP        bool
+}
+
+type streamUpstream struct {
+	Name    string
+	Servers []upstreamServer
+}
+
+type upstreamServer struct {
+	Address string
+	Weight  int32
+}
@@ -6,16 +6,50 @@ import (
 	"text/template"
 )
 
+var mainTemplate = `load_module /usr/lib/nginx/modules/ngx_http_js_module.so;
+{{ if .WorkerProcesses }}
+worker_processes {{ .WorkerProcesses }};
+{{ end }}
+error_log stderr notice;
+pid nginx.pid;
+
+events {}
+
+http {
+{{- range $f := .LogFormats }}
+	log_format {{ $f.Name }}{{ if $f.Escape }} escape={{ $f.Escape }}{{ end }} '{{ $f.Format }}';
+{{- end }}
+{{- range $l := .AccessLogs }}
+	access_log {{ $l.Destination }} {{ $l.FormatName }};
+{{- end }}
+{{- if .ConfigHashHeader }}
+	add_header {{ .ConfigHashHeader.Name }} {{ .ConfigHashHeader.Value }} always;
+{{- end }}
+	include conf.d/*.conf;
+	js_import /usr/lib/nginx/modules/njs/httpmatches.js;
+}
+
+stream {
+	include stream-conf.d/*.conf;
+}
+`
+
 var httpServersTemplate = `{{ range $s := .Servers }}
 	{{ if $s.IsDefaultSSL }}
 server {
-	listen 443 ssl default_server;
+	listen {{ $s.Port }} ssl{{ if $s.HTTP2 }} http2{{ end }} default_server;
 
 	ssl_reject_handshake on;
+		{{- if $s.SSL.Protocols }}
+	ssl_protocols {{ $s.SSL.Protocols }};
+		{{- end }}
+		{{- if $s.SSL.Ciphers }}
+	ssl_ciphers {{ $s.SSL.Ciphers }};
+		{{- end }}
 }
 	{{ else if $s.IsDefaultHTTP }}
 server {
-	listen 80 default_server;
+	listen {{ $s.Port }} default_server;
 	
 	default_type text/html;
 	return 404;
@@ -23,13 +57,39 @@ server {
 	{{ else }}
 server {
 		{{ if $s.SSL }}
-	listen 443 ssl;
-	ssl_certificate {{ $s.SSL.Certificate }};
-	ssl_certificate_key {{ $s.SSL.CertificateKey }};
+	listen {{ $s.Port }} ssl{{ if $s.HTTP2 }} http2{{ end }};
+		{{- range $c := $s.SSL.Certificates }}
+	ssl_certificate {{ $c.Certificate }};
+	ssl_certificate_key {{ $c.CertificateKey }};
+		{{- end }}
+		{{- if $s.SSL.Protocols }}
+	ssl_protocols {{ $s.SSL.Protocols }};
+		{{- end }}
+		{{- if $s.SSL.Ciphers }}
+	ssl_ciphers {{ $s.SSL.Ciphers }};
+		{{- end }}
+		{{- if $s.SSL.PreferServerCiphers }}
+	ssl_prefer_server_ciphers {{ $s.SSL.PreferServerCiphers }};
+		{{- end }}
+		{{- if $s.SSL.SessionCache }}
+	ssl_session_cache {{ $s.SSL.SessionCache }};
+		{{- end }}
+		{{- if $s.SSL.SessionTimeout }}
+	ssl_session_timeout {{ $s.SSL.SessionTimeout }};
+		{{- end }}
+		{{- if $s.SSL.ClientCertificate }}
+	ssl_client_certificate {{ $s.SSL.ClientCertificate }};
+	ssl_verify_client {{ $s.SSL.VerifyClient }};
+			{{- if $s.SSL.VerifyDepth }}
+	ssl_verify_depth {{ $s.SSL.VerifyDepth }};
+			{{- end }}
+		{{- end }}
 
 	if ($ssl_server_name != $host) {
 		return 421;
 	}
+		{{ else }}
+	listen {{ $s.Port }};
 		{{ end }}
 
 	server_name {{ $s.ServerName }};
@@ -40,6 +100,13 @@ server {
 		internal;
 		{{ end }}
 
+		{{ if $l.Route }}
+		set $gateway_route "{{ $l.Route }}";
+		{{ end }}
+		{{ if $l.Backend }}
+		set $gateway_backend "{{ $l.Backend }}";
+		{{ end }}
+
 		{{ if $l.Return }}
 		return {{ $l.Return.Code }} {{ $l.Return.URL }};
 		{{ end }}
@@ -50,9 +117,38 @@ server {
 		{{ end }}
 
 		{{ if $l.ProxyPass }}
+		proxy_http_version 1.1;
 		proxy_set_header Host $host;
+		proxy_set_header Upgrade $http_upgrade;
+		proxy_set_header Connection $connection_upgrade;
+			{{ if and $s.SSL $s.SSL.ForwardClientCertificate }}
+		proxy_set_header X-SSL-Client-Verify $ssl_client_verify;
+		proxy_set_header X-SSL-Client-S-DN $ssl_client_s_dn;
+		proxy_set_header X-SSL-Client-Cert $ssl_client_escaped_cert;
+			{{ end }}
+			{{ if $l.ProxyReadTimeout }}
+		proxy_read_timeout {{ $l.ProxyReadTimeout }};
+			{{ end }}
+			{{ if $l.ProxySendTimeout }}
+		proxy_send_timeout {{ $l.ProxySendTimeout }};
+			{{ end }}
 		proxy_pass {{ $l.ProxyPass }}$request_uri;
 		{{ end }}
+
+		{{ if $l.GRPCPass }}
+			{{ if and $s.SSL $s.SSL.ForwardClientCertificate }}
+		grpc_set_header X-SSL-Client-Verify $ssl_client_verify;
+		grpc_set_header X-SSL-Client-S-DN $ssl_client_s_dn;
+		grpc_set_header X-SSL-Client-Cert $ssl_client_escaped_cert;
+			{{ end }}
+			{{ if $l.ProxyReadTimeout }}
+		grpc_read_timeout {{ $l.ProxyReadTimeout }};
+			{{ end }}
+			{{ if $l.ProxySendTimeout }}
+		grpc_send_timeout {{ $l.ProxySendTimeout }};
+			{{ end }}
+		grpc_pass {{ $l.GRPCPass }};
+		{{ end }}
 	}
 		{{ end }}
 }
@@ -60,21 +156,90 @@ server {
 {{ end }}
 `
 
+var mapsTemplate = `{{ range $m := . }}
+map {{ $m.Source }} {{ $m.Variable }} {
+	{{ if $m.Hostnames }}
+	hostnames;
+	{{ end }}
+	{{ range $p := $m.Parameters }}
+	{{ $p.Value }} {{ $p.Result }};
+	{{ end }}
+}
+{{ end }}
+`
+
+var streamServersTemplate = `{{ range $u := .Upstreams }}
+upstream {{ $u.Name }} {
+	{{ range $s := $u.Servers }}
+	server {{ $s.Address }} weight={{ $s.Weight }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $s := .Servers }}
+server {
+	listen {{ $s.Port }}{{ if $s.UDP }} udp{{ end }};
+	{{ if $s.SSLPreread }}
+	ssl_preread on;
+	{{ end }}
+	{{ if $s.ProxyResponses }}
+	proxy_responses {{ $s.ProxyResponses }};
+	{{ end }}
+	{{ if $s.ProxyTimeout }}
+	proxy_timeout {{ $s.ProxyTimeout }};
+	{{ end }}
+	proxy_pass {{ $s.ProxyPass }};
+}
+{{ end }}
+`
+
 // templateExecutor generates NGINX configuration using a template.
 // Template parsing or executing errors can only occur if there is a bug in the template, so they are handled with panics.
-// For now, we only generate configuration with NGINX http servers, but in the future we will also need to generate
-// the main NGINX configuration file, upstreams, stream servers.
 type templateExecutor struct {
-	httpServersTemplate *template.Template
+	mainTemplate          *template.Template
+	httpServersTemplate   *template.Template
+	mapsTemplate          *template.Template
+	streamServersTemplate *template.Template
 }
 
 func newTemplateExecutor() *templateExecutor {
+	mt, err := template.New("main").Parse(mainTemplate)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse main template: %w", err))
+	}
+
 	t, err := template.New("server").Parse(httpServersTemplate)
 	if err != nil {
 		panic(fmt.Errorf("failed to parse http servers template: %w", err))
 	}
 
-	return &templateExecutor{httpServersTemplate: t}
+	m, err := template.New("maps").Parse(mapsTemplate)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse maps template: %w", err))
+	}
+
+	st, err := template.New("stream servers").Parse(streamServersTemplate)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse stream servers template: %w", err))
+	}
+
+	return &templateExecutor{
+		mainTemplate:          mt,
+		httpServersTemplate:   t,
+		mapsTemplate:          m,
+		streamServersTemplate: st,
+	}
+}
+
+func (e *templateExecutor) ExecuteForMain(cfg mainConfig) []byte {
+	var buf bytes.Buffer
+
+	err := e.mainTemplate.Execute(&buf, cfg)
+	if err != nil {
+		panic(fmt.Errorf("failed to execute main template: %w", err))
+	}
+
+	return buf.Bytes()
 }
 
 func (e *templateExecutor) ExecuteForHTTPServers(servers httpServers) []byte {
@@ -87,3 +252,25 @@ func (e *templateExecutor) ExecuteForHTTPServers(servers httpServers) []byte {
 
 	return buf.Bytes()
 }
+
+func (e *templateExecutor) ExecuteForMaps(maps []nginxMap) []byte {
+	var buf bytes.Buffer
+
+	err := e.mapsTemplate.Execute(&buf, maps)
+	if err != nil {
+		panic(fmt.Errorf("failed to execute maps template: %w", err))
+	}
+
+	return buf.Bytes()
+}
+
+func (e *templateExecutor) ExecuteForStreamServers(servers streamServers) []byte {
+	var buf bytes.Buffer
+
+	err := e.streamServersTemplate.Execute(&buf, servers)
+	if err != nil {
+		panic(fmt.Errorf("failed to execute stream servers template: %w", err))
+	}
+
+	return buf.Bytes()
+}
@@ -5,6 +5,17 @@ import (
 	"text/template"
 )
 
+func TestExecuteForMain(t *testing.T) {
+	executor := newTemplateExecutor()
+
+	cfg := executor.ExecuteForMain(mainConfig{WorkerProcesses: "auto"})
+	// we only do a sanity check here.
+	// the config generation logic is tested in the Generator tests.
+	if len(cfg) == 0 {
+		t.Error("ExecuteForMain() returned 0-length config")
+	}
+}
+
 func TestExecuteForServer(t *testing.T) {
 	executor := newTemplateExecutor()
 
@@ -30,6 +41,68 @@ func TestExecuteForServer(t *testing.T) {
 	}
 }
 
+func TestExecuteForMaps(t *testing.T) {
+	executor := newTemplateExecutor()
+
+	maps := []nginxMap{
+		{
+			Source:   "$http_upgrade",
+			Variable: "$connection_upgrade",
+			Parameters: []mapParameter{
+				{Value: "default", Result: "upgrade"},
+				{Value: "''", Result: "close"},
+			},
+		},
+	}
+
+	cfg := executor.ExecuteForMaps(maps)
+	// we only do a sanity check here.
+	// the config generation logic is tested in the Generator tests.
+	if len(cfg) == 0 {
+		t.Error("ExecuteForMaps() returned 0-length config")
+	}
+}
+
+func TestExecuteForStreamServers(t *testing.T) {
+	executor := newTemplateExecutor()
+
+	servers := streamServers{
+		Upstreams: []streamUpstream{
+			{
+				Name: "tcp_backend_5432",
+				Servers: []upstreamServer{
+					{Address: "10.0.0.1:5432", Weight: 1},
+				},
+			},
+		},
+		Servers: []streamServer{
+			{
+				Port:       443,
+				SSLPreread: true,
+				ProxyPass:  "$tls_passthrough_backend_443",
+			},
+			{
+				Port:      5432,
+				ProxyPass: "tcp_backend_5432",
+			},
+			{
+				Port:           53,
+				UDP:            true,
+				ProxyPass:      "udp_backend_53",
+				ProxyResponses: "1",
+				ProxyTimeout:   "30s",
+			},
+		},
+	}
+
+	cfg := executor.ExecuteForStreamServers(servers)
+	// we only do a sanity check here.
+	// the config generation logic is tested in the Generator tests.
+	if len(cfg) == 0 {
+		t.Error("ExecuteForStreamServers() returned 0-length config")
+	}
+}
+
 func TestNewTemplateExecutorPanics(t *testing.T) {
 	defer func() {
 		r := recover()
@@ -8,38 +8,313 @@ import (
 )
 
 type FakeManager struct {
-	WriteHTTPServersConfigStub        func(string, []byte) error
-	writeHTTPServersConfigMutex       sync.RWMutex
-	writeHTTPServersConfigArgsForCall []struct {
+	ApplyStagedConfigsStub        func() error
+	applyStagedConfigsMutex       sync.RWMutex
+	applyStagedConfigsArgsForCall []struct {
+	}
+	applyStagedConfigsReturns struct {
+		result1 error
+	}
+	applyStagedConfigsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RestorePreviousConfigsStub        func() error
+	restorePreviousConfigsMutex       sync.RWMutex
+	restorePreviousConfigsArgsForCall []struct {
+	}
+	restorePreviousConfigsReturns struct {
+		result1 error
+	}
+	restorePreviousConfigsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteHTTPConfigsStub        func(map[string][]byte) error
+	writeHTTPConfigsMutex       sync.RWMutex
+	writeHTTPConfigsArgsForCall []struct {
+		arg1 map[string][]byte
+	}
+	writeHTTPConfigsReturns struct {
+		result1 error
+	}
+	writeHTTPConfigsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteMainConfigStub        func([]byte) error
+	writeMainConfigMutex       sync.RWMutex
+	writeMainConfigArgsForCall []struct {
+		arg1 []byte
+	}
+	writeMainConfigReturns struct {
+		result1 error
+	}
+	writeMainConfigReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteStreamServersConfigStub        func(string, []byte) error
+	writeStreamServersConfigMutex       sync.RWMutex
+	writeStreamServersConfigArgsForCall []struct {
 		arg1 string
 		arg2 []byte
 	}
-	writeHTTPServersConfigReturns struct {
+	writeStreamServersConfigReturns struct {
 		result1 error
 	}
-	writeHTTPServersConfigReturnsOnCall map[int]struct {
+	writeStreamServersConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeManager) WriteHTTPServersConfig(arg1 string, arg2 []byte) error {
+func (fake *FakeManager) ApplyStagedConfigs() error {
+	fake.applyStagedConfigsMutex.Lock()
+	ret, specificReturn := fake.applyStagedConfigsReturnsOnCall[len(fake.applyStagedConfigsArgsForCall)]
+	fake.applyStagedConfigsArgsForCall = append(fake.applyStagedConfigsArgsForCall, struct {
+	}{})
+	stub := fake.ApplyStagedConfigsStub
+	fakeReturns := fake.applyStagedConfigsReturns
+	fake.recordInvocation("ApplyStagedConfigs", []interface{}{})
+	fake.applyStagedConfigsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) ApplyStagedConfigsCallCount() int {
+	fake.applyStagedConfigsMutex.RLock()
+	defer fake.applyStagedConfigsMutex.RUnlock()
+	return len(fake.applyStagedConfigsArgsForCall)
+}
+
+func (fake *FakeManager) ApplyStagedConfigsCalls(stub func() error) {
+	fake.applyStagedConfigsMutex.Lock()
+	defer fake.applyStagedConfigsMutex.Unlock()
+	fake.ApplyStagedConfigsStub = stub
+}
+
+func (fake *FakeManager) ApplyStagedConfigsReturns(result1 error) {
+	fake.applyStagedConfigsMutex.Lock()
+	defer fake.applyStagedConfigsMutex.Unlock()
+	fake.ApplyStagedConfigsStub = nil
+	fake.applyStagedConfigsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) ApplyStagedConfigsReturnsOnCall(i int, result1 error) {
+	fake.applyStagedConfigsMutex.Lock()
+	defer fake.applyStagedConfigsMutex.Unlock()
+	fake.ApplyStagedConfigsStub = nil
+	if fake.applyStagedConfigsReturnsOnCall == nil {
+		fake.applyStagedConfigsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyStagedConfigsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) RestorePreviousConfigs() error {
+	fake.restorePreviousConfigsMutex.Lock()
+	ret, specificReturn := fake.restorePreviousConfigsReturnsOnCall[len(fake.restorePreviousConfigsArgsForCall)]
+	fake.restorePreviousConfigsArgsForCall = append(fake.restorePreviousConfigsArgsForCall, struct {
+	}{})
+	stub := fake.RestorePreviousConfigsStub
+	fakeReturns := fake.restorePreviousConfigsReturns
+	fake.recordInvocation("RestorePreviousConfigs", []interface{}{})
+	fake.restorePreviousConfigsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) RestorePreviousConfigsCallCount() int {
+	fake.restorePreviousConfigsMutex.RLock()
+	defer fake.restorePreviousConfigsMutex.RUnlock()
+	return len(fake.restorePreviousConfigsArgsForCall)
+}
+
+func (fake *FakeManager) RestorePreviousConfigsCalls(stub func() error) {
+	fake.restorePreviousConfigsMutex.Lock()
+	defer fake.restorePreviousConfigsMutex.Unlock()
+	fake.RestorePreviousConfigsStub = stub
+}
+
+func (fake *FakeManager) RestorePreviousConfigsReturns(result1 error) {
+	fake.restorePreviousConfigsMutex.Lock()
+	defer fake.restorePreviousConfigsMutex.Unlock()
+	fake.RestorePreviousConfigsStub = nil
+	fake.restorePreviousConfigsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) RestorePreviousConfigsReturnsOnCall(i int, result1 error) {
+	fake.restorePreviousConfigsMutex.Lock()
+	defer fake.restorePreviousConfigsMutex.Unlock()
+	fake.RestorePreviousConfigsStub = nil
+	if fake.restorePreviousConfigsReturnsOnCall == nil {
+		fake.restorePreviousConfigsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restorePreviousConfigsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WriteHTTPConfigs(arg1 map[string][]byte) error {
+	fake.writeHTTPConfigsMutex.Lock()
+	ret, specificReturn := fake.writeHTTPConfigsReturnsOnCall[len(fake.writeHTTPConfigsArgsForCall)]
+	fake.writeHTTPConfigsArgsForCall = append(fake.writeHTTPConfigsArgsForCall, struct {
+		arg1 map[string][]byte
+	}{arg1})
+	stub := fake.WriteHTTPConfigsStub
+	fakeReturns := fake.writeHTTPConfigsReturns
+	fake.recordInvocation("WriteHTTPConfigs", []interface{}{arg1})
+	fake.writeHTTPConfigsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) WriteHTTPConfigsCallCount() int {
+	fake.writeHTTPConfigsMutex.RLock()
+	defer fake.writeHTTPConfigsMutex.RUnlock()
+	return len(fake.writeHTTPConfigsArgsForCall)
+}
+
+func (fake *FakeManager) WriteHTTPConfigsCalls(stub func(map[string][]byte) error) {
+	fake.writeHTTPConfigsMutex.Lock()
+	defer fake.writeHTTPConfigsMutex.Unlock()
+	fake.WriteHTTPConfigsStub = stub
+}
+
+func (fake *FakeManager) WriteHTTPConfigsArgsForCall(i int) map[string][]byte {
+	fake.writeHTTPConfigsMutex.RLock()
+	defer fake.writeHTTPConfigsMutex.RUnlock()
+	argsForCall := fake.writeHTTPConfigsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) WriteHTTPConfigsReturns(result1 error) {
+	fake.writeHTTPConfigsMutex.Lock()
+	defer fake.writeHTTPConfigsMutex.Unlock()
+	fake.WriteHTTPConfigsStub = nil
+	fake.writeHTTPConfigsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WriteHTTPConfigsReturnsOnCall(i int, result1 error) {
+	fake.writeHTTPConfigsMutex.Lock()
+	defer fake.writeHTTPConfigsMutex.Unlock()
+	fake.WriteHTTPConfigsStub = nil
+	if fake.writeHTTPConfigsReturnsOnCall == nil {
+		fake.writeHTTPConfigsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeHTTPConfigsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WriteMainConfig(arg1 []byte) error {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.writeMainConfigMutex.Lock()
+	ret, specificReturn := fake.writeMainConfigReturnsOnCall[len(fake.writeMainConfigArgsForCall)]
+	fake.writeMainConfigArgsForCall = append(fake.writeMainConfigArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.WriteMainConfigStub
+	fakeReturns := fake.writeMainConfigReturns
+	fake.recordInvocation("WriteMainConfig", []interface{}{arg1Copy})
+	fake.writeMainConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) WriteMainConfigCallCount() int {
+	fake.writeMainConfigMutex.RLock()
+	defer fake.writeMainConfigMutex.RUnlock()
+	return len(fake.writeMainConfigArgsForCall)
+}
+
+func (fake *FakeManager) WriteMainConfigCalls(stub func([]byte) error) {
+	fake.writeMainConfigMutex.Lock()
+	defer fake.writeMainConfigMutex.Unlock()
+	fake.WriteMainConfigStub = stub
+}
+
+func (fake *FakeManager) WriteMainConfigArgsForCall(i int) []byte {
+	fake.writeMainConfigMutex.RLock()
+	defer fake.writeMainConfigMutex.RUnlock()
+	argsForCall := fake.writeMainConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) WriteMainConfigReturns(result1 error) {
+	fake.writeMainConfigMutex.Lock()
+	defer fake.writeMainConfigMutex.Unlock()
+	fake.WriteMainConfigStub = nil
+	fake.writeMainConfigReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WriteMainConfigReturnsOnCall(i int, result1 error) {
+	fake.writeMainConfigMutex.Lock()
+	defer fake.writeMainConfigMutex.Unlock()
+	fake.WriteMainConfigStub = nil
+	if fake.writeMainConfigReturnsOnCall == nil {
+		fake.writeMainConfigReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeMainConfigReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WriteStreamServersConfig(arg1 string, arg2 []byte) error {
 	var arg2Copy []byte
 	if arg2 != nil {
 		arg2Copy = make([]byte, len(arg2))
 		copy(arg2Copy, arg2)
 	}
-	fake.writeHTTPServersConfigMutex.Lock()
-	ret, specificReturn := fake.writeHTTPServersConfigReturnsOnCall[len(fake.writeHTTPServersConfigArgsForCall)]
-	fake.writeHTTPServersConfigArgsForCall = append(fake.writeHTTPServersConfigArgsForCall, struct {
+	fake.writeStreamServersConfigMutex.Lock()
+	ret, specificReturn := fake.writeStreamServersConfigReturnsOnCall[len(fake.writeStreamServersConfigArgsForCall)]
+	fake.writeStreamServersConfigArgsForCall = append(fake.writeStreamServersConfigArgsForCall, struct {
 		arg1 string
 		arg2 []byte
 	}{arg1, arg2Copy})
-	stub := fake.WriteHTTPServersConfigStub
-	fakeReturns := fake.writeHTTPServersConfigReturns
-	fake.recordInvocation("WriteHTTPServersConfig", []interface{}{arg1, arg2Copy})
-	fake.writeHTTPServersConfigMutex.Unlock()
+	stub := fake.WriteStreamServersConfigStub
+	fakeReturns := fake.writeStreamServersConfigReturns
+	fake.recordInvocation("WriteStreamServersConfig", []interface{}{arg1, arg2Copy})
+	fake.writeStreamServersConfigMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -49,44 +324,44 @@ func (fake *FakeManager) WriteHTTPServersConfig(arg1 string, arg2 []byte) error
 	return fakeReturns.result1
 }
 
-func (fake *FakeManager) WriteHTTPServersConfigCallCount() int {
-	fake.writeHTTPServersConfigMutex.RLock()
-	defer fake.writeHTTPServersConfigMutex.RUnlock()
-	return len(fake.writeHTTPServersConfigArgsForCall)
+func (fake *FakeManager) WriteStreamServersConfigCallCount() int {
+	fake.writeStreamServersConfigMutex.RLock()
+	defer fake.writeStreamServersConfigMutex.RUnlock()
+	return len(fake.writeStreamServersConfigArgsForCall)
 }
 
-func (fake *FakeManager) WriteHTTPServersConfigCalls(stub func(string, []byte) error) {
-	fake.writeHTTPServersConfigMutex.Lock()
-	defer fake.writeHTTPServersConfigMutex.Unlock()
-	fake.WriteHTTPServersConfigStub = stub
+func (fake *FakeManager) WriteStreamServersConfigCalls(stub func(string, []byte) error) {
+	fake.writeStreamServersConfigMutex.Lock()
+	defer fake.writeStreamServersConfigMutex.Unlock()
+	fake.WriteStreamServersConfigStub = stub
 }
 
-func (fake *FakeManager) WriteHTTPServersConfigArgsForCall(i int) (string, []byte) {
-	fake.writeHTTPServersConfigMutex.RLock()
-	defer fake.writeHTTPServersConfigMutex.RUnlock()
-	argsForCall := fake.writeHTTPServersConfigArgsForCall[i]
+func (fake *FakeManager) WriteStreamServersConfigArgsForCall(i int) (string, []byte) {
+	fake.writeStreamServersConfigMutex.RLock()
+	defer fake.writeStreamServersConfigMutex.RUnlock()
+	argsForCall := fake.writeStreamServersConfigArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeManager) WriteHTTPServersConfigReturns(result1 error) {
-	fake.writeHTTPServersConfigMutex.Lock()
-	defer fake.writeHTTPServersConfigMutex.Unlock()
-	fake.WriteHTTPServersConfigStub = nil
-	fake.writeHTTPServersConfigReturns = struct {
+func (fake *FakeManager) WriteStreamServersConfigReturns(result1 error) {
+	fake.writeStreamServersConfigMutex.Lock()
+	defer fake.writeStreamServersConfigMutex.Unlock()
+	fake.WriteStreamServersConfigStub = nil
+	fake.writeStreamServersConfigReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeManager) WriteHTTPServersConfigReturnsOnCall(i int, result1 error) {
-	fake.writeHTTPServersConfigMutex.Lock()
-	defer fake.writeHTTPServersConfigMutex.Unlock()
-	fake.WriteHTTPServersConfigStub = nil
-	if fake.writeHTTPServersConfigReturnsOnCall == nil {
-		fake.writeHTTPServersConfigReturnsOnCall = make(map[int]struct {
+func (fake *FakeManager) WriteStreamServersConfigReturnsOnCall(i int, result1 error) {
+	fake.writeStreamServersConfigMutex.Lock()
+	defer fake.writeStreamServersConfigMutex.Unlock()
+	fake.WriteStreamServersConfigStub = nil
+	if fake.writeStreamServersConfigReturnsOnCall == nil {
+		fake.writeStreamServersConfigReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.writeHTTPServersConfigReturnsOnCall[i] = struct {
+	fake.writeStreamServersConfigReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -94,8 +369,16 @@ func (fake *FakeManager) WriteHTTPServersConfigReturnsOnCall(i int, result1 erro
 func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.writeHTTPServersConfigMutex.RLock()
-	defer fake.writeHTTPServersConfigMutex.RUnlock()
+	fake.applyStagedConfigsMutex.RLock()
+	defer fake.applyStagedConfigsMutex.RUnlock()
+	fake.restorePreviousConfigsMutex.RLock()
+	defer fake.restorePreviousConfigsMutex.RUnlock()
+	fake.writeHTTPConfigsMutex.RLock()
+	defer fake.writeHTTPConfigsMutex.RUnlock()
+	fake.writeMainConfigMutex.RLock()
+	defer fake.writeMainConfigMutex.RUnlock()
+	fake.writeStreamServersConfigMutex.RLock()
+	defer fake.writeStreamServersConfigMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,49 +1,417 @@
 package file
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
-const confdFolder = "/etc/nginx/conf.d"
+const (
+	// nginxFolder is the folder of the configs used by NGINX.
+	nginxFolder = "/etc/nginx"
+
+	// The paths of the configs relative to nginxFolder. The staging and the previous folders have the same layout.
+	mainConfigName        = "nginx.conf"
+	confdFolderName       = "conf.d"
+	streamConfdFolderName = "stream-conf.d"
+
+	// stagingFolderName is the folder that holds the configs before they are validated and applied.
+	// It always holds the latest written configs, so that only the configs that change are written.
+	stagingFolderName = "staging"
+	// previousFolderName is the folder that holds the configs that the last ApplyStagedConfigs call replaced
+	// or removed.
+	previousFolderName = "previous"
+
+	configExtension = ".conf"
+)
+
+// StagedMainConfigPath is the path of the staged main config. The main config includes the other configs
+// using the paths relative to its folder, so that the staged configs can be validated by NGINX as a whole.
+const StagedMainConfigPath = nginxFolder + "/" + stagingFolderName + "/" + mainConfigName
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Manager
 
 // Manager manages NGINX configuration files.
+// The configs are written to the staging folder first. NGINX uses them only after they are applied.
+// A config is written only if its content changes, so that an update of a few servers doesn't rewrite all configs.
 type Manager interface {
-	// WriteHTTPServersConfig writes the http servers config on the file system.
-	// The name distinguishes this config among all other configs. For that, it must be unique.
-	// Note that name is not the name of the corresponding configuration file.
-	WriteHTTPServersConfig(name string, cfg []byte) error
+	// WriteMainConfig writes the main config to the staging folder.
+	WriteMainConfig(cfg []byte) error
+	// WriteHTTPConfigs writes the http configs to the staging folder. The keys of cfgs are the names of the
+	// configs, which distinguish them among all other http configs. Note that a name is not the name of the
+	// corresponding configuration file.
+	// The staged http configs that are not in cfgs are removed.
+	WriteHTTPConfigs(cfgs map[string][]byte) error
+	// WriteStreamServersConfig writes the stream servers config to the staging folder.
+	// The name distinguishes this config among all other stream configs. For that, it must be unique.
+	WriteStreamServersConfig(name string, cfg []byte) error
+	// ApplyStagedConfigs copies the staged configs that differ from the configs used by NGINX to the folders
+	// used by NGINX and removes the configs that are not staged. The replaced and removed configs are saved,
+	// so that they can be restored. If it fails, some of the configs might be already applied, so
+	// RestorePreviousConfigs must be called.
+	ApplyStagedConfigs() error
+	// RestorePreviousConfigs restores the configs that NGINX used before the last ApplyStagedConfigs call.
+	// It does nothing if that call didn't apply any configs or the configs are already restored.
+	RestorePreviousConfigs() error
+}
+
+// configsDiff is the difference between the staged configs and the configs used by NGINX.
+// The configs are identified by their paths relative to the folder of the configs.
+type configsDiff struct {
+	// added are the staged configs that NGINX doesn't use.
+	added []string
+	// changed are the staged configs that differ from the configs used by NGINX.
+	changed []string
+	// removed are the configs used by NGINX that are not staged.
+	removed []string
 }
 
 // ManagerImpl is an implementation of Manager.
-type ManagerImpl struct{}
+type ManagerImpl struct {
+	// applied is the diff applied by the last ApplyStagedConfigs call. It is nil if there is nothing to restore.
+	applied *configsDiff
+	folder  string
+}
 
 // NewManagerImpl creates a new NewManagerImpl.
 func NewManagerImpl() *ManagerImpl {
-	return &ManagerImpl{}
+	return newManagerImpl(nginxFolder)
+}
+
+func newManagerImpl(folder string) *ManagerImpl {
+	return &ManagerImpl{
+		folder: folder,
+	}
+}
+
+func (m *ManagerImpl) WriteMainConfig(cfg []byte) error {
+	return writeConfigIfChanged(filepath.Join(m.stagingFolder(), mainConfigName), cfg)
+}
+
+func (m *ManagerImpl) WriteHTTPConfigs(cfgs map[string][]byte) error {
+	folder := filepath.Join(m.stagingFolder(), confdFolderName)
+
+	keep := make(map[string]struct{}, len(cfgs))
+
+	for name, cfg := range cfgs {
+		fileName := name + configExtension
+
+		err := writeConfigIfChanged(filepath.Join(folder, fileName), cfg)
+		if err != nil {
+			return err
+		}
+
+		keep[fileName] = struct{}{}
+	}
+
+	return removeConfigs(folder, keep)
+}
+
+func (m *ManagerImpl) WriteStreamServersConfig(name string, cfg []byte) error {
+	return writeConfigIfChanged(filepath.Join(m.stagingFolder(), streamConfdFolderName, name+configExtension), cfg)
+}
+
+func (m *ManagerImpl) ApplyStagedConfigs() error {
+	// the configs applied by the previous call can't be restored, because the configs they replaced are cleared below
+	m.applied = nil
+
+	diff, err := diffConfigs(m.stagingFolder(), m.folder)
+	if err != nil {
+		return err
+	}
+
+	// save the configs that are about to be replaced or removed. NGINX keeps using them until it is reloaded.
+	err = clearConfigs(m.previousFolder())
+	if err != nil {
+		return err
+	}
+
+	for _, name := range append(diff.changed, diff.removed...) {
+		err := copyConfig(filepath.Join(m.folder, name), filepath.Join(m.previousFolder(), name))
+		if err != nil {
+			return err
+		}
+	}
+
+	m.applied = &diff
+
+	for _, name := range append(diff.added, diff.changed...) {
+		err := replaceConfig(filepath.Join(m.stagingFolder(), name), filepath.Join(m.folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range diff.removed {
+		err := removeConfig(filepath.Join(m.folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ManagerImpl) RestorePreviousConfigs() error {
+	if m.applied == nil {
+		return nil
+	}
+
+	for _, name := range append(m.applied.changed, m.applied.removed...) {
+		err := moveConfig(filepath.Join(m.previousFolder(), name), filepath.Join(m.folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range m.applied.added {
+		err := removeConfig(filepath.Join(m.folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	m.applied = nil
+
+	return nil
+}
+
+// CreateFolders creates the folders for the http and stream servers configs, the staging and the previous folders.
+// The folders must exist before NGINX starts, because the main config includes the configs from them.
+func (m *ManagerImpl) CreateFolders() error {
+	for _, root := range []string{m.folder, m.stagingFolder(), m.previousFolder()} {
+		for _, name := range []string{confdFolderName, streamConfdFolderName} {
+			folder := filepath.Join(root, name)
+
+			err := os.MkdirAll(folder, 0o750)
+			if err != nil {
+				return fmt.Errorf("failed to create folder %s: %w", folder, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ManagerImpl) stagingFolder() string {
+	return filepath.Join(m.folder, stagingFolderName)
+}
+
+func (m *ManagerImpl) previousFolder() string {
+	return filepath.Join(m.folder, previousFolderName)
+}
+
+// diffConfigs returns the difference between the configs in the staged folder and the configs in the folder.
+// The main config is never removed, because NGINX can't run without it.
+func diffConfigs(stagedFolder, folder string) (configsDiff, error) {
+	staged, err := listConfigs(stagedFolder)
+	if err != nil {
+		return configsDiff{}, err
+	}
+
+	current, err := listConfigs(folder)
+	if err != nil {
+		return configsDiff{}, err
+	}
+
+	var diff configsDiff
+
+	for name := range staged {
+		if _, exist := current[name]; !exist {
+			diff.added = append(diff.added, name)
+			continue
+		}
+
+		equal, err := equalConfigs(filepath.Join(stagedFolder, name), filepath.Join(folder, name))
+		if err != nil {
+			return configsDiff{}, err
+		}
+
+		if !equal {
+			diff.changed = append(diff.changed, name)
+		}
+	}
+
+	for name := range current {
+		if _, exist := staged[name]; !exist && name != mainConfigName {
+			diff.removed = append(diff.removed, name)
+		}
+	}
+
+	// sort the configs for predictable order
+	sort.Strings(diff.added)
+	sort.Strings(diff.changed)
+	sort.Strings(diff.removed)
+
+	return diff, nil
+}
+
+// listConfigs returns the paths of the configs in the folder relative to it.
+func listConfigs(folder string) (map[string]struct{}, error) {
+	configs := make(map[string]struct{})
+
+	if _, err := os.Stat(filepath.Join(folder, mainConfigName)); err == nil {
+		configs[mainConfigName] = struct{}{}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to stat config %s: %w", filepath.Join(folder, mainConfigName), err)
+	}
+
+	for _, name := range []string{confdFolderName, streamConfdFolderName} {
+		names, err := listFolderConfigs(filepath.Join(folder, name))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range names {
+			configs[filepath.Join(name, n)] = struct{}{}
+		}
+	}
+
+	return configs, nil
+}
+
+// listFolderConfigs returns the names of the configs in the folder. Other files, like the temporary files of
+// replaceConfig, are ignored.
+func listFolderConfigs(folder string) ([]string, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read folder %s: %w", folder, err)
+	}
+
+	names := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), configExtension) {
+			names = append(names, e.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// removeConfigs removes the configs in the folder except for the ones to keep.
+func removeConfigs(folder string, keep map[string]struct{}) error {
+	names, err := listFolderConfigs(folder)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, exist := keep[name]; exist {
+			continue
+		}
+
+		err := removeConfig(filepath.Join(folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (m *ManagerImpl) WriteHTTPServersConfig(name string, cfg []byte) error {
-	path := getPathForServerConfig(name)
+// clearConfigs removes all configs in the folder.
+func clearConfigs(folder string) error {
+	names, err := listConfigs(folder)
+	if err != nil {
+		return err
+	}
 
+	for name := range names {
+		err := removeConfig(filepath.Join(folder, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func equalConfigs(path1, path2 string) (bool, error) {
+	cfg1, err := os.ReadFile(path1)
+	if err != nil {
+		return false, fmt.Errorf("failed to read config %s: %w", path1, err)
+	}
+
+	cfg2, err := os.ReadFile(path2)
+	if err != nil {
+		return false, fmt.Errorf("failed to read config %s: %w", path2, err)
+	}
+
+	return bytes.Equal(cfg1, cfg2), nil
+}
+
+func writeConfig(path string, cfg []byte) error {
 	file, err := os.Create(path)
 	if err != nil {
-		return fmt.Errorf("failed to create server config %s: %w", path, err)
+		return fmt.Errorf("failed to create config %s: %w", path, err)
 	}
 
 	defer file.Close()
 
 	_, err = file.Write(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to write server config %s: %w", path, err)
+		return fmt.Errorf("failed to write config %s: %w", path, err)
 	}
 
 	return nil
 }
 
-func getPathForServerConfig(name string) string {
-	return filepath.Join(confdFolder, name+".conf")
+// writeConfigIfChanged writes the config unless the file already has the same content.
+func writeConfigIfChanged(path string, cfg []byte) error {
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, cfg) {
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	return writeConfig(path, cfg)
+}
+
+func copyConfig(srcPath string, path string) error {
+	cfg, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config %s: %w", srcPath, err)
+	}
+
+	return writeConfig(path, cfg)
+}
+
+// replaceConfig copies the config to the path through a temporary file, so that NGINX never reads a partially
+// written config.
+func replaceConfig(srcPath string, path string) error {
+	tmpPath := path + ".tmp"
+
+	err := copyConfig(srcPath, tmpPath)
+	if err != nil {
+		return err
+	}
+
+	return moveConfig(tmpPath, path)
+}
+
+// moveConfig moves the config to the path. The move is atomic, so NGINX never reads a partially written config.
+func moveConfig(srcPath string, path string) error {
+	err := os.Rename(srcPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to move config %s: %w", srcPath, err)
+	}
+
+	return nil
+}
+
+func removeConfig(path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove config %s: %w", path, err)
+	}
+
+	return nil
 }
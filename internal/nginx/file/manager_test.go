@@ -1,12 +1,242 @@
 package file
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
 
-func TestGetPathForServerConfig(t *testing.T) {
-	expected := "/etc/nginx/conf.d/test.example.com.conf"
+	"github.com/google/go-cmp/cmp"
+)
 
-	result := getPathForServerConfig("test.example.com")
-	if result != expected {
-		t.Errorf("getPathForServerConfig() returned %q but expected %q", result, expected)
+func newTestManager(t *testing.T) *ManagerImpl {
+	m := newManagerImpl(t.TempDir())
+
+	if err := m.CreateFolders(); err != nil {
+		t.Fatalf("CreateFolders() returned unexpected error: %v", err)
+	}
+
+	return m
+}
+
+// readConfigs returns the configs in the folder by their paths relative to it.
+func readConfigs(t *testing.T, folder string) map[string]string {
+	names, err := listConfigs(folder)
+	if err != nil {
+		t.Fatalf("listConfigs() returned unexpected error: %v", err)
+	}
+
+	configs := make(map[string]string, len(names))
+
+	for name := range names {
+		cfg, err := os.ReadFile(filepath.Join(folder, name))
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+
+		configs[name] = string(cfg)
+	}
+
+	return configs
+}
+
+func TestWriteHTTPConfigs(t *testing.T) {
+	m := newTestManager(t)
+
+	err := m.WriteHTTPConfigs(map[string][]byte{
+		"maps":   []byte("maps"),
+		"server": []byte("server"),
+		"stale":  []byte("stale"),
+	})
+	if err != nil {
+		t.Fatalf("WriteHTTPConfigs() returned unexpected error: %v", err)
+	}
+
+	unchangedPath := filepath.Join(m.stagingFolder(), confdFolderName, "maps.conf")
+
+	// the modification time reveals whether the unchanged config was rewritten
+	past := mustSetPastModTime(t, unchangedPath)
+
+	err = m.WriteHTTPConfigs(map[string][]byte{
+		"maps":   []byte("maps"),
+		"server": []byte("updated server"),
+	})
+	if err != nil {
+		t.Fatalf("WriteHTTPConfigs() returned unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"conf.d/maps.conf":   "maps",
+		"conf.d/server.conf": "updated server",
+	}
+
+	if diff := cmp.Diff(expected, readConfigs(t, m.stagingFolder())); diff != "" {
+		t.Errorf("WriteHTTPConfigs() mismatch on staged configs (-want +got):\n%s", diff)
+	}
+
+	info, err := os.Stat(unchangedPath)
+	if err != nil {
+		t.Fatalf("failed to stat config: %v", err)
+	}
+
+	if !info.ModTime().Equal(past) {
+		t.Errorf("WriteHTTPConfigs() rewrote the unchanged config")
+	}
+}
+
+func mustSetPastModTime(t *testing.T, path string) (past time.Time) {
+	past = time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatalf("failed to change the modification time: %v", err)
+	}
+
+	return past
+}
+
+func TestApplyStagedConfigsAndRestorePreviousConfigs(t *testing.T) {
+	m := newTestManager(t)
+
+	writeConfigs := func(main string, httpCfgs map[string][]byte, stream string) {
+		if err := m.WriteMainConfig([]byte(main)); err != nil {
+			t.Fatalf("WriteMainConfig() returned unexpected error: %v", err)
+		}
+		if err := m.WriteHTTPConfigs(httpCfgs); err != nil {
+			t.Fatalf("WriteHTTPConfigs() returned unexpected error: %v", err)
+		}
+		if err := m.WriteStreamServersConfig("stream-servers", []byte(stream)); err != nil {
+			t.Fatalf("WriteStreamServersConfig() returned unexpected error: %v", err)
+		}
+	}
+
+	writeConfigs("main", map[string][]byte{"a": []byte("a"), "b": []byte("b")}, "stream")
+
+	// the initial configs
+	if err := m.ApplyStagedConfigs(); err != nil {
+		t.Fatalf("ApplyStagedConfigs() returned unexpected error: %v", err)
+	}
+
+	initial := map[string]string{
+		"nginx.conf":                        "main",
+		"conf.d/a.conf":                     "a",
+		"conf.d/b.conf":                     "b",
+		"stream-conf.d/stream-servers.conf": "stream",
+	}
+
+	if diff := cmp.Diff(initial, readConfigs(t, m.folder)); diff != "" {
+		t.Errorf("ApplyStagedConfigs() mismatch on initial configs (-want +got):\n%s", diff)
+	}
+
+	writeConfigs("main", map[string][]byte{"b": []byte("updated b"), "c": []byte("c")}, "stream")
+
+	if err := m.ApplyStagedConfigs(); err != nil {
+		t.Fatalf("ApplyStagedConfigs() returned unexpected error: %v", err)
+	}
+
+	expectedDiff := configsDiff{
+		added:   []string{"conf.d/c.conf"},
+		changed: []string{"conf.d/b.conf"},
+		removed: []string{"conf.d/a.conf"},
+	}
+
+	if diff := cmp.Diff(expectedDiff, *m.applied, cmp.AllowUnexported(configsDiff{})); diff != "" {
+		t.Errorf("ApplyStagedConfigs() mismatch on applied diff (-want +got):\n%s", diff)
+	}
+
+	updated := map[string]string{
+		"nginx.conf":                        "main",
+		"conf.d/b.conf":                     "updated b",
+		"conf.d/c.conf":                     "c",
+		"stream-conf.d/stream-servers.conf": "stream",
+	}
+
+	if diff := cmp.Diff(updated, readConfigs(t, m.folder)); diff != "" {
+		t.Errorf("ApplyStagedConfigs() mismatch on updated configs (-want +got):\n%s", diff)
+	}
+
+	// only the replaced and the removed configs are saved
+	previous := map[string]string{
+		"conf.d/a.conf": "a",
+		"conf.d/b.conf": "b",
+	}
+
+	if diff := cmp.Diff(previous, readConfigs(t, m.previousFolder())); diff != "" {
+		t.Errorf("ApplyStagedConfigs() mismatch on previous configs (-want +got):\n%s", diff)
+	}
+
+	if err := m.RestorePreviousConfigs(); err != nil {
+		t.Fatalf("RestorePreviousConfigs() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(initial, readConfigs(t, m.folder)); diff != "" {
+		t.Errorf("RestorePreviousConfigs() mismatch on restored configs (-want +got):\n%s", diff)
+	}
+
+	// nothing to restore
+	if err := m.RestorePreviousConfigs(); err != nil {
+		t.Errorf("RestorePreviousConfigs() returned unexpected error when there is nothing to restore: %v", err)
+	}
+
+	if diff := cmp.Diff(initial, readConfigs(t, m.folder)); diff != "" {
+		t.Errorf("RestorePreviousConfigs() changed the configs when there is nothing to restore (-want +got):\n%s", diff)
+	}
+}
+
+func TestRestorePreviousConfigsAfterFailedApply(t *testing.T) {
+	m := newTestManager(t)
+
+	writeHTTPConfigs := func(cfgs map[string][]byte) {
+		if err := m.WriteHTTPConfigs(cfgs); err != nil {
+			t.Fatalf("WriteHTTPConfigs() returned unexpected error: %v", err)
+		}
+	}
+
+	writeHTTPConfigs(map[string][]byte{"a": []byte("a"), "b": []byte("b")})
+
+	if err := m.ApplyStagedConfigs(); err != nil {
+		t.Fatalf("ApplyStagedConfigs() returned unexpected error: %v", err)
+	}
+
+	initial := readConfigs(t, m.folder)
+
+	writeHTTPConfigs(map[string][]byte{"b": []byte("updated b"), "c": []byte("c"), "d": []byte("d")})
+
+	// the temporary file of d can't be created, so the apply fails after c is added, but before b is replaced
+	// and a is removed
+	if err := os.Mkdir(filepath.Join(m.folder, confdFolderName, "d.conf.tmp"), 0o750); err != nil {
+		t.Fatalf("failed to create folder: %v", err)
+	}
+
+	if err := m.ApplyStagedConfigs(); err == nil {
+		t.Fatalf("ApplyStagedConfigs() didn't return an error")
+	}
+
+	if _, err := os.Stat(filepath.Join(m.folder, confdFolderName, "c.conf")); err != nil {
+		t.Fatalf("ApplyStagedConfigs() didn't add c before the failure: %v", err)
+	}
+
+	if err := m.RestorePreviousConfigs(); err != nil {
+		t.Fatalf("RestorePreviousConfigs() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(initial, readConfigs(t, m.folder)); diff != "" {
+		t.Errorf("RestorePreviousConfigs() mismatch on restored configs (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffConfigsKeepsMainConfig(t *testing.T) {
+	m := newTestManager(t)
+
+	if err := os.WriteFile(filepath.Join(m.folder, mainConfigName), []byte("main"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	diff, err := diffConfigs(m.stagingFolder(), m.folder)
+	if err != nil {
+		t.Fatalf("diffConfigs() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(configsDiff{}, diff, cmp.AllowUnexported(configsDiff{})); diff != "" {
+		t.Errorf("diffConfigs() mismatch (-want +got):\n%s", diff)
 	}
 }
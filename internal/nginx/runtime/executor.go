@@ -0,0 +1,33 @@
+package runtime
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . CommandExecutor
+
+// CommandExecutor executes commands.
+type CommandExecutor interface {
+	// Execute executes the command with the arguments using the root as the root directory.
+	// It returns the combined stdout and stderr of the command.
+	Execute(ctx context.Context, root string, name string, args ...string) ([]byte, error)
+}
+
+// CommandExecutorImpl implements CommandExecutor.
+type CommandExecutorImpl struct{}
+
+// NewCommandExecutorImpl creates a new CommandExecutorImpl.
+func NewCommandExecutorImpl() *CommandExecutorImpl {
+	return &CommandExecutorImpl{}
+}
+
+func (e *CommandExecutorImpl) Execute(ctx context.Context, root string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = "/"
+	// the command runs in the file system of another container, so the chroot requires CAP_SYS_CHROOT
+	cmd.SysProcAttr = &syscall.SysProcAttr{Chroot: root}
+
+	return cmd.CombinedOutput()
+}
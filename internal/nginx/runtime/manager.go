@@ -2,15 +2,41 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 )
 
-const pidFile = "/etc/nginx/nginx.pid"
+const (
+	// pidFile is the pid file of the NGINX main process. The main config sets it relative to the NGINX prefix,
+	// which is /etc/nginx.
+	pidFile     = "/etc/nginx/nginx.pid"
+	nginxBinary = "/usr/sbin/nginx"
+	// childrenFileFmt is the format of the path of the file with the PIDs of the child processes of a process.
+	childrenFileFmt = "/proc/%[1]d/task/%[1]d/children"
+
+	// statFileFmt is the format of the path of the file with the status of a process.
+	statFileFmt = "/proc/%d/stat"
+
+	// readyCheckInitialInterval and readyCheckMaxInterval bound the intervals between the checks whether NGINX
+	// is running. The interval doubles after every check.
+	readyCheckInitialInterval = 100 * time.Millisecond
+	readyCheckMaxInterval     = 5 * time.Second
+
+	// reloadTimeout is the time NGINX has to start new worker processes after a reload.
+	reloadTimeout       = 10 * time.Second
+	reloadCheckInterval = 100 * time.Millisecond
+)
+
+// ErrReloadUnverified means that NGINX was signaled to reload, but its worker processes can't be found, so it is
+// unknown whether NGINX applied the configuration. For example, the kernel might not provide the children files
+// of the processes.
+var ErrReloadUnverified = errors.New("cannot check whether NGINX applied the configuration")
 
 type readFileFunc func(string) ([]byte, error)
 
@@ -18,29 +44,75 @@ type readFileFunc func(string) ([]byte, error)
 
 // Manager manages the runtime of NGINX.
 type Manager interface {
+	// WaitForReady waits until the NGINX main process is running. It is a blocking operation.
+	// It returns an error only if the ctx is canceled.
+	WaitForReady(ctx context.Context) error
+	// Validate validates the NGINX configuration with the main config at the path. The error includes
+	// the output of NGINX. It is a blocking operation.
+	Validate(ctx context.Context, mainConfigPath string) error
 	// Reload reloads NGINX configuration. It is a blocking operation.
+	// It returns an error if NGINX fails to apply the configuration. If it is unknown whether NGINX applied
+	// the configuration, because the worker processes of NGINX can't be found, the error wraps ErrReloadUnverified.
 	Reload(ctx context.Context) error
 }
 
 // ManagerImpl implements Manager.
-type ManagerImpl struct{}
+type ManagerImpl struct {
+	executor CommandExecutor
+	readFile readFileFunc
+}
 
 // NewManagerImpl creates a new ManagerImpl.
-func NewManagerImpl() *ManagerImpl {
-	return &ManagerImpl{}
+func NewManagerImpl(executor CommandExecutor) *ManagerImpl {
+	return &ManagerImpl{
+		executor: executor,
+		readFile: os.ReadFile,
+	}
+}
+
+func (m *ManagerImpl) WaitForReady(ctx context.Context) error {
+	return waitForMainProcess(ctx, m.readFile, readyCheckInitialInterval, readyCheckMaxInterval)
+}
+
+func (m *ManagerImpl) Validate(ctx context.Context, mainConfigPath string) error {
+	pid, err := findMainProcess(m.readFile)
+	if err != nil {
+		return fmt.Errorf("failed to find NGINX main process: %w", err)
+	}
+
+	// The Gateway container doesn't include NGINX, so the NGINX binary runs in the file system of the NGINX
+	// container, which is accessible through the root of the NGINX main process. The configs are in the volume
+	// shared by both containers, so they have the same paths in both file systems.
+	root := fmt.Sprintf("/proc/%d/root", pid)
+
+	// NGINX opens the pid file even when it only tests the config, but the pid file of the NGINX main process
+	// is not writable by the user of the Gateway. The main config sets the pid file relative to the prefix,
+	// so the prefix of the test is the folder of the main config. For the same reason, the error log goes
+	// to stderr.
+	args := []string{"-t", "-q", "-p", filepath.Dir(mainConfigPath), "-e", "stderr", "-c", mainConfigPath}
+
+	output, err := m.executor.Execute(ctx, root, nginxBinary, args...)
+	if err != nil {
+		return fmt.Errorf("NGINX configuration is invalid: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
 }
 
 func (m *ManagerImpl) Reload(ctx context.Context) error {
-	// FIXME(pleshakov): Before reload attempt, make sure NGINX is running.
-	// If the gateway container starts before NGINX container (which is possible), then it is possible that a reload can be attempted
-	// when NGINX is not running yet. Make sure to prevent this case, so we don't get an error.
+	// Note: the gateway container can start before the NGINX container, so WaitForReady must be called before
+	// the first reload.
 
 	// We find the main NGINX PID on every reload because it will change if the NGINX container is restarted.
-	pid, err := findMainProcess(os.ReadFile)
+	pid, err := findMainProcess(m.readFile)
 	if err != nil {
 		return fmt.Errorf("failed to find NGINX main process: %w", err)
 	}
 
+	// The workers are only used to check the outcome of the reload, so failing to find them doesn't prevent
+	// the reload.
+	previousWorkers, workersErr := findWorkerProcesses(pid, m.readFile)
+
 	// send HUP signal to the NGINX main process reload configuration
 	// See https://nginx.org/en/docs/control.html
 	err = syscall.Kill(pid, syscall.SIGHUP)
@@ -48,20 +120,132 @@ func (m *ManagerImpl) Reload(ctx context.Context) error {
 		return fmt.Errorf("failed to send the HUP signal to NGINX main: %w", err)
 	}
 
-	// FIXME(pleshakov)
-	// (1) ensure the reload actually happens.
-	// (2) ensure that in case of an error, the error message can be seen by the admins.
+	if workersErr != nil {
+		return fmt.Errorf("%w: failed to find NGINX worker processes: %v", ErrReloadUnverified, workersErr)
+	}
+
+	// NGINX starts new worker processes only if it successfully applied the new configuration.
+	// Otherwise, it keeps the old workers and reports the error in its error log.
+	return waitForNewWorkers(ctx, pid, previousWorkers, m.readFile, reloadTimeout, reloadCheckInterval)
+}
+
+// waitForMainProcess waits until the pid file exists and the NGINX main process is running.
+// The interval between the checks doubles up to the maxInterval.
+func waitForMainProcess(
+	ctx context.Context,
+	readFile readFileFunc,
+	initialInterval time.Duration,
+	maxInterval time.Duration,
+) error {
+	interval := initialInterval
+
+	for {
+		pid, err := findMainProcess(readFile)
+		if err == nil && isNginxRunning(pid, readFile) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// isNginxRunning checks that the process is a running NGINX process. The pid file can outlive NGINX, for example,
+// when the NGINX container is restarted, and its PID can be reused by another process.
+func isNginxRunning(pid int, readFile readFileFunc) bool {
+	content, err := readFile(fmt.Sprintf(statFileFmt, pid))
+	if err != nil {
+		return false
+	}
+
+	// the content is "PID (COMMAND) STATE ...". The command can include spaces and parentheses.
+	stat := string(content)
 
-	// for now, to prevent a subsequent reload starting before the in-flight reload finishes, we simply sleep.
-	// Fixing (1) will make the sleep unnecessary.
+	start := strings.Index(stat, "(")
+	end := strings.LastIndex(stat, ")")
+	if start == -1 || end < start {
+		return false
+	}
 
-	select {
-	case <-ctx.Done():
-		return nil
-	case <-time.After(1 * time.Second):
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) == 0 {
+		return false
 	}
 
-	return nil
+	command := stat[start+1 : end]
+	state := fields[0]
+
+	// Z is a zombie and X is a dead process
+	return command == "nginx" && state != "Z" && state != "X"
+}
+
+// findWorkerProcesses returns the PIDs of the child processes of the NGINX main process.
+func findWorkerProcesses(mainPID int, readFile readFileFunc) (map[int]struct{}, error) {
+	content, err := readFile(fmt.Sprintf(childrenFileFmt, mainPID))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(content))
+	workers := make(map[int]struct{}, len(fields))
+
+	for _, f := range fields {
+		pid, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid children file content %q: %w", content, err)
+		}
+
+		workers[pid] = struct{}{}
+	}
+
+	return workers, nil
+}
+
+// waitForNewWorkers waits until the NGINX main process has a child process that is not among the previous
+// workers. It returns an error if that doesn't happen within the timeout, ErrReloadUnverified if the workers
+// can't be found, and the error of the ctx if the ctx is canceled.
+func waitForNewWorkers(
+	ctx context.Context,
+	mainPID int,
+	previousWorkers map[int]struct{},
+	readFile readFileFunc,
+	timeout time.Duration,
+	interval time.Duration,
+) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		workers, err := findWorkerProcesses(mainPID, readFile)
+		if err != nil {
+			return fmt.Errorf("%w: failed to find NGINX worker processes: %v", ErrReloadUnverified, err)
+		}
+
+		for pid := range workers {
+			if _, exist := previousWorkers[pid]; !exist {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return fmt.Errorf("NGINX didn't start new worker processes within %v; see the NGINX error log", timeout)
+		case <-ticker.C:
+		}
+	}
 }
 
 func findMainProcess(readFile readFileFunc) (int, error) {
@@ -1,8 +1,13 @@
 package runtime
 
 import (
+	"context"
 	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestFindMainProcess(t *testing.T) {
@@ -68,3 +73,312 @@ func TestFindMainProcess(t *testing.T) {
 		}
 	}
 }
+
+type stubCommandExecutor struct {
+	root   string
+	name   string
+	args   []string
+	output []byte
+	err    error
+}
+
+func (e *stubCommandExecutor) Execute(_ context.Context, root string, name string, args ...string) ([]byte, error) {
+	e.root = root
+	e.name = name
+	e.args = args
+
+	return e.output, e.err
+}
+
+func TestValidate(t *testing.T) {
+	readPidFile := func(string) ([]byte, error) {
+		return []byte("12\n"), nil
+	}
+	readFileError := func(string) ([]byte, error) {
+		return nil, errors.New("error")
+	}
+
+	tests := []struct {
+		executor    *stubCommandExecutor
+		readFile    readFileFunc
+		expectError bool
+		msg         string
+	}{
+		{
+			executor:    &stubCommandExecutor{},
+			readFile:    readPidFile,
+			expectError: false,
+			msg:         "valid config",
+		},
+		{
+			executor: &stubCommandExecutor{
+				output: []byte("nginx: [emerg] unknown directive \"foo\"\n"),
+				err:    errors.New("exit status 1"),
+			},
+			readFile:    readPidFile,
+			expectError: true,
+			msg:         "invalid config",
+		},
+		{
+			executor:    &stubCommandExecutor{},
+			readFile:    readFileError,
+			expectError: true,
+			msg:         "cannot find main process",
+		},
+	}
+
+	for _, test := range tests {
+		mgr := &ManagerImpl{executor: test.executor, readFile: test.readFile}
+
+		err := mgr.Validate(context.Background(), "/etc/nginx/staging/nginx.conf")
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Validate() didn't return error for case %q", test.msg)
+			}
+		} else if err != nil {
+			t.Errorf("Validate() returned unexpected error %v for case %q", err, test.msg)
+		}
+
+		if test.executor.err != nil && !strings.Contains(err.Error(), "unknown directive") {
+			t.Errorf("Validate() returned error %v without the NGINX output for case %q", err, test.msg)
+		}
+
+		if err == nil {
+			expectedArgs := []string{
+				"-t", "-q", "-p", "/etc/nginx/staging", "-e", "stderr", "-c", "/etc/nginx/staging/nginx.conf",
+			}
+			if test.executor.root != "/proc/12/root" || test.executor.name != nginxBinary ||
+				!reflect.DeepEqual(test.executor.args, expectedArgs) {
+				t.Errorf("Validate() executed %q %v in %q for case %q",
+					test.executor.name, test.executor.args, test.executor.root, test.msg)
+			}
+		}
+	}
+}
+
+func TestFindWorkerProcesses(t *testing.T) {
+	readFileFuncGen := func(content []byte) readFileFunc {
+		return func(name string) ([]byte, error) {
+			if name != "/proc/1/task/1/children" {
+				return nil, errors.New("error")
+			}
+			return content, nil
+		}
+	}
+
+	tests := []struct {
+		readFile    readFileFunc
+		expected    map[int]struct{}
+		expectError bool
+		msg         string
+	}{
+		{
+			readFile:    readFileFuncGen([]byte("10 11 ")),
+			expected:    map[int]struct{}{10: {}, 11: {}},
+			expectError: false,
+			msg:         "normal case",
+		},
+		{
+			readFile:    readFileFuncGen([]byte("")),
+			expected:    map[int]struct{}{},
+			expectError: false,
+			msg:         "no workers",
+		},
+		{
+			readFile:    readFileFuncGen([]byte("10 not-a-number")),
+			expected:    nil,
+			expectError: true,
+			msg:         "bad file content",
+		},
+		{
+			readFile: func(string) ([]byte, error) {
+				return nil, errors.New("error")
+			},
+			expected:    nil,
+			expectError: true,
+			msg:         "cannot read file",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := findWorkerProcesses(1, test.readFile)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("findWorkerProcesses() returned %v but expected %v for case %q", result, test.expected, test.msg)
+		}
+
+		if test.expectError {
+			if err == nil {
+				t.Errorf("findWorkerProcesses() didn't return error for case %q", test.msg)
+			}
+		} else if err != nil {
+			t.Errorf("findWorkerProcesses() returned unexpected error %v for case %q", err, test.msg)
+		}
+	}
+}
+
+func TestWaitForNewWorkers(t *testing.T) {
+	previousWorkers := map[int]struct{}{10: {}, 11: {}}
+
+	// readFileSequence returns the contents one by one, repeating the last one.
+	readFileSequence := func(contents ...string) readFileFunc {
+		i := 0
+		return func(string) ([]byte, error) {
+			content := contents[i]
+			if i < len(contents)-1 {
+				i++
+			}
+			return []byte(content), nil
+		}
+	}
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		ctx         context.Context
+		readFile    readFileFunc
+		expectedErr error
+		expectError bool
+		msg         string
+	}{
+		{
+			ctx:         context.Background(),
+			readFile:    readFileSequence("10 11", "10 11 12 13"),
+			expectError: false,
+			msg:         "new workers started",
+		},
+		{
+			ctx:         context.Background(),
+			readFile:    readFileSequence("10 11"),
+			expectError: true,
+			msg:         "no new workers",
+		},
+		{
+			ctx: context.Background(),
+			readFile: func(string) ([]byte, error) {
+				return nil, errors.New("error")
+			},
+			expectedErr: ErrReloadUnverified,
+			expectError: true,
+			msg:         "cannot read file",
+		},
+		{
+			ctx:         canceledCtx,
+			readFile:    readFileSequence("10 11"),
+			expectedErr: context.Canceled,
+			expectError: true,
+			msg:         "canceled context",
+		},
+	}
+
+	for _, test := range tests {
+		err := waitForNewWorkers(test.ctx, 1, previousWorkers, test.readFile, 50*time.Millisecond, time.Millisecond)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("waitForNewWorkers() didn't return error for case %q", test.msg)
+			}
+		} else if err != nil {
+			t.Errorf("waitForNewWorkers() returned unexpected error %v for case %q", err, test.msg)
+		}
+
+		if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("waitForNewWorkers() returned %v but expected %v for case %q", err, test.expectedErr, test.msg)
+		}
+
+		if test.expectedErr == nil && errors.Is(err, ErrReloadUnverified) {
+			t.Errorf("waitForNewWorkers() returned %v for case %q", err, test.msg)
+		}
+	}
+}
+
+func TestIsNginxRunning(t *testing.T) {
+	readFileFuncGen := func(content string) readFileFunc {
+		return func(name string) ([]byte, error) {
+			if name != "/proc/1/stat" {
+				return nil, errors.New("error")
+			}
+			return []byte(content), nil
+		}
+	}
+
+	tests := []struct {
+		readFile readFileFunc
+		expected bool
+		msg      string
+	}{
+		{
+			readFile: readFileFuncGen("1 (nginx) S 0 1 1 0 -1"),
+			expected: true,
+			msg:      "running nginx",
+		},
+		{
+			readFile: readFileFuncGen("1 (nginx) Z 0 1 1 0 -1"),
+			expected: false,
+			msg:      "zombie nginx",
+		},
+		{
+			readFile: readFileFuncGen("1 (my (process)) S 0 1 1 0 -1"),
+			expected: false,
+			msg:      "another process",
+		},
+		{
+			readFile: readFileFuncGen("invalid"),
+			expected: false,
+			msg:      "invalid file content",
+		},
+		{
+			readFile: func(string) ([]byte, error) {
+				return nil, errors.New("error")
+			},
+			expected: false,
+			msg:      "process doesn't exist",
+		},
+	}
+
+	for _, test := range tests {
+		result := isNginxRunning(1, test.readFile)
+		if result != test.expected {
+			t.Errorf("isNginxRunning() returned %v but expected %v for case %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestWaitForMainProcess(t *testing.T) {
+	// readFileAfter returns the pid file and the stat file of a running NGINX only after the given number of
+	// the checks of the pid file.
+	readFileAfter := func(checks int) readFileFunc {
+		pidFileReads := 0
+		return func(name string) ([]byte, error) {
+			switch name {
+			case pidFile:
+				pidFileReads++
+				if pidFileReads <= checks {
+					return nil, errors.New("error")
+				}
+				return []byte("1\n"), nil
+			case "/proc/1/stat":
+				return []byte("1 (nginx) S 0 1 1 0 -1"), nil
+			default:
+				return nil, errors.New("error")
+			}
+		}
+	}
+
+	err := waitForMainProcess(context.Background(), readFileAfter(0), time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Errorf("waitForMainProcess() returned unexpected error %v when NGINX is running", err)
+	}
+
+	err = waitForMainProcess(context.Background(), readFileAfter(3), time.Millisecond, 2*time.Millisecond)
+	if err != nil {
+		t.Errorf("waitForMainProcess() returned unexpected error %v when NGINX starts later", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = waitForMainProcess(ctx, readFileAfter(math.MaxInt), time.Millisecond, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitForMainProcess() returned %v but expected %v when NGINX is not running", err, context.DeadlineExceeded)
+	}
+}
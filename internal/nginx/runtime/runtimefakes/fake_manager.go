@@ -20,6 +20,29 @@ type FakeManager struct {
 	reloadReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateStub        func(context.Context, string) error
+	validateMutex       sync.RWMutex
+	validateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	validateReturns struct {
+		result1 error
+	}
+	validateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitForReadyStub        func(context.Context) error
+	waitForReadyMutex       sync.RWMutex
+	waitForReadyArgsForCall []struct {
+		arg1 context.Context
+	}
+	waitForReadyReturns struct {
+		result1 error
+	}
+	waitForReadyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -85,11 +108,138 @@ func (fake *FakeManager) ReloadReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeManager) Validate(arg1 context.Context, arg2 string) error {
+	fake.validateMutex.Lock()
+	ret, specificReturn := fake.validateReturnsOnCall[len(fake.validateArgsForCall)]
+	fake.validateArgsForCall = append(fake.validateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ValidateStub
+	fakeReturns := fake.validateReturns
+	fake.recordInvocation("Validate", []interface{}{arg1, arg2})
+	fake.validateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) ValidateCallCount() int {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	return len(fake.validateArgsForCall)
+}
+
+func (fake *FakeManager) ValidateCalls(stub func(context.Context, string) error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = stub
+}
+
+func (fake *FakeManager) ValidateArgsForCall(i int) (context.Context, string) {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	argsForCall := fake.validateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeManager) ValidateReturns(result1 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	fake.validateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) ValidateReturnsOnCall(i int, result1 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	if fake.validateReturnsOnCall == nil {
+		fake.validateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WaitForReady(arg1 context.Context) error {
+	fake.waitForReadyMutex.Lock()
+	ret, specificReturn := fake.waitForReadyReturnsOnCall[len(fake.waitForReadyArgsForCall)]
+	fake.waitForReadyArgsForCall = append(fake.waitForReadyArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.WaitForReadyStub
+	fakeReturns := fake.waitForReadyReturns
+	fake.recordInvocation("WaitForReady", []interface{}{arg1})
+	fake.waitForReadyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) WaitForReadyCallCount() int {
+	fake.waitForReadyMutex.RLock()
+	defer fake.waitForReadyMutex.RUnlock()
+	return len(fake.waitForReadyArgsForCall)
+}
+
+func (fake *FakeManager) WaitForReadyCalls(stub func(context.Context) error) {
+	fake.waitForReadyMutex.Lock()
+	defer fake.waitForReadyMutex.Unlock()
+	fake.WaitForReadyStub = stub
+}
+
+func (fake *FakeManager) WaitForReadyArgsForCall(i int) context.Context {
+	fake.waitForReadyMutex.RLock()
+	defer fake.waitForReadyMutex.RUnlock()
+	argsForCall := fake.waitForReadyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) WaitForReadyReturns(result1 error) {
+	fake.waitForReadyMutex.Lock()
+	defer fake.waitForReadyMutex.Unlock()
+	fake.WaitForReadyStub = nil
+	fake.waitForReadyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) WaitForReadyReturnsOnCall(i int, result1 error) {
+	fake.waitForReadyMutex.Lock()
+	defer fake.waitForReadyMutex.Unlock()
+	fake.WaitForReadyStub = nil
+	if fake.waitForReadyReturnsOnCall == nil {
+		fake.waitForReadyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitForReadyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	fake.waitForReadyMutex.RLock()
+	defer fake.waitForReadyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
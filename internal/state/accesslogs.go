@@ -0,0 +1,219 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
+)
+
+// Special destinations of an access log.
+const (
+	AccessLogDestinationStdout = "stdout"
+	AccessLogDestinationStderr = "stderr"
+	accessLogSyslogPrefix      = "syslog:"
+)
+
+// knownLogVariables are the NGINX variables that can be used in an access log format.
+// gateway_route and gateway_backend are set by the Gateway for every location generated from an HTTPRoute.
+// gateway_config_hash is the hash of the NGINX configuration.
+var knownLogVariables = map[string]struct{}{
+	"binary_remote_addr":       {},
+	"body_bytes_sent":          {},
+	"bytes_sent":               {},
+	"connection":               {},
+	"connection_requests":      {},
+	"content_length":           {},
+	"content_type":             {},
+	"document_uri":             {},
+	"gateway_backend":          {},
+	"gateway_config_hash":      {},
+	"gateway_route":            {},
+	"host":                     {},
+	"hostname":                 {},
+	"https":                    {},
+	"is_args":                  {},
+	"msec":                     {},
+	"nginx_version":            {},
+	"pid":                      {},
+	"pipe":                     {},
+	"proxy_protocol_addr":      {},
+	"proxy_protocol_port":      {},
+	"query_string":             {},
+	"remote_addr":              {},
+	"remote_port":              {},
+	"remote_user":              {},
+	"request":                  {},
+	"request_id":               {},
+	"request_length":           {},
+	"request_method":           {},
+	"request_time":             {},
+	"request_uri":              {},
+	"scheme":                   {},
+	"server_addr":              {},
+	"server_name":              {},
+	"server_port":              {},
+	"server_protocol":          {},
+	"ssl_cipher":               {},
+	"ssl_client_s_dn":          {},
+	"ssl_client_verify":        {},
+	"ssl_protocol":             {},
+	"ssl_server_name":          {},
+	"ssl_session_reused":       {},
+	"status":                   {},
+	"time_iso8601":             {},
+	"time_local":               {},
+	"upstream_addr":            {},
+	"upstream_bytes_received":  {},
+	"upstream_bytes_sent":      {},
+	"upstream_connect_time":    {},
+	"upstream_header_time":     {},
+	"upstream_response_length": {},
+	"upstream_response_time":   {},
+	"upstream_status":          {},
+	"uri":                      {},
+}
+
+// knownLogVariablePrefixes are the prefixes of the NGINX variables that are created for arbitrary headers,
+// cookies and arguments.
+var knownLogVariablePrefixes = []string{
+	"arg_",
+	"cookie_",
+	"http_",
+	"sent_http_",
+	"sent_trailer_",
+	"upstream_cookie_",
+	"upstream_http_",
+	"upstream_trailer_",
+}
+
+// validateAccessLogs validates the access logs of a GatewayConfig, so that they can't break NGINX configuration.
+func validateAccessLogs(logs []nginxgwv1alpha1.AccessLog) error {
+	for i, l := range logs {
+		if err := validateAccessLogFormat(l.Format); err != nil {
+			return fmt.Errorf("Spec.HTTP.AccessLogs[%d].Format is invalid: %w", i, err)
+		}
+		if err := validateAccessLogDestination(l.Destination); err != nil {
+			return fmt.Errorf("Spec.HTTP.AccessLogs[%d].Destination is invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateAccessLogFormat(format string) error {
+	if format == nginxgwv1alpha1.AccessLogFormatCombined || format == nginxgwv1alpha1.AccessLogFormatJSON {
+		return nil
+	}
+
+	if strings.TrimSpace(format) == "" {
+		return errors.New("must not be empty")
+	}
+
+	// the format is put into a single-quoted string in the NGINX configuration,
+	// so quotes and escape sequences could break it
+	if strings.ContainsAny(format, `'\`) {
+		return errors.New("must not include single quotes and backslashes")
+	}
+
+	for _, r := range format {
+		if r < ' ' && r != '\t' {
+			return errors.New("must not include control characters")
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '$' {
+			continue
+		}
+
+		name, length, err := parseLogVariable(format[i+1:])
+		if err != nil {
+			return err
+		}
+
+		if !isKnownLogVariable(name) {
+			return fmt.Errorf("unknown variable $%s", name)
+		}
+
+		i += length
+	}
+
+	return nil
+}
+
+// parseLogVariable parses the name of a variable that follows a '$' in a format. The name is either a sequence of
+// letters, digits and underscores, or such a sequence in curly braces.
+// It returns the name and the number of the parsed bytes.
+func parseLogVariable(s string) (name string, length int, err error) {
+	braced := strings.HasPrefix(s, "{")
+	if braced {
+		s = s[1:]
+	}
+
+	end := 0
+	for end < len(s) && isLogVariableChar(s[end]) {
+		end++
+	}
+
+	if end == 0 {
+		return "", 0, errors.New("'$' must be followed by a variable name")
+	}
+
+	if !braced {
+		return s[:end], end, nil
+	}
+
+	if end == len(s) || s[end] != '}' {
+		return "", 0, fmt.Errorf("missing '}' after the variable ${%s", s[:end])
+	}
+
+	// the braces are included in the length
+	return s[:end], end + 2, nil
+}
+
+func isLogVariableChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_'
+}
+
+func isKnownLogVariable(name string) bool {
+	if _, exist := knownLogVariables[name]; exist {
+		return true
+	}
+
+	for _, p := range knownLogVariablePrefixes {
+		if strings.HasPrefix(name, p) && len(name) > len(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateAccessLogDestination(dest string) error {
+	switch {
+	case dest == AccessLogDestinationStdout || dest == AccessLogDestinationStderr:
+		return nil
+	case strings.HasPrefix(dest, accessLogSyslogPrefix):
+		if !strings.HasPrefix(dest, accessLogSyslogPrefix+"server=") ||
+			len(dest) == len(accessLogSyslogPrefix+"server=") {
+			return errors.New("syslog destination must be of the form syslog:server=ADDRESS[,PARAMETERS]")
+		}
+	case filepath.IsAbs(dest):
+		if filepath.Clean(dest) != dest {
+			return errors.New("file path must be clean")
+		}
+	default:
+		return fmt.Errorf("must be %s, %s, an absolute path of a file or a syslog server",
+			AccessLogDestinationStdout, AccessLogDestinationStderr)
+	}
+
+	// the destination is a parameter of the access_log directive
+	if strings.ContainsAny(dest, " \t\n\r;{}'\"\\$#") {
+		return errors.New("must not include whitespace and special characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package state
+
+import (
+	"testing"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
+)
+
+func TestValidateAccessLogs(t *testing.T) {
+	tests := []struct {
+		logs        []nginxgwv1alpha1.AccessLog
+		expectedErr bool
+		msg         string
+	}{
+		{
+			logs:        nil,
+			expectedErr: false,
+			msg:         "no access logs",
+		},
+		{
+			logs: []nginxgwv1alpha1.AccessLog{
+				{Format: "combined", Destination: "stdout"},
+				{Format: "json", Destination: "stderr"},
+				{Format: `$remote_addr "${request}" $status $http_user_agent $gateway_route`, Destination: "/var/log/access.log"},
+				{Format: "json", Destination: "syslog:server=10.0.0.1:514,tag=nginx"},
+			},
+			expectedErr: false,
+			msg:         "valid access logs",
+		},
+		{
+			logs: []nginxgwv1alpha1.AccessLog{
+				{Format: "combined", Destination: "stdout"},
+				{Format: "$unknown_variable", Destination: "stdout"},
+			},
+			expectedErr: true,
+			msg:         "unknown variable",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "$http_", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "only the prefix of a variable",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "$remote_addr $", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "no variable name",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "${remote_addr", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "missing closing brace",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "'$remote_addr'", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "single quotes",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: `$remote_addr\`, Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "backslash",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "$remote_addr\n$status", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "control character",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: " ", Destination: "stdout"}},
+			expectedErr: true,
+			msg:         "empty format",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "json", Destination: "access.log"}},
+			expectedErr: true,
+			msg:         "relative path",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "json", Destination: "/var/log/../access.log"}},
+			expectedErr: true,
+			msg:         "unclean path",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "json", Destination: "/var/log/access.log;"}},
+			expectedErr: true,
+			msg:         "special character in path",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "json", Destination: "syslog:10.0.0.1"}},
+			expectedErr: true,
+			msg:         "syslog without server",
+		},
+		{
+			logs:        []nginxgwv1alpha1.AccessLog{{Format: "json", Destination: "syslog:server="}},
+			expectedErr: true,
+			msg:         "syslog with empty server",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateAccessLogs(test.logs)
+		if test.expectedErr && err == nil {
+			t.Errorf("validateAccessLogs() %q didn't return an error", test.msg)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("validateAccessLogs() %q returned unexpected error %v", test.msg, err)
+		}
+	}
+}
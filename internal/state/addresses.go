@@ -0,0 +1,89 @@
+package state
+
+import (
+	"fmt"
+	"net"
+
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// NewGatewayAddress creates a GatewayAddress from an IP address or a hostname.
+func NewGatewayAddress(value string) v1beta1.GatewayAddress {
+	addrType := v1beta1.HostnameAddressType
+	if net.ParseIP(value) != nil {
+		addrType = v1beta1.IPAddressType
+	}
+
+	return v1beta1.GatewayAddress{
+		Type:  &addrType,
+		Value: value,
+	}
+}
+
+// buildAddresses builds the addresses of the NGINX data plane.
+// If the static addresses are set, they are used. Otherwise, the addresses are discovered from
+// the LoadBalancer ingress points of the Service of the data plane, if the Service exists.
+func buildAddresses(svc *apiv1.Service, staticAddresses []v1beta1.GatewayAddress) []v1beta1.GatewayAddress {
+	if len(staticAddresses) > 0 {
+		return staticAddresses
+	}
+
+	if svc == nil {
+		return nil
+	}
+
+	var addresses []v1beta1.GatewayAddress
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, NewGatewayAddress(ingress.IP))
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, NewGatewayAddress(ingress.Hostname))
+		}
+	}
+
+	return addresses
+}
+
+// validateGatewayAddresses validates the addresses requested in the spec of a Gateway resource.
+// The NGINX Gateway can't assign addresses, so a requested address can only be honoured if it is one of the
+// addresses of the data plane. The function returns a description per each address that can't be honoured.
+func validateGatewayAddresses(gw *v1beta1.Gateway, addresses []v1beta1.GatewayAddress) []string {
+	var invalid []string
+
+	for _, requested := range gw.Spec.Addresses {
+		requestedType := getAddressType(requested)
+
+		if requestedType == v1beta1.NamedAddressType {
+			invalid = append(invalid, fmt.Sprintf("address %q: type %s is not supported", requested.Value, requestedType))
+			continue
+		}
+
+		found := false
+		for _, a := range addresses {
+			if getAddressType(a) == requestedType && a.Value == requested.Value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			invalid = append(
+				invalid,
+				fmt.Sprintf("address %q of type %s is not an address of the NGINX Gateway", requested.Value, requestedType),
+			)
+		}
+	}
+
+	return invalid
+}
+
+// getAddressType returns the type of the address. IPAddress is the default type.
+func getAddressType(a v1beta1.GatewayAddress) v1beta1.AddressType {
+	if a.Type == nil {
+		return v1beta1.IPAddressType
+	}
+	return *a.Type
+}
@@ -0,0 +1,126 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestBuildAddresses(t *testing.T) {
+	svc := &v1.Service{
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{
+					{IP: "10.0.0.1"},
+					{Hostname: "lb.example.com"},
+				},
+			},
+		},
+	}
+
+	staticAddresses := []v1beta1.GatewayAddress{NewGatewayAddress("192.168.0.1")}
+
+	tests := []struct {
+		svc             *v1.Service
+		staticAddresses []v1beta1.GatewayAddress
+		expected        []v1beta1.GatewayAddress
+		msg             string
+	}{
+		{
+			svc:      nil,
+			expected: nil,
+			msg:      "no service",
+		},
+		{
+			svc:      &v1.Service{},
+			expected: nil,
+			msg:      "service without ingress points",
+		},
+		{
+			svc: svc,
+			expected: []v1beta1.GatewayAddress{
+				NewGatewayAddress("10.0.0.1"),
+				NewGatewayAddress("lb.example.com"),
+			},
+			msg: "service with ingress points",
+		},
+		{
+			svc:             svc,
+			staticAddresses: staticAddresses,
+			expected:        staticAddresses,
+			msg:             "static addresses",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildAddresses(test.svc, test.staticAddresses)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildAddresses() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateGatewayAddresses(t *testing.T) {
+	namedType := v1beta1.NamedAddressType
+
+	addresses := []v1beta1.GatewayAddress{
+		NewGatewayAddress("10.0.0.1"),
+		NewGatewayAddress("lb.example.com"),
+	}
+
+	createGateway := func(addresses ...v1beta1.GatewayAddress) *v1beta1.Gateway {
+		return &v1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+			Spec: v1beta1.GatewaySpec{
+				Addresses: addresses,
+			},
+		}
+	}
+
+	tests := []struct {
+		gw       *v1beta1.Gateway
+		expected []string
+		msg      string
+	}{
+		{
+			gw:       createGateway(),
+			expected: nil,
+			msg:      "no addresses",
+		},
+		{
+			gw: createGateway(
+				NewGatewayAddress("10.0.0.1"),
+				v1beta1.GatewayAddress{Value: "10.0.0.1"},
+				NewGatewayAddress("lb.example.com"),
+			),
+			expected: nil,
+			msg:      "addresses of the data plane",
+		},
+		{
+			gw: createGateway(
+				NewGatewayAddress("10.0.0.2"),
+				v1beta1.GatewayAddress{Type: &namedType, Value: "my-address"},
+				NewGatewayAddress("other.example.com"),
+			),
+			expected: []string{
+				`address "10.0.0.2" of type IPAddress is not an address of the NGINX Gateway`,
+				`address "my-address": type NamedAddress is not supported`,
+				`address "other.example.com" of type Hostname is not an address of the NGINX Gateway`,
+			},
+			msg: "invalid addresses",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateGatewayAddresses(test.gw, addresses)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("validateGatewayAddresses() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
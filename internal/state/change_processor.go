@@ -2,11 +2,16 @@ package state
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ChangeProcessor
@@ -22,6 +27,9 @@ type ChangeProcessor interface {
 	// The method panics if the resource is of unsupported type or if the passed Gateway is different from the one this ChangeProcessor
 	// was created for.
 	CaptureDeleteChange(resourceType client.Object, nsname types.NamespacedName)
+	// CaptureCertificateValidityChange captures a change of the validity of a referenced certificate, which happens
+	// when the time returned by SecretDiskMemoryManager.NextCertificateValidityChange is reached.
+	CaptureCertificateValidityChange()
 	// Process processes any captured changes and produces an internal representation of the Gateway configuration and
 	// the status information about the processed resources.
 	// If no changes were captured, the changed return argument will be false and both the configuration and statuses
@@ -37,36 +45,43 @@ type ChangeProcessorConfig struct {
 	GatewayClassName string
 	// SecretMemoryManager is the secret memory manager.
 	SecretMemoryManager SecretDiskMemoryManager
+	// ServiceNsName is the namespaced name of the Service of the NGINX data plane. The addresses of the Service
+	// are reported in the statuses of the Gateway resources.
+	ServiceNsName types.NamespacedName
+	// StaticAddresses are the addresses of the NGINX data plane. If set, they are reported in the statuses of
+	// the Gateway resources instead of the addresses of the Service.
+	StaticAddresses []v1beta1.GatewayAddress
 }
 
 // ChangeProcessorImpl is an implementation of ChangeProcessor.
 type ChangeProcessorImpl struct {
 	store *store
 	// storeChanged tells if the store is changed.
-	// The store is considered changed if:
-	// (1) Any of its resources was deleted.
-	// (2) A new resource was upserted.
-	// (3) An existing resource with the updated Generation was upserted.
+	// The store is considered changed if a resource related to the Gateways of the GatewayClass was:
+	// (1) Deleted.
+	// (2) Upserted for the first time.
+	// (3) Upserted with the updated Generation (or the updated contents for the resources without a Generation).
+	// The changes to the unrelated resources are ignored, because they affect neither the configuration nor
+	// the statuses.
 	storeChanged bool
-	cfg          ChangeProcessorConfig
+	// relationships tracks which resources are related to the Gateways of the GatewayClass.
+	relationships *relationshipTracker
+	cfg           ChangeProcessorConfig
 
 	lock sync.Mutex
 }
 
 // NewChangeProcessorImpl creates a new ChangeProcessorImpl for the Gateway resource with the configured namespace name.
 func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
+	s := newStore()
+
 	return &ChangeProcessorImpl{
-		store: newStore(),
-		cfg:   cfg,
+		store:         s,
+		relationships: newRelationshipTracker(s, cfg.GatewayClassName),
+		cfg:           cfg,
 	}
 }
 
-// FIXME(pleshakov)
-// Currently, changes (upserts/delete) trigger rebuilding of the configuration, even if the change doesn't change
-// the configuration or the statuses of the resources. For example, a change in a Gateway resource that doesn't
-// belong to the NGINX Gateway or an HTTPRoute that doesn't belong to any of the Gateways of the NGINX Gateway.
-// Find a way to ignore changes that don't affect the configuration and/or statuses of the resources.
-
 func (c *ChangeProcessorImpl) CaptureUpsertChange(obj client.Object) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -84,19 +99,99 @@ func (c *ChangeProcessorImpl) CaptureUpsertChange(obj client.Object) {
 		}
 		c.store.gc = o
 	case *v1beta1.Gateway:
-		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		// Only the Gateways of the GatewayClass matter, including a Gateway that has moved to another GatewayClass.
+		// If the resource spec hasn't changed (its generation is the same), ignore the upsert.
 		prev, exist := c.store.gateways[getNamespacedName(obj)]
-		if exist && o.Generation == prev.Generation {
+		relevant := c.relationships.isGatewayRelevant(o) || (exist && c.relationships.isGatewayRelevant(prev))
+		if !relevant || (exist && o.Generation == prev.Generation) {
 			resourceChanged = false
 		}
 		c.store.gateways[getNamespacedName(obj)] = o
 	case *v1beta1.HTTPRoute:
-		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		// Only the routes that reference the Gateways of the GatewayClass matter, including a route that no longer
+		// references them. If the resource spec hasn't changed (its generation is the same), ignore the upsert.
 		prev, exist := c.store.httpRoutes[getNamespacedName(obj)]
-		if exist && o.Generation == prev.Generation {
+		relevant := c.relationships.isRouteRelevant(o.Namespace, o.Spec.ParentRefs) ||
+			(exist && c.relationships.isRouteRelevant(prev.Namespace, prev.Spec.ParentRefs))
+		if !relevant || (exist && o.Generation == prev.Generation) {
 			resourceChanged = false
 		}
 		c.store.httpRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.GRPCRoute:
+		// the same as for HTTPRoute
+		prev, exist := c.store.grpcRoutes[getNamespacedName(obj)]
+		relevant := c.relationships.isRouteRelevant(o.Namespace, convertParentReferences(o.Spec.ParentRefs)) ||
+			(exist && c.relationships.isRouteRelevant(prev.Namespace, convertParentReferences(prev.Spec.ParentRefs)))
+		if !relevant || (exist && o.Generation == prev.Generation) {
+			resourceChanged = false
+		}
+		c.store.grpcRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.TLSRoute:
+		// the same as for HTTPRoute
+		prev, exist := c.store.tlsRoutes[getNamespacedName(obj)]
+		relevant := c.relationships.isRouteRelevant(o.Namespace, convertParentReferences(o.Spec.ParentRefs)) ||
+			(exist && c.relationships.isRouteRelevant(prev.Namespace, convertParentReferences(prev.Spec.ParentRefs)))
+		if !relevant || (exist && o.Generation == prev.Generation) {
+			resourceChanged = false
+		}
+		c.store.tlsRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.TCPRoute:
+		// the same as for HTTPRoute
+		prev, exist := c.store.tcpRoutes[getNamespacedName(obj)]
+		relevant := c.relationships.isRouteRelevant(o.Namespace, convertParentReferences(o.Spec.ParentRefs)) ||
+			(exist && c.relationships.isRouteRelevant(prev.Namespace, convertParentReferences(prev.Spec.ParentRefs)))
+		if !relevant || (exist && o.Generation == prev.Generation) {
+			resourceChanged = false
+		}
+		c.store.tcpRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.UDPRoute:
+		// the same as for HTTPRoute
+		prev, exist := c.store.udpRoutes[getNamespacedName(obj)]
+		relevant := c.relationships.isRouteRelevant(o.Namespace, convertParentReferences(o.Spec.ParentRefs)) ||
+			(exist && c.relationships.isRouteRelevant(prev.Namespace, convertParentReferences(prev.Spec.ParentRefs)))
+		if !relevant || (exist && o.Generation == prev.Generation) {
+			resourceChanged = false
+		}
+		c.store.udpRoutes[getNamespacedName(obj)] = o
+	case *apiv1.Secret:
+		// Secrets don't have a generation, so we compare their contents.
+		// Only the Secrets referenced by the Gateway matter.
+		nsname := getNamespacedName(obj)
+		prev, exist := c.store.secrets[nsname]
+		referenced := c.relationships.isSecretReferenced(nsname)
+		if !referenced || (exist && prev.Type == o.Type && reflect.DeepEqual(prev.Data, o.Data)) {
+			resourceChanged = false
+		}
+		c.store.secrets[nsname] = o
+	case *nginxgwv1alpha1.GatewayConfig:
+		// Only the GatewayConfig referenced by the GatewayClass matters.
+		// If the resource spec hasn't changed (its generation is the same), ignore the upsert.
+		prev, exist := c.store.gatewayConfigs[o.Name]
+		if !referencesGatewayConfig(c.store.gc, o.Name) || (exist && o.Generation == prev.Generation) {
+			resourceChanged = false
+		}
+		c.store.gatewayConfigs[o.Name] = o
+	case *apiv1.Service:
+		// Only the Service of the data plane and the Services referenced by the backends matter.
+		// Services don't have a generation, so we compare the parts that matter:
+		// - For the Service of the data plane, its status (the LoadBalancer ingress points).
+		// - For a referenced Service, its cluster IP, which the backends are resolved to.
+		nsname := getNamespacedName(obj)
+
+		prev, exist := c.store.services[nsname]
+		backendChanged := c.relationships.isServiceReferenced(nsname) &&
+			(!exist || prev.Spec.ClusterIP != o.Spec.ClusterIP)
+		c.store.services[nsname] = o
+
+		dataPlaneChanged := false
+		if nsname == c.cfg.ServiceNsName {
+			prevDataPlane := c.store.dataPlaneService
+			dataPlaneChanged = prevDataPlane == nil ||
+				!reflect.DeepEqual(prevDataPlane.Status.LoadBalancer.Ingress, o.Status.LoadBalancer.Ingress)
+			c.store.dataPlaneService = o
+		}
+
+		resourceChanged = backendChanged || dataPlaneChanged
 	default:
 		panic(fmt.Errorf("ChangeProcessor doesn't support %T", obj))
 	}
@@ -108,7 +203,7 @@ func (c *ChangeProcessorImpl) CaptureDeleteChange(resourceType client.Object, ns
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.storeChanged = true
+	resourceChanged := true
 
 	switch resourceType.(type) {
 	case *v1beta1.GatewayClass:
@@ -117,12 +212,62 @@ func (c *ChangeProcessorImpl) CaptureDeleteChange(resourceType client.Object, ns
 		}
 		c.store.gc = nil
 	case *v1beta1.Gateway:
+		gw, exist := c.store.gateways[nsname]
+		resourceChanged = exist && c.relationships.isGatewayRelevant(gw)
 		delete(c.store.gateways, nsname)
 	case *v1beta1.HTTPRoute:
+		r, exist := c.store.httpRoutes[nsname]
+		resourceChanged = exist && c.relationships.isRouteRelevant(r.Namespace, r.Spec.ParentRefs)
 		delete(c.store.httpRoutes, nsname)
+	case *v1alpha2.GRPCRoute:
+		r, exist := c.store.grpcRoutes[nsname]
+		resourceChanged = exist && c.relationships.isRouteRelevant(r.Namespace, convertParentReferences(r.Spec.ParentRefs))
+		delete(c.store.grpcRoutes, nsname)
+	case *v1alpha2.TLSRoute:
+		r, exist := c.store.tlsRoutes[nsname]
+		resourceChanged = exist && c.relationships.isRouteRelevant(r.Namespace, convertParentReferences(r.Spec.ParentRefs))
+		delete(c.store.tlsRoutes, nsname)
+	case *v1alpha2.TCPRoute:
+		r, exist := c.store.tcpRoutes[nsname]
+		resourceChanged = exist && c.relationships.isRouteRelevant(r.Namespace, convertParentReferences(r.Spec.ParentRefs))
+		delete(c.store.tcpRoutes, nsname)
+	case *v1alpha2.UDPRoute:
+		r, exist := c.store.udpRoutes[nsname]
+		resourceChanged = exist && c.relationships.isRouteRelevant(r.Namespace, convertParentReferences(r.Spec.ParentRefs))
+		delete(c.store.udpRoutes, nsname)
+	case *apiv1.Secret:
+		// the deletion of a referenced Secret invalidates the listeners that reference it
+		resourceChanged = c.relationships.isSecretReferenced(nsname)
+		delete(c.store.secrets, nsname)
+	case *nginxgwv1alpha1.GatewayConfig:
+		_, exist := c.store.gatewayConfigs[nsname.Name]
+		resourceChanged = exist && referencesGatewayConfig(c.store.gc, nsname.Name)
+		delete(c.store.gatewayConfigs, nsname.Name)
+	case *apiv1.Service:
+		_, exist := c.store.services[nsname]
+		// the backends that reference a deleted Service can't be resolved
+		backendChanged := exist && c.relationships.isServiceReferenced(nsname)
+		delete(c.store.services, nsname)
+
+		dataPlaneChanged := nsname == c.cfg.ServiceNsName && c.store.dataPlaneService != nil
+		if dataPlaneChanged {
+			c.store.dataPlaneService = nil
+		}
+
+		resourceChanged = backendChanged || dataPlaneChanged
 	default:
 		panic(fmt.Errorf("ChangeProcessor doesn't support %T", resourceType))
 	}
+
+	c.storeChanged = c.storeChanged || resourceChanged
+}
+
+func (c *ChangeProcessorImpl) CaptureCertificateValidityChange() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// the resources didn't change, but the listeners that reference the certificate must be validated again
+	c.storeChanged = true
 }
 
 func (c *ChangeProcessorImpl) Process() (changed bool, conf Configuration, statuses Statuses) {
@@ -139,11 +284,52 @@ func (c *ChangeProcessorImpl) Process() (changed bool, conf Configuration, statu
 		c.store,
 		c.cfg.GatewayCtlrName,
 		c.cfg.GatewayClassName,
+		c.cfg.StaticAddresses,
 		c.cfg.SecretMemoryManager,
 	)
 
+	c.relationships.update(graph)
+
 	conf = buildConfiguration(graph)
 	statuses = buildStatuses(graph)
 
 	return true, conf, statuses
 }
+
+// getReferencedSecrets returns the Secrets referenced by the HTTPS listeners of the Gateways, including the listeners
+// that are invalid, so that creating a missing Secret makes such a listener valid.
+func getReferencedSecrets(gws map[types.NamespacedName]*gateway) map[types.NamespacedName]struct{} {
+	secrets := make(map[types.NamespacedName]struct{})
+
+	for _, gw := range gws {
+		addReferencedSecrets(gw, secrets)
+	}
+
+	return secrets
+}
+
+// addReferencedSecrets adds the Secrets referenced by the HTTPS listeners of the Gateway to secrets.
+func addReferencedSecrets(gw *gateway, secrets map[types.NamespacedName]struct{}) {
+	for _, l := range gw.Listeners {
+		if l.Source.Protocol != v1beta1.HTTPSProtocolType || l.Source.TLS == nil {
+			continue
+		}
+
+		for _, ref := range l.Source.TLS.CertificateRefs {
+			if ref.Kind != nil && *ref.Kind != "Secret" {
+				continue
+			}
+
+			ns := gw.Source.Namespace
+			if ref.Namespace != nil {
+				ns = string(*ref.Namespace)
+			}
+
+			secrets[types.NamespacedName{Namespace: ns, Name: string(ref.Name)}] = struct{}{}
+		}
+
+		if ca, ok := l.Source.TLS.Options[TLSOptionClientCertificate]; ok && ca != "" {
+			secrets[types.NamespacedName{Namespace: gw.Source.Namespace, Name: string(ca)}] = struct{}{}
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package state_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,17 +15,28 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status/statusfakes"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // FIXME(kate-osborn): Consider refactoring these tests to reduce code duplication.
 var _ = Describe("ChangeProcessor", func() {
 	Describe("Normal cases of processing changes", func() {
 		const (
-			controllerName  = "my.controller"
-			gcName          = "test-class"
-			certificatePath = "path/to/cert"
+			controllerName = "my.controller"
+			gcName         = "test-class"
+		)
+
+		var (
+			gw1NsName = types.NamespacedName{Namespace: "test", Name: "gateway-1"}
+			gw2NsName = types.NamespacedName{Namespace: "test", Name: "gateway-2"}
 		)
 
+		certificateFiles := state.CertificateFiles{
+			Certificate: "path/to/cert",
+			Key:         "path/to/key",
+		}
+
 		var (
 			gc, gcUpdated        *v1beta1.GatewayClass
 			hr1, hr1Updated, hr2 *v1beta1.HTTPRoute
@@ -91,7 +105,7 @@ var _ = Describe("ChangeProcessor", func() {
 
 			hr2 = createRoute("hr-2", "gateway-2", "bar.example.com")
 
-			createGateway := func(name string) *v1beta1.Gateway {
+			createGateway := func(name string, httpPort, httpsPort v1beta1.PortNumber) *v1beta1.Gateway {
 				return &v1beta1.Gateway{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace:  "test",
@@ -104,13 +118,13 @@ var _ = Describe("ChangeProcessor", func() {
 							{
 								Name:     "listener-80-1",
 								Hostname: nil,
-								Port:     80,
+								Port:     httpPort,
 								Protocol: v1beta1.HTTPProtocolType,
 							},
 							{
 								Name:     "listener-443-1",
 								Hostname: nil,
-								Port:     443,
+								Port:     httpsPort,
 								Protocol: v1beta1.HTTPSProtocolType,
 								TLS: &v1beta1.GatewayTLSConfig{
 									Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
@@ -128,12 +142,13 @@ var _ = Describe("ChangeProcessor", func() {
 				}
 			}
 
-			gw1 = createGateway("gateway-1")
+			gw1 = createGateway("gateway-1", 80, 443)
 
 			gw1Updated = gw1.DeepCopy()
 			gw1Updated.Generation++
 
-			gw2 = createGateway("gateway-2")
+			// gw2 uses different ports, so that its listeners don't conflict with the listeners of gw1
+			gw2 = createGateway("gateway-2", 8080, 8443)
 
 			fakeSecretMemoryMgr = &statefakes.FakeSecretDiskMemoryManager{}
 
@@ -143,7 +158,7 @@ var _ = Describe("ChangeProcessor", func() {
 				SecretMemoryManager: fakeSecretMemoryMgr,
 			})
 
-			fakeSecretMemoryMgr.RequestReturns(certificatePath, nil)
+			fakeSecretMemoryMgr.RequestReturns(certificateFiles, nil)
 		})
 
 		Describe("Process resources", Ordered, func() {
@@ -157,19 +172,14 @@ var _ = Describe("ChangeProcessor", func() {
 			})
 			When("GatewayClass doesn't exist", func() {
 				When("Gateways don't exist", func() {
-					It("should return empty configuration and updated statuses after upserting the first HTTPRoute", func() {
+					It("should report not changed after upserting the first HTTPRoute", func() {
+						// the HTTPRoute doesn't reference any Gateway of the GatewayClass yet
 						processor.CaptureUpsertChange(hr1)
 
-						expectedConf := state.Configuration{}
-						expectedStatuses := state.Statuses{
-							IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-							HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
-						}
-
 						changed, conf, statuses := processor.Process()
-						Expect(changed).To(BeTrue())
-						Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
-						Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
+						Expect(changed).To(BeFalse())
+						Expect(conf).To(BeZero())
+						Expect(statuses).To(BeZero())
 					})
 				})
 
@@ -178,28 +188,34 @@ var _ = Describe("ChangeProcessor", func() {
 
 					expectedConf := state.Configuration{}
 					expectedStatuses := state.Statuses{
-						GatewayStatus: &state.GatewayStatus{
-							NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-							ListenerStatuses: map[string]state.ListenerStatus{
-								"listener-80-1": {
-									Valid:          false,
-									AttachedRoutes: 1,
-								},
-								"listener-443-1": {
-									Valid:          false,
-									AttachedRoutes: 1,
+						GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+							gw1NsName: {
+								ListenerStatuses: map[string]state.ListenerStatus{
+									"listener-80-1": {
+										Valid:          false,
+										AttachedRoutes: 1,
+										SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+									},
+									"listener-443-1": {
+										Valid:          false,
+										AttachedRoutes: 1,
+										SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+									},
 								},
 							},
 						},
-						IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 						HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 							{Namespace: "test", Name: "hr-1"}: {
-								ParentStatuses: map[string]state.ParentStatus{
-									"listener-80-1":  {Attached: false},
-									"listener-443-1": {Attached: false},
+								ParentStatuses: map[state.ParentRef]state.ParentStatus{
+									{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: false},
+									{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: false},
 								},
 							},
 						},
+						GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+						TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+						TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+						UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 					}
 
 					changed, conf, statuses := processor.Process()
@@ -215,7 +231,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -232,8 +250,10 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -248,10 +268,15 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 
 				expectedStatuses := state.Statuses{
@@ -259,28 +284,34 @@ var _ = Describe("ChangeProcessor", func() {
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -306,7 +337,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -323,8 +356,10 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -339,38 +374,49 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -396,7 +442,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -413,8 +461,10 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -429,38 +479,49 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -486,7 +547,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -503,8 +566,10 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -519,38 +584,49 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -573,7 +649,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -590,7 +668,9 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     443,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -604,46 +684,73 @@ var _ = Describe("ChangeProcessor", func() {
 								},
 							},
 							SSL: &state.SSL{
-								CertificatePath: certificatePath,
+								Certificates: []state.CertificateFiles{certificateFiles},
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
+							Hostname: "~^",
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
+						},
+						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
-						{Namespace: "test", Name: "gateway-2"}: {
-							ObservedGeneration: gw2.Generation,
+						gw2NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
+							},
 						},
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -652,13 +759,15 @@ var _ = Describe("ChangeProcessor", func() {
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
 
-			It("should return same configuration and updated statuses after the second HTTPRoute is upserted", func() {
+			It("should return updated configuration and statuses after the second HTTPRoute is upserted", func() {
 				processor.CaptureUpsertChange(hr2)
 
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -672,11 +781,30 @@ var _ = Describe("ChangeProcessor", func() {
 								},
 							},
 						},
+						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
+							Hostname: "bar.example.com",
+							Port:     8080,
+							PathRules: []state.PathRule{
+								{
+									Path: "/",
+									MatchRules: []state.MatchRule{
+										{
+											MatchIdx: 0,
+											RuleIdx:  0,
+											Source:   hr2,
+										},
+									},
+								},
+							},
+						},
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
 							Hostname: "foo.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -691,48 +819,93 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-1"},
+							Hostname: "~^",
+							Port:     443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
+						},
+						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
+							Hostname: "bar.example.com",
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
+							PathRules: []state.PathRule{
+								{
+									Path: "/",
+									MatchRules: []state.MatchRule{
+										{
+											MatchIdx: 0,
+											RuleIdx:  0,
+											Source:   hr2,
+										},
+									},
+								},
+							},
+						},
+						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw1NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
-						{Namespace: "test", Name: "gateway-2"}: {
-							ObservedGeneration: gw2.Generation,
+						gw2NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
+							},
 						},
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw1NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 						{Namespace: "test", Name: "hr-2"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: false},
-								"listener-443-1": {Attached: false},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw2NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw2NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -747,7 +920,9 @@ var _ = Describe("ChangeProcessor", func() {
 				expectedConf := state.Configuration{
 					HTTPServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "bar.example.com",
+							Port:     8080,
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -764,8 +939,10 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "bar.example.com",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 							PathRules: []state.PathRule{
 								{
 									Path: "/",
@@ -780,38 +957,49 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw2NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-2"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1":  {Attached: true},
-								"listener-443-1": {Attached: true},
+							ParentStatuses: map[state.ParentRef]state.ParentStatus{
+								{Gateway: gw2NsName, SectionName: "listener-80-1"}:  {Attached: true},
+								{Gateway: gw2NsName, SectionName: "listener-443-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -827,31 +1015,42 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.VirtualServer{},
 					SSLServers: []state.VirtualServer{
 						{
+							Gateway:  types.NamespacedName{Namespace: "test", Name: "gateway-2"},
 							Hostname: "~^",
-							SSL:      &state.SSL{CertificatePath: certificatePath},
+							Port:     8443,
+							SSL:      &state.SSL{Certificates: []state.CertificateFiles{certificateFiles}},
 						},
 					},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					TCPServers:            []state.TCPServer{},
+					UDPServers:            []state.UDPServer{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 0,
-							},
-							"listener-443-1": {
-								Valid:          true,
-								AttachedRoutes: 0,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw2NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          true,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -865,21 +1064,27 @@ var _ = Describe("ChangeProcessor", func() {
 
 				expectedConf := state.Configuration{}
 				expectedStatuses := state.Statuses{
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          false,
-								AttachedRoutes: 0,
-							},
-							"listener-443-1": {
-								Valid:          false,
-								AttachedRoutes: 0,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						gw2NsName: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          false,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+								"listener-443-1": {
+									Valid:          false,
+									AttachedRoutes: 0,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -893,8 +1098,12 @@ var _ = Describe("ChangeProcessor", func() {
 
 				expectedConf := state.Configuration{}
 				expectedStatuses := state.Statuses{
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					GatewayStatuses:   map[types.NamespacedName]state.GatewayStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
@@ -903,30 +1112,27 @@ var _ = Describe("ChangeProcessor", func() {
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
 
-			It("should return empty configuration and statuses after deleting the first HTTPRoute", func() {
+			It("should report not changed after deleting the first HTTPRoute", func() {
+				// the HTTPRoute no longer references any Gateway of the GatewayClass
 				processor.CaptureDeleteChange(&v1beta1.HTTPRoute{}, types.NamespacedName{Namespace: "test", Name: "hr-1"})
 
-				expectedConf := state.Configuration{}
-				expectedStatuses := state.Statuses{
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
-				}
-
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
-				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
-				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
+				Expect(changed).To(BeFalse())
+				Expect(conf).To(BeZero())
+				Expect(statuses).To(BeZero())
 			})
 		})
 	})
 
 	Describe("Multiple captured changes", func() {
 		var (
-			processor                    *state.ChangeProcessorImpl
-			gcNsName, gwNsName, hrNsName types.NamespacedName
-			gc, gcUpdated                *v1beta1.GatewayClass
-			gw1, gw1Updated, gw2         *v1beta1.Gateway
-			hr1, hr1Updated, hr2         *v1beta1.HTTPRoute
+			processor                                        *state.ChangeProcessorImpl
+			gcNsName, gwNsName, hrNsName, grNsName, trNsName types.NamespacedName
+			gc, gcUpdated                                    *v1beta1.GatewayClass
+			gw1, gw1Updated, gw2                             *v1beta1.Gateway
+			hr1, hr1Updated, hr2                             *v1beta1.HTTPRoute
+			gr1, gr1Updated, gr2                             *v1alpha2.GRPCRoute
+			tr1, tr1Updated, tr2                             *v1alpha2.TLSRoute
 		)
 
 		BeforeEach(OncePerOrdered, func() {
@@ -958,6 +1164,9 @@ var _ = Describe("ChangeProcessor", func() {
 					Namespace: gwNsName.Namespace,
 					Name:      gwNsName.Name,
 				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: v1beta1.ObjectName(gcNsName.Name),
+				},
 			}
 
 			gw1Updated = gw1.DeepCopy()
@@ -973,6 +1182,15 @@ var _ = Describe("ChangeProcessor", func() {
 					Namespace: hrNsName.Namespace,
 					Name:      hrNsName.Name,
 				},
+				Spec: v1beta1.HTTPRouteSpec{
+					CommonRouteSpec: v1beta1.CommonRouteSpec{
+						ParentRefs: []v1beta1.ParentReference{
+							{
+								Name: v1beta1.ObjectName(gwNsName.Name),
+							},
+						},
+					},
+				},
 			}
 
 			hr1Updated = hr1.DeepCopy()
@@ -980,6 +1198,45 @@ var _ = Describe("ChangeProcessor", func() {
 
 			hr2 = hr1.DeepCopy()
 			hr2.Name = "hr-2"
+
+			grNsName = types.NamespacedName{Namespace: "test", Name: "gr-1"}
+
+			gr1 = &v1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: grNsName.Namespace,
+					Name:      grNsName.Name,
+				},
+			}
+
+			gr1Updated = gr1.DeepCopy()
+			gr1Updated.Generation++
+
+			gr2 = gr1.DeepCopy()
+			gr2.Name = "gr-2"
+
+			trNsName = types.NamespacedName{Namespace: "test", Name: "tr-1"}
+
+			tr1 = &v1alpha2.TLSRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: trNsName.Namespace,
+					Name:      trNsName.Name,
+				},
+				Spec: v1alpha2.TLSRouteSpec{
+					CommonRouteSpec: v1alpha2.CommonRouteSpec{
+						ParentRefs: []v1alpha2.ParentReference{
+							{
+								Name: v1alpha2.ObjectName(gwNsName.Name),
+							},
+						},
+					},
+				},
+			}
+
+			tr1Updated = tr1.DeepCopy()
+			tr1Updated.Generation++
+
+			tr2 = tr1.DeepCopy()
+			tr2.Name = "tr-2"
 		})
 
 		Describe("Ensuring non-changing changes don't override previously changing changes", Ordered, func() {
@@ -993,6 +1250,8 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(gc)
 				processor.CaptureUpsertChange(gw1)
 				processor.CaptureUpsertChange(hr1)
+				processor.CaptureUpsertChange(gr1)
+				processor.CaptureUpsertChange(tr1)
 
 				changed, _, _ := processor.Process()
 				Expect(changed).To(BeTrue())
@@ -1002,6 +1261,8 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(gc)
 				processor.CaptureUpsertChange(gw1)
 				processor.CaptureUpsertChange(hr1)
+				processor.CaptureUpsertChange(gr1)
+				processor.CaptureUpsertChange(tr1)
 
 				changed, _, _ := processor.Process()
 				Expect(changed).To(BeFalse())
@@ -1012,11 +1273,15 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(gcUpdated)
 				processor.CaptureUpsertChange(gw1Updated)
 				processor.CaptureUpsertChange(hr1Updated)
+				processor.CaptureUpsertChange(gr1Updated)
+				processor.CaptureUpsertChange(tr1Updated)
 
 				// there are non-changing changes
 				processor.CaptureUpsertChange(gcUpdated)
 				processor.CaptureUpsertChange(gw1Updated)
 				processor.CaptureUpsertChange(hr1Updated)
+				processor.CaptureUpsertChange(gr1Updated)
+				processor.CaptureUpsertChange(tr1Updated)
 
 				changed, _, _ := processor.Process()
 				Expect(changed).To(BeTrue())
@@ -1026,6 +1291,8 @@ var _ = Describe("ChangeProcessor", func() {
 				// we can't have a second GatewayClass, so we don't add it
 				processor.CaptureUpsertChange(gw2)
 				processor.CaptureUpsertChange(hr2)
+				processor.CaptureUpsertChange(gr2)
+				processor.CaptureUpsertChange(tr2)
 
 				changed, _, _ := processor.Process()
 				Expect(changed).To(BeTrue())
@@ -1036,10 +1303,14 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureDeleteChange(&v1beta1.GatewayClass{}, gcNsName)
 				processor.CaptureDeleteChange(&v1beta1.Gateway{}, gwNsName)
 				processor.CaptureDeleteChange(&v1beta1.HTTPRoute{}, hrNsName)
+				processor.CaptureDeleteChange(&v1alpha2.GRPCRoute{}, grNsName)
+				processor.CaptureDeleteChange(&v1alpha2.TLSRoute{}, trNsName)
 
 				// these are non-changing changes
 				processor.CaptureUpsertChange(gw2)
 				processor.CaptureUpsertChange(hr2)
+				processor.CaptureUpsertChange(gr2)
+				processor.CaptureUpsertChange(tr2)
 
 				changed, _, _ := processor.Process()
 				Expect(changed).To(BeTrue())
@@ -1047,6 +1318,736 @@ var _ = Describe("ChangeProcessor", func() {
 		})
 	})
 
+	Describe("Secret changes", Ordered, func() {
+		var (
+			processor                                     *state.ChangeProcessorImpl
+			secretNsName, caNsName, unrelatedSecretNsName types.NamespacedName
+			secret, secretSameData, secretUpdated         *apiv1.Secret
+			ca, unrelatedSecret                           *apiv1.Secret
+		)
+
+		BeforeAll(func() {
+			fakeSecretMemoryMgr := &statefakes.FakeSecretDiskMemoryManager{}
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     "test.controller",
+				GatewayClassName:    "my-class",
+				SecretMemoryManager: fakeSecretMemoryMgr,
+			})
+
+			createSecret := func(name string, data string) *apiv1.Secret {
+				return &apiv1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      name,
+					},
+					Data: map[string][]byte{
+						apiv1.TLSCertKey: []byte(data),
+					},
+					Type: apiv1.SecretTypeTLS,
+				}
+			}
+
+			secretNsName = types.NamespacedName{Namespace: "test", Name: "secret"}
+			caNsName = types.NamespacedName{Namespace: "test", Name: "ca"}
+			unrelatedSecretNsName = types.NamespacedName{Namespace: "test", Name: "unrelated"}
+
+			secret = createSecret(secretNsName.Name, "cert")
+
+			secretSameData = secret.DeepCopy()
+			secretSameData.ResourceVersion = "2"
+
+			secretUpdated = createSecret(secretNsName.Name, "rotated-cert")
+
+			ca = createSecret(caNsName.Name, "ca")
+			unrelatedSecret = createSecret(unrelatedSecretNsName.Name, "cert")
+		})
+
+		It("should report changed after upserting the GatewayClass and the Gateway with an HTTPS listener", func() {
+			processor.CaptureUpsertChange(&v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-class",
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: "test.controller",
+				},
+			})
+			processor.CaptureUpsertChange(&v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: "my-class",
+					Listeners: []v1beta1.Listener{
+						{
+							Name:     "listener-443-1",
+							Hostname: (*v1beta1.Hostname)(helpers.GetStringPointer("foo.example.com")),
+							Port:     443,
+							Protocol: v1beta1.HTTPSProtocolType,
+							TLS: &v1beta1.GatewayTLSConfig{
+								Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+								CertificateRefs: []v1beta1.SecretObjectReference{
+									{
+										Kind: (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+										Name: v1beta1.ObjectName(secretNsName.Name),
+									},
+								},
+								Options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+									state.TLSOptionClientCertificate: v1beta1.AnnotationValue(caNsName.Name),
+								},
+							},
+						},
+					},
+				},
+			})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report changed after upserting a referenced Secret", func() {
+			processor.CaptureUpsertChange(secret)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting a referenced Secret with the same data", func() {
+			processor.CaptureUpsertChange(secretSameData)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting a referenced Secret with updated data", func() {
+			processor.CaptureUpsertChange(secretUpdated)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report changed after upserting a Secret referenced by a TLS option", func() {
+			processor.CaptureUpsertChange(ca)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting and deleting an unrelated Secret", func() {
+			processor.CaptureUpsertChange(unrelatedSecret)
+			processor.CaptureDeleteChange(&apiv1.Secret{}, unrelatedSecretNsName)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after deleting a referenced Secret", func() {
+			processor.CaptureDeleteChange(&apiv1.Secret{}, secretNsName)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+	})
+
+	Describe("Certificate validity changes", Ordered, func() {
+		var (
+			processor *state.ChangeProcessorImpl
+			secretMgr *state.SecretDiskMemoryManagerImpl
+			fakeClock *statusfakes.FakeClock
+			gwNsName  types.NamespacedName
+			notAfter  time.Time
+		)
+
+		BeforeAll(func() {
+			secretStore := state.NewSecretStore()
+			secretStore.Upsert(secret1)
+
+			fakeClock = &statusfakes.FakeClock{}
+			secretMgr = state.NewSecretDiskMemoryManager("", secretStore, state.WithSecretNowFunc(fakeClock.Now))
+
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     "test.controller",
+				GatewayClassName:    "my-class",
+				SecretMemoryManager: secretMgr,
+			})
+
+			gwNsName = types.NamespacedName{Namespace: "test", Name: "gateway"}
+			// the NotAfter of the certificate of secret1
+			notAfter = time.Date(2023, 9, 11, 16, 15, 35, 0, time.UTC)
+		})
+
+		It("should report a valid listener while the certificate is valid", func() {
+			fakeClock.NowReturns(metav1.NewTime(notAfter.Add(-time.Hour)))
+
+			processor.CaptureUpsertChange(&v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-class",
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: "test.controller",
+				},
+			})
+			processor.CaptureUpsertChange(&v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gwNsName.Namespace,
+					Name:      gwNsName.Name,
+				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: "my-class",
+					Listeners: []v1beta1.Listener{
+						{
+							Name:     "listener-443-1",
+							Hostname: (*v1beta1.Hostname)(helpers.GetStringPointer("foo.example.com")),
+							Port:     443,
+							Protocol: v1beta1.HTTPSProtocolType,
+							TLS: &v1beta1.GatewayTLSConfig{
+								Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+								CertificateRefs: []v1beta1.SecretObjectReference{
+									{
+										Kind: (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+										Name: v1beta1.ObjectName(secret1.Name),
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(statuses.GatewayStatuses[gwNsName].ListenerStatuses["listener-443-1"].Valid).To(BeTrue())
+			Expect(secretMgr.NextCertificateValidityChange()).To(Equal(notAfter.Add(time.Second)))
+		})
+
+		It("should report not changed if the validity change is not captured", func() {
+			fakeClock.NowReturns(metav1.NewTime(notAfter.Add(time.Second)))
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report an invalid listener after the certificate expires", func() {
+			processor.CaptureCertificateValidityChange()
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(statuses.GatewayStatuses[gwNsName].ListenerStatuses["listener-443-1"].Valid).To(BeFalse())
+		})
+	})
+
+	Describe("GatewayConfig changes", Ordered, func() {
+		var (
+			processor                      *state.ChangeProcessorImpl
+			gc                             *v1beta1.GatewayClass
+			gcfg, gcfgSameGen, gcfgUpdated *nginxgwv1alpha1.GatewayConfig
+			unrelatedGcfg                  *nginxgwv1alpha1.GatewayConfig
+		)
+
+		BeforeAll(func() {
+			fakeSecretMemoryMgr := &statefakes.FakeSecretDiskMemoryManager{}
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     "test.controller",
+				GatewayClassName:    "my-class",
+				SecretMemoryManager: fakeSecretMemoryMgr,
+			})
+
+			gc = &v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "my-class",
+					Generation: 1,
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: "test.controller",
+					ParametersRef: &v1beta1.ParametersReference{
+						Group: "gateway.nginx.org",
+						Kind:  "GatewayConfig",
+						Name:  "nginx",
+					},
+				},
+			}
+
+			createGatewayConfig := func(name string, generation int64, readTimeout time.Duration) *nginxgwv1alpha1.GatewayConfig {
+				return &nginxgwv1alpha1.GatewayConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Generation: generation,
+					},
+					Spec: nginxgwv1alpha1.GatewayConfigSpec{
+						HTTP: &nginxgwv1alpha1.HTTP{
+							ProxyReadTimeout: &metav1.Duration{Duration: readTimeout},
+						},
+					},
+				}
+			}
+
+			gcfg = createGatewayConfig("nginx", 1, time.Minute)
+
+			gcfgSameGen = gcfg.DeepCopy()
+			gcfgSameGen.ResourceVersion = "2"
+
+			gcfgUpdated = createGatewayConfig("nginx", 2, 5*time.Minute)
+
+			unrelatedGcfg = createGatewayConfig("unrelated", 1, time.Minute)
+		})
+
+		It("should report the GatewayClass with invalid parameters when the GatewayConfig doesn't exist", func() {
+			processor.CaptureUpsertChange(gc)
+			processor.CaptureUpsertChange(&v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: "my-class",
+					Listeners: []v1beta1.Listener{
+						{
+							Name:     "listener-80-1",
+							Port:     80,
+							Protocol: v1beta1.HTTPProtocolType,
+						},
+					},
+				},
+			})
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(conf).To(Equal(state.Configuration{}))
+			Expect(statuses.GatewayClassStatus.Valid).To(BeFalse())
+			Expect(statuses.GatewayClassStatus.InvalidParameters).To(BeTrue())
+		})
+
+		It("should report changed and the settings after upserting the referenced GatewayConfig", func() {
+			processor.CaptureUpsertChange(gcfg)
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(conf.Settings).To(Equal(state.Settings{ProxyReadTimeout: time.Minute}))
+			Expect(statuses.GatewayClassStatus.Valid).To(BeTrue())
+		})
+
+		It("should report not changed after upserting the GatewayConfig with the same generation", func() {
+			processor.CaptureUpsertChange(gcfgSameGen)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed and the updated settings after upserting the GatewayConfig with generation change", func() {
+			processor.CaptureUpsertChange(gcfgUpdated)
+
+			changed, conf, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(conf.Settings).To(Equal(state.Settings{ProxyReadTimeout: 5 * time.Minute}))
+		})
+
+		It("should report not changed after upserting and deleting an unrelated GatewayConfig", func() {
+			processor.CaptureUpsertChange(unrelatedGcfg)
+			processor.CaptureDeleteChange(&nginxgwv1alpha1.GatewayConfig{}, types.NamespacedName{Name: "unrelated"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after deleting the referenced GatewayConfig", func() {
+			processor.CaptureDeleteChange(&nginxgwv1alpha1.GatewayConfig{}, types.NamespacedName{Name: "nginx"})
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(conf).To(Equal(state.Configuration{}))
+			Expect(statuses.GatewayClassStatus.InvalidParameters).To(BeTrue())
+		})
+	})
+
+	Describe("Service changes", Ordered, func() {
+		var (
+			processor                      *state.ChangeProcessorImpl
+			svcNsName, unrelatedSvcNsName  types.NamespacedName
+			svc, svcSameStatus, svcUpdated *apiv1.Service
+			unrelatedSvc                   *apiv1.Service
+		)
+
+		BeforeAll(func() {
+			svcNsName = types.NamespacedName{Namespace: "nginx-gateway", Name: "nginx-gateway"}
+			unrelatedSvcNsName = types.NamespacedName{Namespace: "test", Name: "unrelated"}
+
+			fakeSecretMemoryMgr := &statefakes.FakeSecretDiskMemoryManager{}
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     "test.controller",
+				GatewayClassName:    "my-class",
+				SecretMemoryManager: fakeSecretMemoryMgr,
+				ServiceNsName:       svcNsName,
+			})
+
+			createService := func(nsname types.NamespacedName, ip string) *apiv1.Service {
+				return &apiv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: nsname.Namespace,
+						Name:      nsname.Name,
+					},
+					Status: apiv1.ServiceStatus{
+						LoadBalancer: apiv1.LoadBalancerStatus{
+							Ingress: []apiv1.LoadBalancerIngress{{IP: ip}},
+						},
+					},
+				}
+			}
+
+			svc = createService(svcNsName, "10.0.0.1")
+
+			svcSameStatus = svc.DeepCopy()
+			svcSameStatus.ResourceVersion = "2"
+
+			svcUpdated = createService(svcNsName, "10.0.0.2")
+
+			unrelatedSvc = createService(unrelatedSvcNsName, "10.0.0.3")
+		})
+
+		It("should report changed and the addresses of the Service after upserting the Gateway", func() {
+			processor.CaptureUpsertChange(&v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-class",
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: "test.controller",
+				},
+			})
+			processor.CaptureUpsertChange(&v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: "my-class",
+					Listeners: []v1beta1.Listener{
+						{
+							Name:     "listener-80-1",
+							Port:     80,
+							Protocol: v1beta1.HTTPProtocolType,
+						},
+					},
+				},
+			})
+			processor.CaptureUpsertChange(svc)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+
+			gs := statuses.GatewayStatuses[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(gs.Addresses).To(Equal([]v1beta1.GatewayAddress{state.NewGatewayAddress("10.0.0.1")}))
+		})
+
+		It("should report not changed after upserting the Service with the same status", func() {
+			processor.CaptureUpsertChange(svcSameStatus)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting the Service with an updated status", func() {
+			processor.CaptureUpsertChange(svcUpdated)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+
+			gs := statuses.GatewayStatuses[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(gs.Addresses).To(Equal([]v1beta1.GatewayAddress{state.NewGatewayAddress("10.0.0.2")}))
+		})
+
+		It("should report not changed after upserting and deleting an unrelated Service", func() {
+			processor.CaptureUpsertChange(unrelatedSvc)
+			processor.CaptureDeleteChange(&apiv1.Service{}, unrelatedSvcNsName)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed and no addresses after deleting the Service", func() {
+			processor.CaptureDeleteChange(&apiv1.Service{}, svcNsName)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+
+			gs := statuses.GatewayStatuses[types.NamespacedName{Namespace: "test", Name: "gateway"}]
+			Expect(gs.Addresses).To(BeEmpty())
+		})
+	})
+
+	Describe("Relevance of changes", Ordered, func() {
+		var (
+			processor                                     *state.ChangeProcessorImpl
+			gw, unrelatedGw, gwOtherClass                 *v1beta1.Gateway
+			hr, hrUpdated, unrelatedHr                    *v1beta1.HTTPRoute
+			gr, unrelatedGr                               *v1alpha2.GRPCRoute
+			backendSvc, backendSvcSameIP, backendSvcNewIP *apiv1.Service
+			unrelatedSvc                                  *apiv1.Service
+			backendSvcNsName, unrelatedSvcNsName          types.NamespacedName
+		)
+
+		BeforeAll(func() {
+			fakeSecretMemoryMgr := &statefakes.FakeSecretDiskMemoryManager{}
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     "test.controller",
+				GatewayClassName:    "my-class",
+				SecretMemoryManager: fakeSecretMemoryMgr,
+			})
+
+			createGateway := func(name string, className string) *v1beta1.Gateway {
+				return &v1beta1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:  "test",
+						Name:       name,
+						Generation: 1,
+					},
+					Spec: v1beta1.GatewaySpec{
+						GatewayClassName: v1beta1.ObjectName(className),
+						Listeners: []v1beta1.Listener{
+							{
+								Name:     "listener-80-1",
+								Port:     80,
+								Protocol: v1beta1.HTTPProtocolType,
+							},
+						},
+					},
+				}
+			}
+
+			gw = createGateway("gateway", "my-class")
+			unrelatedGw = createGateway("unrelated-gateway", "other-class")
+
+			gwOtherClass = gw.DeepCopy()
+			gwOtherClass.Generation++
+			gwOtherClass.Spec.GatewayClassName = "other-class"
+
+			backendSvcNsName = types.NamespacedName{Namespace: "test", Name: "backend"}
+			unrelatedSvcNsName = types.NamespacedName{Namespace: "test", Name: "unrelated"}
+
+			createRoute := func(name string, gatewayName string) *v1beta1.HTTPRoute {
+				return &v1beta1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:  "test",
+						Name:       name,
+						Generation: 1,
+					},
+					Spec: v1beta1.HTTPRouteSpec{
+						CommonRouteSpec: v1beta1.CommonRouteSpec{
+							ParentRefs: []v1beta1.ParentReference{
+								{
+									Name:        v1beta1.ObjectName(gatewayName),
+									SectionName: (*v1beta1.SectionName)(helpers.GetStringPointer("listener-80-1")),
+								},
+							},
+						},
+						Hostnames: []v1beta1.Hostname{"foo.example.com"},
+						Rules: []v1beta1.HTTPRouteRule{
+							{
+								BackendRefs: []v1beta1.HTTPBackendRef{
+									{
+										BackendRef: v1beta1.BackendRef{
+											BackendObjectReference: v1beta1.BackendObjectReference{
+												Name: v1beta1.ObjectName(backendSvcNsName.Name),
+												Port: (*v1beta1.PortNumber)(helpers.GetInt32Pointer(80)),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			hr = createRoute("hr", gw.Name)
+
+			hrUpdated = hr.DeepCopy()
+			hrUpdated.Generation++
+			hrUpdated.Spec.ParentRefs[0].Name = v1beta1.ObjectName(unrelatedGw.Name)
+
+			unrelatedHr = createRoute("unrelated-hr", unrelatedGw.Name)
+
+			createGRPCRoute := func(name string, gatewayName string) *v1alpha2.GRPCRoute {
+				return &v1alpha2.GRPCRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:  "test",
+						Name:       name,
+						Generation: 1,
+					},
+					Spec: v1alpha2.GRPCRouteSpec{
+						CommonRouteSpec: v1alpha2.CommonRouteSpec{
+							ParentRefs: []v1alpha2.ParentReference{
+								{
+									Name: v1alpha2.ObjectName(gatewayName),
+								},
+							},
+						},
+						Hostnames: []v1alpha2.Hostname{"grpc.example.com"},
+					},
+				}
+			}
+
+			gr = createGRPCRoute("gr", gw.Name)
+			unrelatedGr = createGRPCRoute("unrelated-gr", unrelatedGw.Name)
+
+			createService := func(nsname types.NamespacedName, clusterIP string) *apiv1.Service {
+				return &apiv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: nsname.Namespace,
+						Name:      nsname.Name,
+					},
+					Spec: apiv1.ServiceSpec{
+						ClusterIP: clusterIP,
+					},
+				}
+			}
+
+			backendSvc = createService(backendSvcNsName, "10.0.0.1")
+
+			backendSvcSameIP = backendSvc.DeepCopy()
+			backendSvcSameIP.ResourceVersion = "2"
+
+			backendSvcNewIP = createService(backendSvcNsName, "10.0.0.2")
+
+			unrelatedSvc = createService(unrelatedSvcNsName, "10.0.0.3")
+		})
+
+		It("should report changed after upserting the GatewayClass and the Gateway", func() {
+			processor.CaptureUpsertChange(&v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-class",
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: "test.controller",
+				},
+			})
+			processor.CaptureUpsertChange(gw)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting a Gateway of another GatewayClass", func() {
+			processor.CaptureUpsertChange(unrelatedGw)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report not changed after upserting an HTTPRoute that references another Gateway", func() {
+			processor.CaptureUpsertChange(unrelatedHr)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting an HTTPRoute that references the Gateway", func() {
+			processor.CaptureUpsertChange(hr)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting a GRPCRoute that references another Gateway", func() {
+			processor.CaptureUpsertChange(unrelatedGr)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting a GRPCRoute that references the Gateway", func() {
+			processor.CaptureUpsertChange(gr)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting the same GRPCRoute", func() {
+			processor.CaptureUpsertChange(gr)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report not changed after deleting a GRPCRoute that references another Gateway", func() {
+			processor.CaptureDeleteChange(&v1alpha2.GRPCRoute{}, types.NamespacedName{Namespace: "test", Name: "unrelated-gr"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after deleting a GRPCRoute that references the Gateway", func() {
+			processor.CaptureDeleteChange(&v1alpha2.GRPCRoute{}, types.NamespacedName{Namespace: "test", Name: "gr"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report changed after upserting a referenced Service", func() {
+			processor.CaptureUpsertChange(backendSvc)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting a referenced Service with the same cluster IP", func() {
+			processor.CaptureUpsertChange(backendSvcSameIP)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting a referenced Service with an updated cluster IP", func() {
+			processor.CaptureUpsertChange(backendSvcNewIP)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after upserting and deleting an unreferenced Service", func() {
+			processor.CaptureUpsertChange(unrelatedSvc)
+			processor.CaptureDeleteChange(&apiv1.Service{}, unrelatedSvcNsName)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after deleting a referenced Service", func() {
+			processor.CaptureDeleteChange(&apiv1.Service{}, backendSvcNsName)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report changed after upserting an HTTPRoute that no longer references the Gateway", func() {
+			processor.CaptureUpsertChange(hrUpdated)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+		})
+
+		It("should report not changed after deleting an HTTPRoute that references another Gateway", func() {
+			processor.CaptureDeleteChange(&v1beta1.HTTPRoute{}, types.NamespacedName{Namespace: "test", Name: "hr"})
+			processor.CaptureDeleteChange(&v1beta1.HTTPRoute{}, types.NamespacedName{Namespace: "test", Name: "unrelated-hr"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report not changed after deleting a Gateway of another GatewayClass", func() {
+			processor.CaptureDeleteChange(&v1beta1.Gateway{}, types.NamespacedName{Namespace: "test", Name: "unrelated-gateway"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should report changed after upserting the Gateway that moved to another GatewayClass", func() {
+			processor.CaptureUpsertChange(gwOtherClass)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(statuses.GatewayStatuses).To(BeEmpty())
+		})
+	})
+
 	Describe("Edge cases with panic", func() {
 		var processor state.ChangeProcessor
 		var fakeSecretMemoryMgr *statefakes.FakeSecretDiskMemoryManager
@@ -1068,7 +2069,7 @@ var _ = Describe("ChangeProcessor", func() {
 				}
 				Expect(process).Should(Panic())
 			},
-			Entry("an unsupported resource", &v1alpha2.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tcp"}}),
+			Entry("an unsupported resource", &v1alpha2.ReferenceGrant{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "grant"}}),
 			Entry("a wrong gatewayclass", &v1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "wrong-class"}}))
 
 		DescribeTable("CaptureDeleteChange must panic",
@@ -1078,7 +2079,7 @@ var _ = Describe("ChangeProcessor", func() {
 				}
 				Expect(process).Should(Panic())
 			},
-			Entry("an unsupported resource", &v1alpha2.TCPRoute{}, types.NamespacedName{Namespace: "test", Name: "tcp"}),
+			Entry("an unsupported resource", &v1alpha2.ReferenceGrant{}, types.NamespacedName{Namespace: "test", Name: "grant"}),
 			Entry("a wrong gatewayclass", &v1beta1.GatewayClass{}, types.NamespacedName{Name: "wrong-class"}))
 	})
 })
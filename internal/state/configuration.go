@@ -3,7 +3,10 @@ package state
 import (
 	"fmt"
 	"sort"
+	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -13,27 +16,101 @@ const wildcardHostname = "~^"
 // We can think of Configuration as an intermediate state between the Gateway API resources and the data plane (NGINX)
 // configuration.
 type Configuration struct {
-	// HTTPServers holds all HTTPServers.
-	// FIXME(pleshakov) We assume that all servers are HTTP and listen on port 80.
+	// HTTPServers holds all HTTPServers, grouped by port.
 	HTTPServers []VirtualServer
-	// SSLServers holds all SSLServers.
-	// FIXME(kate-osborn) We assume that all SSL servers listen on port 443.
+	// SSLServers holds all SSLServers, grouped by port.
 	SSLServers []VirtualServer
+	// TLSPassthroughServers holds all TLSPassthroughServers, sorted by port and then by hostname.
+	TLSPassthroughServers []TLSPassthroughServer
+	// TCPServers holds all TCPServers, sorted by port.
+	TCPServers []TCPServer
+	// UDPServers holds all UDPServers, sorted by port.
+	UDPServers []UDPServer
+	// Settings holds the NGINX settings that don't come from the Gateway API resources.
+	Settings Settings
+}
+
+// Settings holds the NGINX settings configured through the GatewayConfig resource.
+type Settings struct {
+	// WorkerProcesses is the number of NGINX worker processes.
+	// Nil means the NGINX default is used.
+	WorkerProcesses *int
+	// AccessLogs are the access logs of the HTTP traffic. If empty, the NGINX default access log is used.
+	AccessLogs []AccessLog
+	// ProxyReadTimeout is the timeout for reading a response from a backend.
+	// Zero means the NGINX default is used.
+	ProxyReadTimeout time.Duration
+	// ProxySendTimeout is the timeout for transmitting a request to a backend.
+	// Zero means the NGINX default is used.
+	ProxySendTimeout time.Duration
+	// DisableHTTP2 disables HTTP/2 for the SSL servers. HTTP/2 is enabled by default.
+	DisableHTTP2 bool
+	// ConfigHashHeader adds a header with the hash of the NGINX configuration to all responses.
+	ConfigHashHeader bool
+	// UDPProxyResponses is the number of datagrams expected from a backend in response to a client datagram.
+	// Nil means the NGINX default is used.
+	UDPProxyResponses *int32
+	// UDPProxyTimeout is the timeout between two successive datagrams of a UDP session.
+	// Zero means the NGINX default is used.
+	UDPProxyTimeout time.Duration
+}
+
+// AccessLog is an access log.
+type AccessLog struct {
+	// Format is either the name of a built-in format or an NGINX log format string.
+	Format string
+	// Destination is stdout, stderr, an absolute path of a file or a syslog server.
+	Destination string
 }
 
 // VirtualServer is a virtual server.
 type VirtualServer struct {
+	// Gateway is the namespaced name of the Gateway of the listeners of the server.
+	Gateway types.NamespacedName
 	// Hostname is the hostname of the server.
 	Hostname string
+	// Port is the port of the server.
+	Port int32
 	// PathRules is a collection of routing rules.
 	PathRules []PathRule
+	// GRPCMethodRules is a collection of gRPC routing rules.
+	GRPCMethodRules []GRPCMethodRule
 	// SSL holds the SSL configuration options fo the server.
 	SSL *SSL
 }
 
+// TLSPassthroughServer is a server that routes TLS connections to a backend based on the SNI of the connection.
+// The server doesn't terminate TLS.
+type TLSPassthroughServer struct {
+	// Hostname is the hostname of the server. It is matched against the SNI.
+	Hostname string
+	// Port is the port of the server.
+	Port int32
+	// Source is the corresponding TLSRoute resource.
+	Source *v1alpha2.TLSRoute
+}
+
+// TCPServer is a server that proxies TCP connections on a port to the backends of a TCPRoute.
+type TCPServer struct {
+	// Port is the port of the server.
+	Port int32
+	// Source is the corresponding TCPRoute resource.
+	Source *v1alpha2.TCPRoute
+}
+
+// UDPServer is a server that proxies UDP datagrams on a port to the backends of a UDPRoute.
+type UDPServer struct {
+	// Port is the port of the server.
+	Port int32
+	// Source is the corresponding UDPRoute resource.
+	Source *v1alpha2.UDPRoute
+}
+
 type SSL struct {
-	// CertificatePath is the path to the certificate file.
-	CertificatePath string
+	// Certificates are the paths to the certificate and key files.
+	Certificates []CertificateFiles
+	// Options holds the TLS options.
+	Options TLSOptions
 }
 
 // PathRule represents routing rules that share a common path.
@@ -70,6 +147,37 @@ func (r *MatchRule) GetMatch() v1beta1.HTTPRouteMatch {
 	return r.Source.Spec.Rules[r.RuleIdx].Matches[r.MatchIdx]
 }
 
+// GRPCMethodRule represents gRPC routing rules that share a common service and method.
+type GRPCMethodRule struct {
+	// Service is the fully-qualified name of a gRPC service. For example, 'helloworld.Greeter'.
+	// Empty means any service.
+	Service string
+	// Method is the name of a gRPC method. For example, 'SayHello'. Empty means any method.
+	Method string
+	// MatchRules holds routing rules.
+	MatchRules []GRPCMatchRule
+}
+
+// GRPCMatchRule represents a gRPC routing rule. It corresponds directly to a Match in the GRPCRoute resource.
+// A rule without matches matches all requests, so it is represented by a single GRPCMatchRule with the empty match.
+type GRPCMatchRule struct {
+	// MatchIdx is the index of the rule in the Rule.Matches.
+	MatchIdx int
+	// RuleIdx is the index of the corresponding rule in the GRPCRoute.
+	RuleIdx int
+	// Source is the corresponding GRPCRoute resource.
+	Source *v1alpha2.GRPCRoute
+}
+
+// GetMatch returns the GRPCRouteMatch of the Route. For a rule without matches, it returns the empty match.
+func (r *GRPCMatchRule) GetMatch() v1alpha2.GRPCRouteMatch {
+	matches := r.Source.Spec.Rules[r.RuleIdx].Matches
+	if len(matches) == 0 {
+		return v1alpha2.GRPCRouteMatch{}
+	}
+	return matches[r.MatchIdx]
+}
+
 // buildConfiguration builds the Configuration from the graph.
 // FIXME(pleshakov) For now we only handle paths with prefix matches. Handle exact and regex matches
 func buildConfiguration(graph *graph) Configuration {
@@ -77,65 +185,172 @@ func buildConfiguration(graph *graph) Configuration {
 		return Configuration{}
 	}
 
-	if graph.Gateway == nil {
+	if len(graph.Gateways) == 0 {
 		return Configuration{}
 	}
 
 	configBuilder := newConfigBuilder()
 
-	for _, l := range graph.Gateway.Listeners {
-		// only upsert listeners that are valid
-		if l.Valid {
-			configBuilder.upsertListener(l)
+	// The listeners of all Gateways are served by the same NGINX, but each Gateway has its own servers on a port,
+	// so that the servers use the certificates and the routes of the listeners of their Gateway.
+	// The conflicts between the listeners of different Gateways are already resolved in the graph.
+	// The Gateways are processed oldest first.
+	for _, gw := range sortGateways(graph.Gateways) {
+		gwNsName := types.NamespacedName{Namespace: gw.Source.Namespace, Name: gw.Source.Name}
+		configBuilder.gateways = append(configBuilder.gateways, gwNsName)
+
+		for _, l := range gw.Listeners {
+			// only upsert listeners that are valid
+			if l.Valid {
+				configBuilder.upsertListener(gwNsName, l)
+			}
 		}
 	}
 
-	return configBuilder.build()
+	conf := configBuilder.build()
+	conf.Settings = buildSettings(graph.GatewayClass.Config)
+
+	return conf
+}
+
+// serverKey identifies the servers of a Gateway on a port.
+type serverKey struct {
+	gateway types.NamespacedName
+	port    v1beta1.PortNumber
 }
 
 type configBuilder struct {
-	http *virtualServerBuilder
-	ssl  *virtualServerBuilder
+	http           map[serverKey]*virtualServerBuilder
+	ssl            map[serverKey]*virtualServerBuilder
+	tlsPassthrough *tlsPassthroughServerBuilder
+	tcp            *tcpServerBuilder
+	udp            *udpServerBuilder
+	// gateways holds the Gateways of the upserted listeners, oldest first.
+	gateways []types.NamespacedName
 }
 
 func newConfigBuilder() *configBuilder {
 	return &configBuilder{
-		http: newVirtualServerBuilder(v1beta1.HTTPProtocolType),
-		ssl:  newVirtualServerBuilder(v1beta1.HTTPSProtocolType),
+		http:           make(map[serverKey]*virtualServerBuilder),
+		ssl:            make(map[serverKey]*virtualServerBuilder),
+		tlsPassthrough: newTLSPassthroughServerBuilder(),
+		tcp:            newTCPServerBuilder(),
+		udp:            newUDPServerBuilder(),
 	}
 }
 
-func (b *configBuilder) upsertListener(l *listener) {
+func (b *configBuilder) upsertListener(gw types.NamespacedName, l *listener) {
+	var builders map[serverKey]*virtualServerBuilder
+
 	switch l.Source.Protocol {
 	case v1beta1.HTTPProtocolType:
-		b.http.upsertListener(l)
+		builders = b.http
 	case v1beta1.HTTPSProtocolType:
-		b.ssl.upsertListener(l)
+		builders = b.ssl
+	case v1beta1.TLSProtocolType:
+		b.tlsPassthrough.upsertListener(l)
+		return
+	case v1beta1.TCPProtocolType:
+		b.tcp.upsertListener(l)
+		return
+	case v1beta1.UDPProtocolType:
+		b.udp.upsertListener(l)
+		return
 	default:
 		panic(fmt.Sprintf("listener protocol %s not supported", l.Source.Protocol))
 	}
+
+	key := serverKey{gateway: gw, port: l.Source.Port}
+
+	if _, exist := builders[key]; !exist {
+		builders[key] = newVirtualServerBuilder(l.Source.Protocol, key)
+	}
+
+	builders[key].upsertListener(l)
 }
 
 func (b *configBuilder) build() Configuration {
 	return Configuration{
-		HTTPServers: b.http.build(),
-		SSLServers:  b.ssl.build(),
+		HTTPServers:           buildServersForPorts(b.http, b.gateways),
+		SSLServers:            buildServersForPorts(b.ssl, b.gateways),
+		TLSPassthroughServers: b.tlsPassthrough.build(),
+		TCPServers:            b.tcp.build(),
+		UDPServers:            b.udp.build(),
+	}
+}
+
+// buildServersForPorts builds the servers for all ports. The servers are sorted by port and then by hostname.
+// NGINX uses only one server for a hostname on a port, so when the servers of multiple Gateways have the same
+// hostname on the same port, the server of the oldest Gateway wins.
+func buildServersForPorts(builders map[serverKey]*virtualServerBuilder, gateways []types.NamespacedName) []VirtualServer {
+	gatewayOrder := make(map[types.NamespacedName]int, len(gateways))
+	for i, gw := range gateways {
+		gatewayOrder[gw] = i
+	}
+
+	keys := make([]serverKey, 0, len(builders))
+	for key := range builders {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].port != keys[j].port {
+			return keys[i].port < keys[j].port
+		}
+		return gatewayOrder[keys[i].gateway] < gatewayOrder[keys[j].gateway]
+	})
+
+	servers := make([]VirtualServer, 0)
+	gatewaysForHost := make(map[hostnameKey]types.NamespacedName)
+
+	for _, key := range keys {
+		for _, s := range builders[key].build() {
+			h := hostnameKey{hostname: s.Hostname, port: key.port}
+
+			if gw, exist := gatewaysForHost[h]; exist && gw != key.gateway {
+				continue
+			}
+
+			gatewaysForHost[h] = key.gateway
+			servers = append(servers, s)
+		}
 	}
+
+	// the servers of the same Gateway with the same hostname keep their order
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Port != servers[j].Port {
+			return servers[i].Port < servers[j].Port
+		}
+		return servers[i].Hostname < servers[j].Hostname
+	})
+
+	return servers
 }
 
+// virtualServerBuilder builds the servers of a Gateway for a single port.
 type virtualServerBuilder struct {
-	protocolType     v1beta1.ProtocolType
-	rulesPerHost     map[string]map[string]PathRule
-	listenersForHost map[string]*listener
-	listeners        []*listener
+	protocolType           v1beta1.ProtocolType
+	key                    serverKey
+	rulesPerHost           map[string]map[string]PathRule
+	grpcMethodRulesPerHost map[string]map[grpcMethod]GRPCMethodRule
+	listenersForHost       map[string]*listener
+	listeners              []*listener
 }
 
-func newVirtualServerBuilder(protocolType v1beta1.ProtocolType) *virtualServerBuilder {
+// grpcMethod identifies the gRPC method rules of a host.
+type grpcMethod struct {
+	service string
+	method  string
+}
+
+func newVirtualServerBuilder(protocolType v1beta1.ProtocolType, key serverKey) *virtualServerBuilder {
 	return &virtualServerBuilder{
-		protocolType:     protocolType,
-		rulesPerHost:     make(map[string]map[string]PathRule),
-		listenersForHost: make(map[string]*listener),
-		listeners:        make([]*listener, 0),
+		protocolType:           protocolType,
+		key:                    key,
+		rulesPerHost:           make(map[string]map[string]PathRule),
+		grpcMethodRulesPerHost: make(map[string]map[grpcMethod]GRPCMethodRule),
+		listenersForHost:       make(map[string]*listener),
+		listeners:              make([]*listener, 0),
 	}
 }
 
@@ -147,7 +362,7 @@ func (b *virtualServerBuilder) upsertListener(l *listener) {
 	for _, r := range l.Routes {
 		var hostnames []string
 
-		for _, h := range r.Source.Spec.Hostnames {
+		for _, h := range getRouteHostnames(r.Source) {
 			if _, exist := l.AcceptedHostnames[string(h)]; exist {
 				hostnames = append(hostnames, string(h))
 			}
@@ -161,27 +376,77 @@ func (b *virtualServerBuilder) upsertListener(l *listener) {
 			}
 		}
 
-		for i, rule := range r.Source.Spec.Rules {
-			filters := createFilters(rule.Filters)
+		switch src := r.Source.(type) {
+		case *v1beta1.HTTPRoute:
+			b.upsertHTTPRoute(src, hostnames)
+		case *v1alpha2.GRPCRoute:
+			b.upsertGRPCRoute(src, hostnames)
+		default:
+			panic(fmt.Sprintf("route type %T not supported", r.Source))
+		}
+	}
+}
+
+func (b *virtualServerBuilder) upsertHTTPRoute(hr *v1beta1.HTTPRoute, hostnames []string) {
+	for i, rule := range hr.Spec.Rules {
+		filters := createFilters(rule.Filters)
+
+		for _, h := range hostnames {
+			for j, m := range rule.Matches {
+				path := getPath(m.Path)
+
+				rule, exist := b.rulesPerHost[h][path]
+				if !exist {
+					rule.Path = path
+				}
+
+				rule.MatchRules = append(rule.MatchRules, MatchRule{
+					MatchIdx: j,
+					RuleIdx:  i,
+					Source:   hr,
+					Filters:  filters,
+				})
+
+				b.rulesPerHost[h][path] = rule
+			}
+		}
+	}
+}
+
+func (b *virtualServerBuilder) upsertGRPCRoute(gr *v1alpha2.GRPCRoute, hostnames []string) {
+	for i, rule := range gr.Spec.Rules {
+		matches := rule.Matches
+		if len(matches) == 0 {
+			// a rule without matches matches all requests
+			matches = []v1alpha2.GRPCRouteMatch{{}}
+		}
 
-			for _, h := range hostnames {
-				for j, m := range rule.Matches {
-					path := getPath(m.Path)
+		for _, h := range hostnames {
+			if _, exist := b.grpcMethodRulesPerHost[h]; !exist {
+				b.grpcMethodRulesPerHost[h] = make(map[grpcMethod]GRPCMethodRule)
+			}
 
-					rule, exist := b.rulesPerHost[h][path]
-					if !exist {
-						rule.Path = path
-					}
+			for j, m := range matches {
+				// FIXME(pleshakov): For now we only support type "Exact".
+				if m.Method != nil && m.Method.Type != nil && *m.Method.Type != v1alpha2.GRPCMethodMatchExact {
+					continue
+				}
 
-					rule.MatchRules = append(rule.MatchRules, MatchRule{
-						MatchIdx: j,
-						RuleIdx:  i,
-						Source:   r.Source,
-						Filters:  filters,
-					})
+				key := getGRPCMethod(m.Method)
 
-					b.rulesPerHost[h][path] = rule
+				rule, exist := b.grpcMethodRulesPerHost[h][key]
+				if !exist {
+					rule.Service = key.service
+					rule.Method = key.method
 				}
+
+				rule.MatchRules = append(rule.MatchRules, GRPCMatchRule{
+					MatchIdx: j,
+					RuleIdx:  i,
+					Source:   gr,
+				})
+
+				b.grpcMethodRulesPerHost[h][key] = rule
 			}
 		}
 	}
@@ -192,7 +457,9 @@ func (b *virtualServerBuilder) build() []VirtualServer {
 
 	for h, rules := range b.rulesPerHost {
 		s := VirtualServer{
+			Gateway:   b.key.gateway,
 			Hostname:  h,
+			Port:      int32(b.key.port),
 			PathRules: make([]PathRule, 0, len(rules)),
 		}
 
@@ -201,8 +468,8 @@ func (b *virtualServerBuilder) build() []VirtualServer {
 			panic(fmt.Sprintf("no listener found for hostname: %s", h))
 		}
 
-		if l.SecretPath != "" {
-			s.SSL = &SSL{CertificatePath: l.SecretPath}
+		if len(l.Certificates) > 0 {
+			s.SSL = &SSL{Certificates: l.Certificates, Options: l.TLSOptions}
 		}
 
 		for _, r := range rules {
@@ -216,6 +483,18 @@ func (b *virtualServerBuilder) build() []VirtualServer {
 			return s.PathRules[i].Path < s.PathRules[j].Path
 		})
 
+		if grpcRules, exist := b.grpcMethodRulesPerHost[h]; exist {
+			s.GRPCMethodRules = make([]GRPCMethodRule, 0, len(grpcRules))
+
+			for _, r := range grpcRules {
+				sortGRPCMatchRules(r.MatchRules)
+
+				s.GRPCMethodRules = append(s.GRPCMethodRules, r)
+			}
+
+			sortGRPCMethodRules(s.GRPCMethodRules)
+		}
+
 		servers = append(servers, s)
 	}
 
@@ -225,8 +504,10 @@ func (b *virtualServerBuilder) build() []VirtualServer {
 		// FIXME(kate-osborn): when we support regex hostnames (e.g. *.example.com) we will have to modify this check to catch regex hostnames.
 		if len(l.Routes) == 0 || hostname == wildcardHostname {
 			servers = append(servers, VirtualServer{
+				Gateway:  b.key.gateway,
 				Hostname: hostname,
-				SSL:      &SSL{CertificatePath: l.SecretPath},
+				Port:     int32(b.key.port),
+				SSL:      &SSL{Certificates: l.Certificates, Options: l.TLSOptions},
 			})
 		}
 	}
@@ -238,6 +519,160 @@ func (b *virtualServerBuilder) build() []VirtualServer {
 	return servers
 }
 
+// tlsPassthroughServerBuilder builds the TLS passthrough servers for all ports.
+type tlsPassthroughServerBuilder struct {
+	routesForHost map[hostnameKey]*v1alpha2.TLSRoute
+}
+
+func newTLSPassthroughServerBuilder() *tlsPassthroughServerBuilder {
+	return &tlsPassthroughServerBuilder{
+		routesForHost: make(map[hostnameKey]*v1alpha2.TLSRoute),
+	}
+}
+
+func (b *tlsPassthroughServerBuilder) upsertListener(l *listener) {
+	for _, r := range l.Routes {
+		tr := r.Source.(*v1alpha2.TLSRoute)
+
+		for _, h := range tr.Spec.Hostnames {
+			if _, exist := l.AcceptedHostnames[string(h)]; !exist {
+				continue
+			}
+
+			key := hostnameKey{hostname: string(h), port: l.Source.Port}
+
+			// when multiple TLSRoutes claim the same hostname and port, the oldest TLSRoute wins
+			holder, exist := b.routesForHost[key]
+			if exist && lessObjectMeta(&holder.ObjectMeta, &tr.ObjectMeta) {
+				continue
+			}
+
+			b.routesForHost[key] = tr
+		}
+	}
+}
+
+func (b *tlsPassthroughServerBuilder) build() []TLSPassthroughServer {
+	servers := make([]TLSPassthroughServer, 0, len(b.routesForHost))
+
+	for key, tr := range b.routesForHost {
+		servers = append(servers, TLSPassthroughServer{
+			Hostname: key.hostname,
+			Port:     int32(key.port),
+			Source:   tr,
+		})
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].Port != servers[j].Port {
+			return servers[i].Port < servers[j].Port
+		}
+		return servers[i].Hostname < servers[j].Hostname
+	})
+
+	return servers
+}
+
+// tcpServerBuilder builds the TCP servers for all ports.
+type tcpServerBuilder struct {
+	routesForPort map[v1beta1.PortNumber]*v1alpha2.TCPRoute
+}
+
+func newTCPServerBuilder() *tcpServerBuilder {
+	return &tcpServerBuilder{
+		routesForPort: make(map[v1beta1.PortNumber]*v1alpha2.TCPRoute),
+	}
+}
+
+func (b *tcpServerBuilder) upsertListener(l *listener) {
+	for _, r := range l.Routes {
+		tr := r.Source.(*v1alpha2.TCPRoute)
+
+		// when multiple TCPRoutes are attached to the same port, the oldest TCPRoute wins
+		// (the graph already detaches the other TCPRoutes of the listener, see detachConflictedRoutes)
+		holder, exist := b.routesForPort[l.Source.Port]
+		if exist && lessObjectMeta(&holder.ObjectMeta, &tr.ObjectMeta) {
+			continue
+		}
+
+		b.routesForPort[l.Source.Port] = tr
+	}
+}
+
+func (b *tcpServerBuilder) build() []TCPServer {
+	servers := make([]TCPServer, 0, len(b.routesForPort))
+
+	for port, tr := range b.routesForPort {
+		servers = append(servers, TCPServer{
+			Port:   int32(port),
+			Source: tr,
+		})
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Port < servers[j].Port
+	})
+
+	return servers
+}
+
+// udpServerBuilder builds the UDP servers for all ports.
+type udpServerBuilder struct {
+	routesForPort map[v1beta1.PortNumber]*v1alpha2.UDPRoute
+}
+
+func newUDPServerBuilder() *udpServerBuilder {
+	return &udpServerBuilder{
+		routesForPort: make(map[v1beta1.PortNumber]*v1alpha2.UDPRoute),
+	}
+}
+
+func (b *udpServerBuilder) upsertListener(l *listener) {
+	for _, r := range l.Routes {
+		ur := r.Source.(*v1alpha2.UDPRoute)
+
+		// when multiple UDPRoutes are attached to the same port, the oldest UDPRoute wins
+		// (the graph already detaches the other UDPRoutes of the listener, see detachConflictedRoutes)
+		holder, exist := b.routesForPort[l.Source.Port]
+		if exist && lessObjectMeta(&holder.ObjectMeta, &ur.ObjectMeta) {
+			continue
+		}
+
+		b.routesForPort[l.Source.Port] = ur
+	}
+}
+
+func (b *udpServerBuilder) build() []UDPServer {
+	servers := make([]UDPServer, 0, len(b.routesForPort))
+
+	for port, ur := range b.routesForPort {
+		servers = append(servers, UDPServer{
+			Port:   int32(port),
+			Source: ur,
+		})
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Port < servers[j].Port
+	})
+
+	return servers
+}
+
+// sortGateways returns the Gateways sorted from the oldest to the newest.
+func sortGateways(gws map[types.NamespacedName]*gateway) []*gateway {
+	result := make([]*gateway, 0, len(gws))
+	for _, gw := range gws {
+		result = append(result, gw)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessObjectMeta(&result[i].Source.ObjectMeta, &result[j].Source.ObjectMeta)
+	})
+
+	return result
+}
+
 func getListenerHostname(h *v1beta1.Hostname) string {
 	name := getHostname(h)
 	if name == "" {
@@ -254,6 +689,23 @@ func getPath(path *v1beta1.HTTPPathMatch) string {
 	return *path.Value
 }
 
+func getGRPCMethod(m *v1alpha2.GRPCMethodMatch) grpcMethod {
+	var key grpcMethod
+
+	if m == nil {
+		return key
+	}
+
+	if m.Service != nil {
+		key.service = *m.Service
+	}
+	if m.Method != nil {
+		key.method = *m.Method
+	}
+
+	return key
+}
+
 func createFilters(filters []v1beta1.HTTPRouteFilter) Filters {
 	var result Filters
 
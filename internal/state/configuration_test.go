@@ -2,16 +2,22 @@ package state
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 func TestBuildConfiguration(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
 	createRoute := func(name string, hostname string, listenerName string, paths ...string) *v1beta1.HTTPRoute {
 		rules := make([]v1beta1.HTTPRouteRule, 0, len(paths))
 		for _, p := range paths {
@@ -59,90 +65,101 @@ func TestBuildConfiguration(t *testing.T) {
 
 	routeHR1 := &route{
 		Source: hr1,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	hr2 := createRoute("hr-2", "bar.example.com", "listener-80-1", "/")
 
 	routeHR2 := &route{
 		Source: hr2,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
+	}
+
+	otherGwHR := createRoute("other-gw-hr", "bar.example.com", "listener-80-1", "/")
+	otherGwHR.Spec.ParentRefs[0].Name = "other-gateway"
+
+	routeOtherGwHR := &route{
+		Source: otherGwHR,
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: otherGwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	httpsHR1 := createRoute("https-hr-1", "foo.example.com", "listener-443-1", "/")
 
 	httpsRouteHR1 := &route{
 		Source: httpsHR1,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-443-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	httpsHR2 := createRoute("https-hr-2", "bar.example.com", "listener-443-1", "/")
 
 	httpsRouteHR2 := &route{
 		Source: httpsHR2,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-443-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	hr3 := createRoute("hr-3", "foo.example.com", "listener-80-1", "/", "/third")
 
 	routeHR3 := &route{
 		Source: hr3,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	httpsHR3 := createRoute("https-hr-3", "foo.example.com", "listener-443-1", "/", "/third")
 
 	httpsRouteHR3 := &route{
 		Source: httpsHR3,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-443-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	hr4 := createRoute("hr-4", "foo.example.com", "listener-80-1", "/fourth", "/")
 
 	routeHR4 := &route{
 		Source: hr4,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	httpsHR4 := createRoute("https-hr-4", "foo.example.com", "listener-443-1", "/fourth", "/")
 
 	httpsRouteHR4 := &route{
 		Source: httpsHR4,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-443-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	httpsHR5 := createRoute("https-hr-5", "example.com", "listener-443-with-hostname", "/")
 
 	httpsRouteHR5 := &route{
 		Source: httpsHR5,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-443-with-hostname": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-with-hostname"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
 	}
 
 	redirect := v1beta1.HTTPRouteFilter{
@@ -159,10 +176,87 @@ func TestBuildConfiguration(t *testing.T) {
 
 	routeHR6 := &route{
 		Source: hr6,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
+	}
+
+	createGRPCRoute := func(
+		name string,
+		creationTime metav1.Time,
+		rules ...v1alpha2.GRPCRouteRule,
+	) *v1alpha2.GRPCRoute {
+		return &v1alpha2.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              name,
+				CreationTimestamp: creationTime,
+			},
+			Spec: v1alpha2.GRPCRouteSpec{
+				Hostnames: []v1alpha2.Hostname{"grpc.example.com"},
+				Rules:     rules,
+			},
+		}
+	}
+
+	createGRPCMethodMatch := func(service string, method string) v1alpha2.GRPCRouteMatch {
+		m := v1alpha2.GRPCRouteMatch{
+			Method: &v1alpha2.GRPCMethodMatch{},
+		}
+		if service != "" {
+			m.Method.Service = helpers.GetStringPointer(service)
+		}
+		if method != "" {
+			m.Method.Method = helpers.GetStringPointer(method)
+		}
+		return m
+	}
+
+	sayHelloWithHeader := createGRPCMethodMatch("helloworld.Greeter", "SayHello")
+	sayHelloWithHeader.Headers = []v1alpha2.GRPCHeaderMatch{{Name: "version", Value: "2"}}
+
+	regexMatch := createGRPCMethodMatch("helloworld.*", "")
+	regexMatch.Method.Type = (*v1alpha2.GRPCMethodMatchType)(helpers.GetStringPointer(
+		string(v1alpha2.GRPCMethodMatchRegularExpression),
+	))
+
+	gr1 := createGRPCRoute(
+		"gr-1",
+		metav1.NewTime(time.Now().Add(-time.Hour)),
+		v1alpha2.GRPCRouteRule{
+			Matches: []v1alpha2.GRPCRouteMatch{
+				createGRPCMethodMatch("helloworld.Greeter", "SayHello"),
+				sayHelloWithHeader,
+			},
+		},
+		v1alpha2.GRPCRouteRule{}, // no matches
+		v1alpha2.GRPCRouteRule{
+			Matches: []v1alpha2.GRPCRouteMatch{
+				createGRPCMethodMatch("helloworld.Greeter", ""),
+				regexMatch, // not supported
+			},
+		},
+	)
+	// gr2 is newer than gr1, so its match of the same method goes after the match of gr1
+	gr2 := createGRPCRoute(
+		"gr-2",
+		metav1.Now(),
+		v1alpha2.GRPCRouteRule{
+			Matches: []v1alpha2.GRPCRouteMatch{
+				createGRPCMethodMatch("helloworld.Greeter", "SayHello"),
+			},
+		},
+	)
+
+	createGRPCRouteRoute := func(gr *v1alpha2.GRPCRoute) *route {
+		return &route{
+			Source: gr,
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+		}
 	}
 
 	listener80 := v1beta1.Listener{
@@ -172,6 +266,23 @@ func TestBuildConfiguration(t *testing.T) {
 		Protocol: v1beta1.HTTPProtocolType,
 	}
 
+	hr8080 := createRoute("hr-8080", "foo.example.com", "listener-8080", "/")
+
+	routeHR8080 := &route{
+		Source: hr8080,
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-8080"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
+	}
+
+	listener8080 := v1beta1.Listener{
+		Name:     "listener-8080",
+		Hostname: nil,
+		Port:     8080,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
 	listener443 := v1beta1.Listener{
 		Name:     "listener-443-1",
 		Hostname: nil,
@@ -215,8 +326,120 @@ func TestBuildConfiguration(t *testing.T) {
 		TLS:      nil, // missing TLS config
 	}
 
+	createTLSRoute := func(name string, hostname string, creationTime metav1.Time) *v1alpha2.TLSRoute {
+		return &v1alpha2.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              name,
+				CreationTimestamp: creationTime,
+			},
+			Spec: v1alpha2.TLSRouteSpec{
+				Hostnames: []v1alpha2.Hostname{
+					v1alpha2.Hostname(hostname),
+				},
+			},
+		}
+	}
+
+	tr1 := createTLSRoute("tr-1", "foo.example.com", metav1.Now())
+	tr2 := createTLSRoute("tr-2", "bar.example.com", metav1.Now())
+	// tr3 is older than tr1, so it wins the foo.example.com hostname
+	tr3 := createTLSRoute("tr-3", "foo.example.com", metav1.NewTime(tr1.CreationTimestamp.Add(-time.Hour)))
+
+	createTLSRouteRoute := func(tr *v1alpha2.TLSRoute) *route {
+		return &route{
+			Source: tr,
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-tls"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+		}
+	}
+
+	listenerTLS := v1beta1.Listener{
+		Name:     "listener-tls",
+		Hostname: nil,
+		Port:     8443,
+		Protocol: v1beta1.TLSProtocolType,
+		TLS: &v1beta1.GatewayTLSConfig{
+			Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+		},
+	}
+
+	createTCPRoute := func(name string, creationTime metav1.Time) *v1alpha2.TCPRoute {
+		return &v1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              name,
+				CreationTimestamp: creationTime,
+			},
+		}
+	}
+
+	tcpr1 := createTCPRoute("tcpr-1", metav1.Now())
+	// tcpr2 is older than tcpr1, so it wins the port
+	tcpr2 := createTCPRoute("tcpr-2", metav1.NewTime(tcpr1.CreationTimestamp.Add(-time.Hour)))
+	tcpr3 := createTCPRoute("tcpr-3", metav1.Now())
+
+	createTCPRouteRoute := func(tr *v1alpha2.TCPRoute, listenerName string) *route {
+		return &route{
+			Source: tr,
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: listenerName}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+		}
+	}
+
+	listenerTCP5432 := v1beta1.Listener{
+		Name:     "listener-tcp-5432",
+		Port:     5432,
+		Protocol: v1beta1.TCPProtocolType,
+	}
+
+	listenerTCP6379 := v1beta1.Listener{
+		Name:     "listener-tcp-6379",
+		Port:     6379,
+		Protocol: v1beta1.TCPProtocolType,
+	}
+
+	ur1 := &v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "ur-1",
+		},
+	}
+
+	listenerUDP53 := v1beta1.Listener{
+		Name:     "listener-udp-53",
+		Port:     53,
+		Protocol: v1beta1.UDPProtocolType,
+	}
+
 	// nolint:gosec
-	secretPath := "/etc/nginx/secrets/secret"
+	secretFiles := CertificateFiles{
+		Certificate: "/etc/nginx/secrets/secret.crt",
+		Key:         "/etc/nginx/secrets/secret.key",
+	}
+
+	otherGwSecretFiles := CertificateFiles{
+		Certificate: "/etc/nginx/secrets/other-secret.crt",
+		Key:         "/etc/nginx/secrets/other-secret.key",
+	}
+
+	otherGwListener443 := listener443WithHostname
+	otherGwListener443.Hostname = (*v1beta1.Hostname)(helpers.GetStringPointer("foo.example.com"))
+
+	otherGwHTTPSHR := createRoute("other-gw-https-hr", "foo.example.com", "listener-443-with-hostname", "/")
+	otherGwHTTPSHR.Spec.ParentRefs[0].Name = "other-gateway"
+
+	routeOtherGwHTTPSHR := &route{
+		Source: otherGwHTTPSHR,
+		ValidSectionNameRefs: map[ParentRef]struct{}{
+			{Gateway: otherGwNsName, SectionName: "listener-443-with-hostname"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRef]struct{}{},
+	}
 
 	tests := []struct {
 		graph    *graph
@@ -229,15 +452,20 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source:    &v1beta1.Gateway{},
-					Listeners: map[string]*listener{},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{},
+					},
 				},
 				Routes: map[types.NamespacedName]*route{},
 			},
 			expected: Configuration{
-				HTTPServers: []VirtualServer{},
-				SSLServers:  []VirtualServer{},
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "no listeners and routes",
 		},
@@ -247,22 +475,27 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source:            listener80,
-							Valid:             true,
-							Routes:            map[types.NamespacedName]*route{},
-							AcceptedHostnames: map[string]struct{}{},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source:            listener80,
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+							},
 						},
 					},
 				},
 				Routes: map[types.NamespacedName]*route{},
 			},
 			expected: Configuration{
-				HTTPServers: []VirtualServer{},
-				SSLServers:  []VirtualServer{},
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "http listener with no routes",
 		},
@@ -271,23 +504,152 @@ func TestBuildConfiguration(t *testing.T) {
 				GatewayClass: &gatewayClass{
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
+					Config: &nginxgwv1alpha1.GatewayConfig{
+						Spec: nginxgwv1alpha1.GatewayConfigSpec{
+							HTTP: &nginxgwv1alpha1.HTTP{
+								ProxyReadTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+								ProxySendTimeout: &metav1.Duration{Duration: 30 * time.Second},
+							},
+						},
+					},
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source:            listener80,
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{},
+			},
+			expected: Configuration{
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+				Settings: Settings{
+					ProxyReadTimeout: 5 * time.Minute,
+					ProxySendTimeout: 30 * time.Second,
+				},
+			},
+			msg: "gatewayclass with gatewayconfig with proxy timeouts",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+					Config: &nginxgwv1alpha1.GatewayConfig{
+						Spec: nginxgwv1alpha1.GatewayConfigSpec{
+							Stream: &nginxgwv1alpha1.Stream{
+								UDP: &nginxgwv1alpha1.UDP{
+									ProxyResponses: helpers.GetInt32Pointer(0),
+									ProxyTimeout:   &metav1.Duration{Duration: time.Minute},
+								},
+							},
+						},
+					},
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source:            listener80,
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{},
+			},
+			expected: Configuration{
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+				Settings: Settings{
+					UDPProxyResponses: helpers.GetInt32Pointer(0),
+					UDPProxyTimeout:   time.Minute,
+				},
+			},
+			msg: "gatewayclass with gatewayconfig with udp settings",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+					Config: &nginxgwv1alpha1.GatewayConfig{
+						Spec: nginxgwv1alpha1.GatewayConfigSpec{
+							HTTP: &nginxgwv1alpha1.HTTP{
+								HTTP2: helpers.GetBoolPointer(false),
+							},
+						},
+					},
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-443-1": {
-							Source:            listener443, // nil hostname
-							Valid:             true,
-							Routes:            map[types.NamespacedName]*route{},
-							AcceptedHostnames: map[string]struct{}{},
-							SecretPath:        secretPath,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source:            listener80,
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+							},
 						},
-						"listener-443-with-hostname": {
-							Source:            listener443WithHostname, // non-nil hostname
-							Valid:             true,
-							Routes:            map[types.NamespacedName]*route{},
-							AcceptedHostnames: map[string]struct{}{},
-							SecretPath:        secretPath,
+					},
+				},
+				Routes: map[types.NamespacedName]*route{},
+			},
+			expected: Configuration{
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+				Settings: Settings{
+					DisableHTTP2: true,
+				},
+			},
+			msg: "gatewayclass with gatewayconfig with http2 disabled",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-443-1": {
+								Source:            listener443, // nil hostname
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+								Certificates:      []CertificateFiles{secretFiles},
+							},
+							"listener-443-with-hostname": {
+								Source:            listener443WithHostname, // non-nil hostname
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+								Certificates:      []CertificateFiles{secretFiles},
+								TLSOptions:        TLSOptions{Protocols: []string{"TLSv1.3"}},
+							},
 						},
 					},
 				},
@@ -297,14 +659,24 @@ func TestBuildConfiguration(t *testing.T) {
 				HTTPServers: []VirtualServer{},
 				SSLServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: string(hostname),
-						SSL:      &SSL{CertificatePath: secretPath},
+						Port:     443,
+						SSL: &SSL{
+							Certificates: []CertificateFiles{secretFiles},
+							Options:      TLSOptions{Protocols: []string{"TLSv1.3"}},
+						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: wildcardHostname,
-						SSL:      &SSL{CertificatePath: secretPath},
+						Port:     443,
+						SSL:      &SSL{Certificates: []CertificateFiles{secretFiles}},
 					},
 				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "https listeners with no routes",
 		},
@@ -314,21 +686,23 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"invalid-listener": {
-							Source: invalidListener,
-							Valid:  false,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
-								{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
-								"bar.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"invalid-listener": {
+								Source: invalidListener,
+								Valid:  false,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
+									{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
+								Certificates: nil,
 							},
-							SecretPath: "",
 						},
 					},
 				},
@@ -338,8 +712,11 @@ func TestBuildConfiguration(t *testing.T) {
 				},
 			},
 			expected: Configuration{
-				HTTPServers: []VirtualServer{},
-				SSLServers:  []VirtualServer{},
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "invalid listener",
 		},
@@ -349,19 +726,21 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source: listener80,
-							Valid:  true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
-								{Namespace: "test", Name: "hr-2"}: routeHR2,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
-								"bar.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+									{Namespace: "test", Name: "hr-2"}: routeHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
 							},
 						},
 					},
@@ -374,7 +753,9 @@ func TestBuildConfiguration(t *testing.T) {
 			expected: Configuration{
 				HTTPServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: "bar.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -389,7 +770,9 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: "foo.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -404,7 +787,10 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 				},
-				SSLServers: []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "one http listener with two routes for different hostnames",
 		},
@@ -414,31 +800,206 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-443-1": {
-							Source:     listener443,
-							Valid:      true,
-							SecretPath: secretPath,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
-								{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+						},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+					otherGwNsName: {
+						Source: &v1beta1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other-gateway"},
+						},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "other-gw-hr"}: routeOtherGwHR,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"bar.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
-								"bar.example.com": {},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}:        routeHR1,
+					{Namespace: "test", Name: "other-gw-hr"}: routeOtherGwHR,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						Gateway:  otherGwNsName,
+						Hostname: "bar.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   otherGwHR,
+									},
+								},
+							},
+						},
+					},
+					{
+						Gateway:  gwNsName,
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr1,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+			},
+			msg: "two gateways with http listeners on the same port with routes for different hostnames",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-8080": {
+								Source: listener8080,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-8080"}: routeHR8080,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}:    routeHR1,
+					{Namespace: "test", Name: "hr-8080"}: routeHR8080,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						Gateway:  gwNsName,
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr1,
+									},
+								},
+							},
+						},
+					},
+					{
+						Gateway:  gwNsName,
+						Hostname: "foo.example.com",
+						Port:     8080,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr8080,
+									},
+								},
 							},
 						},
-						"listener-443-with-hostname": {
-							Source:     listener443WithHostname,
-							Valid:      true,
-							SecretPath: secretPath,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "https-hr-5"}: httpsRouteHR5,
+					},
+				},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+			},
+			msg: "two http listeners on different ports with routes for the same hostname",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-443-1": {
+								Source:       listener443,
+								Valid:        true,
+								Certificates: []CertificateFiles{secretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
+									{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"example.com": {},
+							"listener-443-with-hostname": {
+								Source:       listener443WithHostname,
+								Valid:        true,
+								Certificates: []CertificateFiles{secretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-5"}: httpsRouteHR5,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"example.com": {},
+								},
 							},
 						},
 					},
@@ -453,7 +1014,9 @@ func TestBuildConfiguration(t *testing.T) {
 				HTTPServers: []VirtualServer{},
 				SSLServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: "bar.example.com",
+						Port:     443,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -467,11 +1030,13 @@ func TestBuildConfiguration(t *testing.T) {
 							},
 						},
 						SSL: &SSL{
-							CertificatePath: secretPath,
+							Certificates: []CertificateFiles{secretFiles},
 						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: "example.com",
+						Port:     443,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -485,11 +1050,13 @@ func TestBuildConfiguration(t *testing.T) {
 							},
 						},
 						SSL: &SSL{
-							CertificatePath: secretPath,
+							Certificates: []CertificateFiles{secretFiles},
 						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: "foo.example.com",
+						Port:     443,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -503,14 +1070,19 @@ func TestBuildConfiguration(t *testing.T) {
 							},
 						},
 						SSL: &SSL{
-							CertificatePath: secretPath,
+							Certificates: []CertificateFiles{secretFiles},
 						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: wildcardHostname,
-						SSL:      &SSL{CertificatePath: secretPath},
+						Port:     443,
+						SSL:      &SSL{Certificates: []CertificateFiles{secretFiles}},
 					},
 				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "two https listeners each with routes for different hostnames",
 		},
@@ -520,30 +1092,228 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source: listener80,
-							Valid:  true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-3"}: routeHR3,
-								{Namespace: "test", Name: "hr-4"}: routeHR4,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-443-1": {
+								Source:       listener443,
+								Valid:        true,
+								Certificates: []CertificateFiles{secretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+						},
+					},
+					otherGwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other-gateway"}},
+						Listeners: map[string]*listener{
+							"listener-443-with-hostname": {
+								Source:       otherGwListener443,
+								Valid:        true,
+								Certificates: []CertificateFiles{otherGwSecretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "other-gw-https-hr"}: routeOtherGwHTTPSHR,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "https-hr-1"}:        httpsRouteHR1,
+					{Namespace: "test", Name: "other-gw-https-hr"}: routeOtherGwHTTPSHR,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers: []VirtualServer{
+					{
+						Gateway:  gwNsName,
+						Hostname: "foo.example.com",
+						Port:     443,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   httpsHR1,
+									},
+								},
 							},
 						},
-						"listener-443-1": {
-							Source:     listener443,
-							Valid:      true,
-							SecretPath: secretPath,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "https-hr-3"}: httpsRouteHR3,
-								{Namespace: "test", Name: "https-hr-4"}: httpsRouteHR4,
+						SSL: &SSL{
+							Certificates: []CertificateFiles{secretFiles},
+						},
+					},
+					{
+						Gateway:  gwNsName,
+						Hostname: wildcardHostname,
+						Port:     443,
+						SSL:      &SSL{Certificates: []CertificateFiles{secretFiles}},
+					},
+				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+			},
+			msg: "two gateways with https listeners on the same port with routes for the same hostname",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-443-1": {
+								Source:       listener443,
+								Valid:        true,
+								Certificates: []CertificateFiles{secretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
+									{Namespace: "test", Name: "gr-1"}:       createGRPCRouteRoute(gr1),
+									{Namespace: "test", Name: "gr-2"}:       createGRPCRouteRoute(gr2),
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com":  {},
+									"grpc.example.com": {},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers: []VirtualServer{
+					{
+						Gateway:  gwNsName,
+						Hostname: "foo.example.com",
+						Port:     443,
+						PathRules: []PathRule{
+							{
+								Path: "/",
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   httpsHR1,
+									},
+								},
+							},
+						},
+						SSL: &SSL{
+							Certificates: []CertificateFiles{secretFiles},
+						},
+					},
+					{
+						Gateway:   gwNsName,
+						Hostname:  "grpc.example.com",
+						Port:      443,
+						PathRules: []PathRule{},
+						GRPCMethodRules: []GRPCMethodRule{
+							{
+								Service: "helloworld.Greeter",
+								Method:  "SayHello",
+								MatchRules: []GRPCMatchRule{
+									{
+										MatchIdx: 1,
+										RuleIdx:  0,
+										Source:   gr1,
+									},
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   gr1,
+									},
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   gr2,
+									},
+								},
+							},
+							{
+								Service: "helloworld.Greeter",
+								MatchRules: []GRPCMatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  2,
+										Source:   gr1,
+									},
+								},
+							},
+							{
+								MatchRules: []GRPCMatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  1,
+										Source:   gr1,
+									},
+								},
+							},
+						},
+						SSL: &SSL{
+							Certificates: []CertificateFiles{secretFiles},
+						},
+					},
+					{
+						Gateway:  gwNsName,
+						Hostname: wildcardHostname,
+						Port:     443,
+						SSL:      &SSL{Certificates: []CertificateFiles{secretFiles}},
+					},
+				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
+			},
+			msg: "one https listener with http and grpc routes for different hostnames",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-3"}: routeHR3,
+									{Namespace: "test", Name: "hr-4"}: routeHR4,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+							"listener-443-1": {
+								Source:       listener443,
+								Valid:        true,
+								Certificates: []CertificateFiles{secretFiles},
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "https-hr-3"}: httpsRouteHR3,
+									{Namespace: "test", Name: "https-hr-4"}: httpsRouteHR4,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
 						},
 					},
@@ -558,7 +1328,9 @@ func TestBuildConfiguration(t *testing.T) {
 			expected: Configuration{
 				HTTPServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: "foo.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -600,9 +1372,11 @@ func TestBuildConfiguration(t *testing.T) {
 				},
 				SSLServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: "foo.example.com",
+						Port:     443,
 						SSL: &SSL{
-							CertificatePath: secretPath,
+							Certificates: []CertificateFiles{secretFiles},
 						},
 						PathRules: []PathRule{
 							{
@@ -643,10 +1417,15 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 					{
+						Gateway:  gwNsName,
 						Hostname: wildcardHostname,
-						SSL:      &SSL{CertificatePath: secretPath},
+						Port:     443,
+						SSL:      &SSL{Certificates: []CertificateFiles{secretFiles}},
 					},
 				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "one http and one https listener with two routes with the same hostname with and without collisions",
 		},
@@ -657,17 +1436,19 @@ func TestBuildConfiguration(t *testing.T) {
 					Valid:    false,
 					ErrorMsg: "error",
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source: listener80,
-							Valid:  true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
 						},
 					},
@@ -682,17 +1463,19 @@ func TestBuildConfiguration(t *testing.T) {
 		{
 			graph: &graph{
 				GatewayClass: nil,
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source: listener80,
-							Valid:  true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
 						},
 					},
@@ -710,8 +1493,8 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: nil,
-				Routes:  map[types.NamespacedName]*route{},
+				Gateways: nil,
+				Routes:   map[types.NamespacedName]*route{},
 			},
 			expected: Configuration{},
 			msg:      "missing gateway",
@@ -722,17 +1505,19 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1beta1.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1beta1.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Source: listener80,
-							Valid:  true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-6"}: routeHR6,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-6"}: routeHR6,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
 						},
 					},
@@ -744,7 +1529,9 @@ func TestBuildConfiguration(t *testing.T) {
 			expected: Configuration{
 				HTTPServers: []VirtualServer{
 					{
+						Gateway:  gwNsName,
 						Hostname: "foo.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
 								Path: "/",
@@ -762,10 +1549,161 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 				},
-				SSLServers: []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers:            []UDPServer{},
 			},
 			msg: "one http listener with one route with filters",
 		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-tls": {
+								Source: listenerTLS,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "tr-1"}: createTLSRouteRoute(tr1),
+									{Namespace: "test", Name: "tr-2"}: createTLSRouteRoute(tr2),
+									{Namespace: "test", Name: "tr-3"}: createTLSRouteRoute(tr3),
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				TLSRoutes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "tr-1"}: createTLSRouteRoute(tr1),
+					{Namespace: "test", Name: "tr-2"}: createTLSRouteRoute(tr2),
+					{Namespace: "test", Name: "tr-3"}: createTLSRouteRoute(tr3),
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers:  []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{
+					{
+						Hostname: "bar.example.com",
+						Port:     8443,
+						Source:   tr2,
+					},
+					{
+						Hostname: "foo.example.com",
+						Port:     8443,
+						Source:   tr3,
+					},
+				},
+				TCPServers: []TCPServer{},
+				UDPServers: []UDPServer{},
+			},
+			msg: "one tls listener with conflicting routes",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-tcp-6379": {
+								Source: listenerTCP6379,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "tcpr-3"}: createTCPRouteRoute(tcpr3, "listener-tcp-6379"),
+								},
+								AcceptedHostnames: map[string]struct{}{},
+							},
+							"listener-tcp-5432": {
+								Source: listenerTCP5432,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "tcpr-1"}: createTCPRouteRoute(tcpr1, "listener-tcp-5432"),
+									{Namespace: "test", Name: "tcpr-2"}: createTCPRouteRoute(tcpr2, "listener-tcp-5432"),
+								},
+								AcceptedHostnames: map[string]struct{}{},
+							},
+						},
+					},
+				},
+				TCPRoutes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "tcpr-1"}: createTCPRouteRoute(tcpr1, "listener-tcp-5432"),
+					{Namespace: "test", Name: "tcpr-2"}: createTCPRouteRoute(tcpr2, "listener-tcp-5432"),
+					{Namespace: "test", Name: "tcpr-3"}: createTCPRouteRoute(tcpr3, "listener-tcp-6379"),
+				},
+			},
+			expected: Configuration{
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers: []TCPServer{
+					{
+						Port:   5432,
+						Source: tcpr2,
+					},
+					{
+						Port:   6379,
+						Source: tcpr3,
+					},
+				},
+				UDPServers: []UDPServer{},
+			},
+			msg: "tcp listeners with conflicting routes",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+						Listeners: map[string]*listener{
+							"listener-udp-53": {
+								Source: listenerUDP53,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "ur-1"}: {
+										Source: ur1,
+										ValidSectionNameRefs: map[ParentRef]struct{}{
+											{Gateway: gwNsName, SectionName: "listener-udp-53"}: {},
+										},
+										InvalidSectionNameRefs: map[ParentRef]struct{}{},
+									},
+								},
+								AcceptedHostnames: map[string]struct{}{},
+							},
+						},
+					},
+				},
+			},
+			expected: Configuration{
+				HTTPServers:           []VirtualServer{},
+				SSLServers:            []VirtualServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				TCPServers:            []TCPServer{},
+				UDPServers: []UDPServer{
+					{
+						Port:   53,
+						Source: ur1,
+					},
+				},
+			},
+			msg: "one udp listener",
+		},
 	}
 
 	for _, test := range tests {
@@ -931,6 +1869,56 @@ func TestMatchRuleGetMatch(t *testing.T) {
 	}
 }
 
+func TestGRPCMatchRuleGetMatch(t *testing.T) {
+	gr := &v1alpha2.GRPCRoute{
+		Spec: v1alpha2.GRPCRouteSpec{
+			Rules: []v1alpha2.GRPCRouteRule{
+				{
+					Matches: []v1alpha2.GRPCRouteMatch{
+						{
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("service-1"),
+							},
+						},
+						{
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("service-2"),
+							},
+						},
+					},
+				},
+				{
+					// no matches
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		expected v1alpha2.GRPCRouteMatch
+		rule     GRPCMatchRule
+		name     string
+	}{
+		{
+			name:     "second match in first rule",
+			expected: gr.Spec.Rules[0].Matches[1],
+			rule:     GRPCMatchRule{MatchIdx: 1, RuleIdx: 0, Source: gr},
+		},
+		{
+			name:     "rule without matches",
+			expected: v1alpha2.GRPCRouteMatch{},
+			rule:     GRPCMatchRule{MatchIdx: 0, RuleIdx: 1, Source: gr},
+		},
+	}
+
+	for _, tc := range tests {
+		actual := tc.rule.GetMatch()
+		if diff := cmp.Diff(tc.expected, actual); diff != "" {
+			t.Errorf("GRPCMatchRule.GetMatch() %q mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
 func TestGetListenerHostname(t *testing.T) {
 	var emptyHostname v1beta1.Hostname
 	var hostname v1beta1.Hostname = "example.com"
@@ -0,0 +1,54 @@
+package state
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// The v1alpha2 routes (like TLSRoute) use their own copies of the common Gateway API types.
+// The functions below convert them to the v1beta1 types, so that all routes can be processed the same way.
+
+func convertParentReferences(refs []v1alpha2.ParentReference) []v1beta1.ParentReference {
+	if refs == nil {
+		return nil
+	}
+
+	result := make([]v1beta1.ParentReference, 0, len(refs))
+
+	for _, ref := range refs {
+		result = append(result, v1beta1.ParentReference{
+			Group:       (*v1beta1.Group)(ref.Group),
+			Kind:        (*v1beta1.Kind)(ref.Kind),
+			Namespace:   (*v1beta1.Namespace)(ref.Namespace),
+			Name:        v1beta1.ObjectName(ref.Name),
+			SectionName: (*v1beta1.SectionName)(ref.SectionName),
+			Port:        (*v1beta1.PortNumber)(ref.Port),
+		})
+	}
+
+	return result
+}
+
+func convertHostnames(hostnames []v1alpha2.Hostname) []v1beta1.Hostname {
+	if hostnames == nil {
+		return nil
+	}
+
+	result := make([]v1beta1.Hostname, 0, len(hostnames))
+
+	for _, h := range hostnames {
+		result = append(result, v1beta1.Hostname(h))
+	}
+
+	return result
+}
+
+func convertBackendObjectReference(ref v1alpha2.BackendObjectReference) v1beta1.BackendObjectReference {
+	return v1beta1.BackendObjectReference{
+		Group:     (*v1beta1.Group)(ref.Group),
+		Kind:      (*v1beta1.Kind)(ref.Kind),
+		Name:      v1beta1.ObjectName(ref.Name),
+		Namespace: (*v1beta1.Namespace)(ref.Namespace),
+		Port:      (*v1beta1.PortNumber)(ref.Port),
+	}
+}
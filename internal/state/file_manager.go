@@ -32,3 +32,11 @@ func (s *stdLibFileManager) Create(name string) (*os.File, error) {
 func (s *stdLibFileManager) Chmod(file *os.File, mode os.FileMode) error {
 	return file.Chmod(mode)
 }
+
+func (s *stdLibFileManager) Close(file *os.File) error {
+	return file.Close()
+}
+
+func (s *stdLibFileManager) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
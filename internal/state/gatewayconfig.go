@@ -0,0 +1,112 @@
+package state
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgw "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
+)
+
+const gatewayConfigKind = "GatewayConfig"
+
+// resolveGatewayConfig resolves the GatewayConfig resource referenced by the parametersRef of the GatewayClass.
+// It returns nil if the GatewayClass doesn't reference any parameters.
+// It returns an error if the reference is invalid or the referenced GatewayConfig doesn't exist.
+func resolveGatewayConfig(
+	gc *v1beta1.GatewayClass,
+	gatewayConfigs map[string]*nginxgwv1alpha1.GatewayConfig,
+) (*nginxgwv1alpha1.GatewayConfig, error) {
+	ref := gc.Spec.ParametersRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	if string(ref.Group) != nginxgw.GroupName {
+		return nil, fmt.Errorf("Spec.ParametersRef.Group must be %s got %s", nginxgw.GroupName, ref.Group)
+	}
+	if string(ref.Kind) != gatewayConfigKind {
+		return nil, fmt.Errorf("Spec.ParametersRef.Kind must be %s got %s", gatewayConfigKind, ref.Kind)
+	}
+	// GatewayConfig is a cluster-scoped resource
+	if ref.Namespace != nil {
+		return nil, fmt.Errorf("Spec.ParametersRef.Namespace must not be set for %s", gatewayConfigKind)
+	}
+
+	gcfg, exist := gatewayConfigs[ref.Name]
+	if !exist {
+		return nil, fmt.Errorf("%s %s referenced in Spec.ParametersRef does not exist", gatewayConfigKind, ref.Name)
+	}
+
+	if err := validateGatewayConfig(gcfg); err != nil {
+		return nil, fmt.Errorf("%s %s referenced in Spec.ParametersRef is invalid: %w", gatewayConfigKind, ref.Name, err)
+	}
+
+	return gcfg, nil
+}
+
+// validateGatewayConfig validates the settings of the GatewayConfig that are not validated by the CRD schema.
+func validateGatewayConfig(gcfg *nginxgwv1alpha1.GatewayConfig) error {
+	if gcfg.Spec.HTTP != nil {
+		return validateAccessLogs(gcfg.Spec.HTTP.AccessLogs)
+	}
+
+	return nil
+}
+
+// referencesGatewayConfig returns true if the parametersRef of the GatewayClass references a GatewayConfig with
+// the name.
+func referencesGatewayConfig(gc *v1beta1.GatewayClass, name string) bool {
+	if gc == nil || gc.Spec.ParametersRef == nil {
+		return false
+	}
+
+	ref := gc.Spec.ParametersRef
+
+	return string(ref.Group) == nginxgw.GroupName && string(ref.Kind) == gatewayConfigKind && ref.Name == name
+}
+
+// buildSettings builds the Settings from the GatewayConfig resource. If the resource is nil, the NGINX defaults
+// are used.
+func buildSettings(gcfg *nginxgwv1alpha1.GatewayConfig) Settings {
+	var settings Settings
+
+	if gcfg == nil {
+		return settings
+	}
+
+	if gcfg.Spec.Worker != nil {
+		settings.WorkerProcesses = gcfg.Spec.Worker.Processes
+	}
+
+	if http := gcfg.Spec.HTTP; http != nil {
+		for _, l := range http.AccessLogs {
+			settings.AccessLogs = append(settings.AccessLogs, AccessLog{
+				Format:      l.Format,
+				Destination: l.Destination,
+			})
+		}
+		if http.ProxyReadTimeout != nil {
+			settings.ProxyReadTimeout = http.ProxyReadTimeout.Duration
+		}
+		if http.ProxySendTimeout != nil {
+			settings.ProxySendTimeout = http.ProxySendTimeout.Duration
+		}
+		if http.HTTP2 != nil {
+			settings.DisableHTTP2 = !*http.HTTP2
+		}
+		if http.ConfigHashHeader != nil {
+			settings.ConfigHashHeader = *http.ConfigHashHeader
+		}
+	}
+
+	if stream := gcfg.Spec.Stream; stream != nil && stream.UDP != nil {
+		settings.UDPProxyResponses = stream.UDP.ProxyResponses
+		if stream.UDP.ProxyTimeout != nil {
+			settings.UDPProxyTimeout = stream.UDP.ProxyTimeout.Duration
+		}
+	}
+
+	return settings
+}
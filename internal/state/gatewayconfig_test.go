@@ -0,0 +1,184 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
+)
+
+func TestResolveGatewayConfig(t *testing.T) {
+	gcfg := &nginxgwv1alpha1.GatewayConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nginx",
+		},
+	}
+
+	invalidGcfg := &nginxgwv1alpha1.GatewayConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "invalid",
+		},
+		Spec: nginxgwv1alpha1.GatewayConfigSpec{
+			HTTP: &nginxgwv1alpha1.HTTP{
+				AccessLogs: []nginxgwv1alpha1.AccessLog{
+					{Format: "json", Destination: "relative/path"},
+				},
+			},
+		},
+	}
+
+	gatewayConfigs := map[string]*nginxgwv1alpha1.GatewayConfig{
+		"nginx":   gcfg,
+		"invalid": invalidGcfg,
+	}
+
+	createGatewayClass := func(ref *v1beta1.ParametersReference) *v1beta1.GatewayClass {
+		return &v1beta1.GatewayClass{
+			Spec: v1beta1.GatewayClassSpec{
+				ControllerName: "test.controller",
+				ParametersRef:  ref,
+			},
+		}
+	}
+
+	createRef := func(group string, kind string, name string) *v1beta1.ParametersReference {
+		return &v1beta1.ParametersReference{
+			Group: v1beta1.Group(group),
+			Kind:  v1beta1.Kind(kind),
+			Name:  name,
+		}
+	}
+
+	refWithNamespace := createRef("gateway.nginx.org", "GatewayConfig", "nginx")
+	refWithNamespace.Namespace = (*v1beta1.Namespace)(helpers.GetStringPointer("test"))
+
+	tests := []struct {
+		gc          *v1beta1.GatewayClass
+		expected    *nginxgwv1alpha1.GatewayConfig
+		expectedErr bool
+		msg         string
+	}{
+		{
+			gc:          createGatewayClass(nil),
+			expected:    nil,
+			expectedErr: false,
+			msg:         "no parametersRef",
+		},
+		{
+			gc:          createGatewayClass(createRef("gateway.nginx.org", "GatewayConfig", "nginx")),
+			expected:    gcfg,
+			expectedErr: false,
+			msg:         "valid parametersRef",
+		},
+		{
+			gc:          createGatewayClass(createRef("example.com", "GatewayConfig", "nginx")),
+			expected:    nil,
+			expectedErr: true,
+			msg:         "invalid group",
+		},
+		{
+			gc:          createGatewayClass(createRef("gateway.nginx.org", "ConfigMap", "nginx")),
+			expected:    nil,
+			expectedErr: true,
+			msg:         "invalid kind",
+		},
+		{
+			gc:          createGatewayClass(refWithNamespace),
+			expected:    nil,
+			expectedErr: true,
+			msg:         "namespace is set",
+		},
+		{
+			gc:          createGatewayClass(createRef("gateway.nginx.org", "GatewayConfig", "not-found")),
+			expected:    nil,
+			expectedErr: true,
+			msg:         "gatewayconfig doesn't exist",
+		},
+		{
+			gc:          createGatewayClass(createRef("gateway.nginx.org", "GatewayConfig", "invalid")),
+			expected:    nil,
+			expectedErr: true,
+			msg:         "gatewayconfig is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := resolveGatewayConfig(test.gc, gatewayConfigs)
+
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("resolveGatewayConfig() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if test.expectedErr && err == nil {
+			t.Errorf("resolveGatewayConfig() %q didn't return an error", test.msg)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("resolveGatewayConfig() %q returned unexpected error %v", test.msg, err)
+		}
+	}
+}
+
+func TestBuildSettings(t *testing.T) {
+	tests := []struct {
+		gcfg     *nginxgwv1alpha1.GatewayConfig
+		expected Settings
+		msg      string
+	}{
+		{
+			gcfg:     nil,
+			expected: Settings{},
+			msg:      "no gatewayconfig",
+		},
+		{
+			gcfg:     &nginxgwv1alpha1.GatewayConfig{},
+			expected: Settings{},
+			msg:      "empty gatewayconfig",
+		},
+		{
+			gcfg: &nginxgwv1alpha1.GatewayConfig{
+				Spec: nginxgwv1alpha1.GatewayConfigSpec{
+					Worker: &nginxgwv1alpha1.Worker{
+						Processes: helpers.GetIntPointer(2),
+					},
+					HTTP: &nginxgwv1alpha1.HTTP{
+						AccessLogs: []nginxgwv1alpha1.AccessLog{
+							{Format: "json", Destination: "stdout"},
+						},
+						ProxyReadTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+						ProxySendTimeout: &metav1.Duration{Duration: 30 * time.Second},
+						HTTP2:            helpers.GetBoolPointer(false),
+						ConfigHashHeader: helpers.GetBoolPointer(true),
+					},
+					Stream: &nginxgwv1alpha1.Stream{
+						UDP: &nginxgwv1alpha1.UDP{
+							ProxyResponses: helpers.GetInt32Pointer(1),
+							ProxyTimeout:   &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+			expected: Settings{
+				WorkerProcesses:   helpers.GetIntPointer(2),
+				AccessLogs:        []AccessLog{{Format: "json", Destination: "stdout"}},
+				ProxyReadTimeout:  5 * time.Minute,
+				ProxySendTimeout:  30 * time.Second,
+				DisableHTTP2:      true,
+				ConfigHashHeader:  true,
+				UDPProxyResponses: helpers.GetInt32Pointer(1),
+				UDPProxyTimeout:   time.Minute,
+			},
+			msg: "full gatewayconfig",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildSettings(test.gcfg)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildSettings() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
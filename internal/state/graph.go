@@ -5,29 +5,40 @@ import (
 	"sort"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
-// gateway represents the winning Gateway resource.
+// gateway represents a Gateway resource that belongs to the GatewayClass of the NGINX Gateway.
 type gateway struct {
 	// Source is the corresponding Gateway resource.
 	Source *v1beta1.Gateway
 	// Listeners include the listeners of the Gateway.
 	Listeners map[string]*listener
+	// InvalidAddresses describes the addresses from the spec of the Gateway that can't be honoured.
+	// If there is at least one such address, all listeners of the Gateway are invalid.
+	InvalidAddresses []string
 }
 
-// route represents an HTTPRoute.
+// route represents an HTTPRoute, a GRPCRoute, a TLSRoute, a TCPRoute or a UDPRoute.
 type route struct {
 	// Source is the source resource of the route.
-	// FIXME(pleshakov)
-	// For now, we assume that the source is only HTTPRoute. Later we can support more types - TLSRoute, TCPRoute and UDPRoute.
-	Source *v1beta1.HTTPRoute
+	// It is either *v1beta1.HTTPRoute, *v1alpha2.GRPCRoute, *v1alpha2.TLSRoute, *v1alpha2.TCPRoute or
+	// *v1alpha2.UDPRoute.
+	Source client.Object
 
-	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the HTTPRoute that are valid -- i.e.
+	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the route that are valid -- i.e.
 	// the Gateway resource has a corresponding valid listener.
-	ValidSectionNameRefs map[string]struct{}
-	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the HTTPRoute that are invalid.
-	InvalidSectionNameRefs map[string]struct{}
+	ValidSectionNameRefs map[ParentRef]struct{}
+	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the route that are invalid.
+	InvalidSectionNameRefs map[ParentRef]struct{}
+	// ConflictedSectionNameRefs includes the sectionNames from the parentRefs of the route that reference a valid
+	// listener, which another route is attached to instead (see detachConflictedRoutes).
+	// The value explains the conflict.
+	ConflictedSectionNameRefs map[ParentRef]string
 }
 
 // gatewayClass represents the GatewayClass resource.
@@ -38,63 +49,117 @@ type gatewayClass struct {
 	Valid bool
 	// ErrorMsg explains the error when the resource is not valid.
 	ErrorMsg string
+	// InvalidParameters shows whether the GatewayClass is not valid because of its parametersRef.
+	InvalidParameters bool
+	// Config holds the GatewayConfig resource referenced by the parametersRef of the GatewayClass.
+	// It is nil if the GatewayClass doesn't reference any parameters or is not valid.
+	Config *nginxgwv1alpha1.GatewayConfig
 }
 
 // graph is a graph-like representation of Gateway API resources.
 type graph struct {
 	// GatewayClass holds the GatewayClass resource.
 	GatewayClass *gatewayClass
-	// Gateway holds the winning Gateway resource.
-	Gateway *gateway
-	// IgnoredGateways holds the ignored Gateway resources, which belong to the NGINX Gateway (based on the
-	// GatewayClassName field of the resource) but ignored. It doesn't hold the Gateway resources that do not belong to
-	// the NGINX Gateway.
-	IgnoredGateways map[types.NamespacedName]*v1beta1.Gateway
-	// Routes holds route resources.
+	// Addresses holds the addresses of the NGINX data plane.
+	Addresses []v1beta1.GatewayAddress
+	// Gateways holds the Gateway resources that belong to the NGINX Gateway (based on the GatewayClassName field
+	// of the resource). It doesn't hold the Gateway resources that do not belong to the NGINX Gateway.
+	Gateways map[types.NamespacedName]*gateway
+	// Routes holds HTTPRoute resources.
 	Routes map[types.NamespacedName]*route
+	// GRPCRoutes holds GRPCRoute resources.
+	GRPCRoutes map[types.NamespacedName]*route
+	// TLSRoutes holds TLSRoute resources.
+	TLSRoutes map[types.NamespacedName]*route
+	// TCPRoutes holds TCPRoute resources.
+	TCPRoutes map[types.NamespacedName]*route
+	// UDPRoutes holds UDPRoute resources.
+	UDPRoutes map[types.NamespacedName]*route
 }
 
-// buildGraph builds a graph from a store assuming that the Gateway resource has the gwNsName namespace and name.
+// buildGraph builds a graph from a store.
 func buildGraph(
 	store *store,
 	controllerName string,
 	gcName string,
+	staticAddresses []v1beta1.GatewayAddress,
 	secretMemoryMgr SecretDiskMemoryManager,
 ) *graph {
-	gc := buildGatewayClass(store.gc, controllerName)
+	gc := buildGatewayClass(store.gc, controllerName, store.gatewayConfigs)
 
-	gw, ignoredGws := processGateways(store.gateways, gcName)
+	addresses := buildAddresses(store.dataPlaneService, staticAddresses)
 
-	listeners := buildListeners(gw, gcName, secretMemoryMgr)
+	gws := buildGateways(store.gateways, gcName, addresses, secretMemoryMgr)
 
 	routes := make(map[types.NamespacedName]*route)
 	for _, ghr := range store.httpRoutes {
-		ignored, r := bindHTTPRouteToListeners(ghr, gw, ignoredGws, listeners)
+		ignored, r := bindHTTPRouteToListeners(ghr, gws)
 		if !ignored {
 			routes[getNamespacedName(ghr)] = r
 		}
 	}
 
-	g := &graph{
-		GatewayClass:    gc,
-		Routes:          routes,
-		IgnoredGateways: ignoredGws,
+	// gRPC requires HTTP/2, so GRPCRoutes can't attach to any listener when HTTP/2 is disabled
+	http2Enabled := gc != nil && !buildSettings(gc.Config).DisableHTTP2
+
+	grpcRoutes := make(map[types.NamespacedName]*route)
+	for _, gr := range store.grpcRoutes {
+		ignored, r := bindGRPCRouteToListeners(gr, gws, http2Enabled)
+		if !ignored {
+			grpcRoutes[getNamespacedName(gr)] = r
+		}
 	}
 
-	if gw != nil {
-		g.Gateway = &gateway{
-			Source:    gw,
-			Listeners: listeners,
+	tlsRoutes := make(map[types.NamespacedName]*route)
+	for _, tr := range store.tlsRoutes {
+		ignored, r := bindTLSRouteToListeners(tr, gws)
+		if !ignored {
+			tlsRoutes[getNamespacedName(tr)] = r
+		}
+	}
+
+	tcpRoutes := make(map[types.NamespacedName]*route)
+	for _, tr := range store.tcpRoutes {
+		ignored, r := bindTCPRouteToListeners(tr, gws)
+		if !ignored {
+			tcpRoutes[getNamespacedName(tr)] = r
 		}
 	}
 
-	return g
+	udpRoutes := make(map[types.NamespacedName]*route)
+	for _, ur := range store.udpRoutes {
+		ignored, r := bindUDPRouteToListeners(ur, gws)
+		if !ignored {
+			udpRoutes[getNamespacedName(ur)] = r
+		}
+	}
+
+	detachConflictedRoutes(gws)
+
+	return &graph{
+		GatewayClass: gc,
+		Addresses:    addresses,
+		Gateways:     gws,
+		Routes:       routes,
+		GRPCRoutes:   grpcRoutes,
+		TLSRoutes:    tlsRoutes,
+		TCPRoutes:    tcpRoutes,
+		UDPRoutes:    udpRoutes,
+	}
 }
 
-// processGateways determines which Gateway resource the NGINX Gateway will use (the winner) and which Gateway(s) will
-// be ignored. Note that the function will not take into the account any unrelated Gateway resources - the ones with the
-// different GatewayClassName field.
-func processGateways(gws map[types.NamespacedName]*v1beta1.Gateway, gcName string) (winner *v1beta1.Gateway, ignoredGateways map[types.NamespacedName]*v1beta1.Gateway) {
+// buildGateways builds the Gateway resources that belong to the NGINX Gateway. Note that the function will not
+// take into the account any unrelated Gateway resources - the ones with the different GatewayClassName field.
+// All Gateways are served by the same NGINX, so the listeners of different Gateways conflict the same way
+// the listeners of a single Gateway do. The Gateways are processed from the oldest to the newest.
+// The listeners of a Gateway with addresses that can't be honoured are invalid and don't conflict with
+// the listeners of the other Gateways.
+func buildGateways(
+	gws map[types.NamespacedName]*v1beta1.Gateway,
+	gcName string,
+	addresses []v1beta1.GatewayAddress,
+	secretMemoryMgr SecretDiskMemoryManager,
+) map[types.NamespacedName]*gateway {
 	referencedGws := make([]*v1beta1.Gateway, 0, len(gws))
 
 	for _, gw := range gws {
@@ -106,53 +171,105 @@ func processGateways(gws map[types.NamespacedName]*v1beta1.Gateway, gcName strin
 	}
 
 	if len(referencedGws) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	sort.Slice(referencedGws, func(i, j int) bool {
 		return lessObjectMeta(&referencedGws[i].ObjectMeta, &referencedGws[j].ObjectMeta)
 	})
 
-	ignoredGws := make(map[types.NamespacedName]*v1beta1.Gateway)
+	listenerFactory := newListenerConfiguratorFactory(secretMemoryMgr)
+	portResolver := newPortConflictResolver()
 
-	for _, gw := range referencedGws[1:] {
-		ignoredGws[getNamespacedName(gw)] = gw
+	result := make(map[types.NamespacedName]*gateway, len(referencedGws))
+
+	for _, gw := range referencedGws {
+		invalidAddresses := validateGatewayAddresses(gw, addresses)
+
+		var listeners map[string]*listener
+		if len(invalidAddresses) > 0 {
+			listeners = buildInvalidListeners(gw)
+		} else {
+			listeners = buildListeners(gw, listenerFactory, portResolver)
+		}
+
+		result[getNamespacedName(gw)] = &gateway{
+			Source:           gw,
+			Listeners:        listeners,
+			InvalidAddresses: invalidAddresses,
+		}
 	}
 
-	return referencedGws[0], ignoredGws
+	return result
 }
 
-func buildGatewayClass(gc *v1beta1.GatewayClass, controllerName string) *gatewayClass {
+func buildGatewayClass(
+	gc *v1beta1.GatewayClass,
+	controllerName string,
+	gatewayConfigs map[string]*nginxgwv1alpha1.GatewayConfig,
+) *gatewayClass {
 	if gc == nil {
 		return nil
 	}
 
-	var errorMsg string
-
 	err := validateGatewayClass(gc, controllerName)
 	if err != nil {
-		errorMsg = err.Error()
+		return &gatewayClass{
+			Source:   gc,
+			Valid:    false,
+			ErrorMsg: err.Error(),
+		}
+	}
+
+	gcfg, err := resolveGatewayConfig(gc, gatewayConfigs)
+	if err != nil {
+		return &gatewayClass{
+			Source:            gc,
+			Valid:             false,
+			ErrorMsg:          err.Error(),
+			InvalidParameters: true,
+		}
 	}
 
 	return &gatewayClass{
-		Source:   gc,
-		Valid:    err == nil,
-		ErrorMsg: errorMsg,
+		Source: gc,
+		Valid:  true,
+		Config: gcfg,
 	}
 }
 
-func buildListeners(gw *v1beta1.Gateway, gcName string, secretMemoryMgr SecretDiskMemoryManager) map[string]*listener {
+// buildListeners builds the listeners of the Gateway. The listenerFactory and the portResolver are shared by
+// all Gateways, so that the conflicts between the listeners of different Gateways are detected.
+func buildListeners(
+	gw *v1beta1.Gateway,
+	listenerFactory *listenerConfiguratorFactory,
+	portResolver *portConflictResolver,
+) map[string]*listener {
 	listeners := make(map[string]*listener)
 
-	if gw == nil || string(gw.Spec.GatewayClassName) != gcName {
-		return listeners
+	for _, gl := range gw.Spec.Listeners {
+		configurator := listenerFactory.getConfiguratorForListener(gl)
+		l := configurator.configure(gw, gl)
+
+		portResolver.resolve(l)
+
+		listeners[string(gl.Name)] = l
 	}
 
-	listenerFactory := newListenerConfiguratorFactory(gw, secretMemoryMgr)
+	return listeners
+}
+
+// buildInvalidListeners builds the listeners of a rejected Gateway. All listeners are invalid.
+func buildInvalidListeners(gw *v1beta1.Gateway) map[string]*listener {
+	listeners := make(map[string]*listener)
 
 	for _, gl := range gw.Spec.Listeners {
-		configurator := listenerFactory.getConfiguratorForListener(gl)
-		listeners[string(gl.Name)] = configurator.configure(gl)
+		listeners[string(gl.Name)] = &listener{
+			Source:            gl,
+			Valid:             false,
+			Routes:            make(map[types.NamespacedName]*route),
+			AcceptedHostnames: make(map[string]struct{}),
+		}
 	}
 
 	return listeners
@@ -165,102 +282,361 @@ func buildListeners(gw *v1beta1.Gateway, gcName string, secretMemoryMgr SecretDi
 // (3) HTTPRoute will be processed and bound to a listener.
 func bindHTTPRouteToListeners(
 	ghr *v1beta1.HTTPRoute,
-	gw *v1beta1.Gateway,
-	ignoredGws map[types.NamespacedName]*v1beta1.Gateway,
-	listeners map[string]*listener,
+	gws map[types.NamespacedName]*gateway,
 ) (ignored bool, r *route) {
-	if len(ghr.Spec.ParentRefs) == 0 {
-		// ignore HTTPRoute without refs
+	return bindRouteToListeners(ghr, ghr.Spec.ParentRefs, ghr.Spec.Hostnames, gws)
+}
+
+// bindGRPCRouteToListeners tries to bind a GRPCRoute to listener.
+// The possibilities are the same as for bindHTTPRouteToListeners. If HTTP/2 is not enabled, the GRPCRoute is
+// processed but not bound.
+func bindGRPCRouteToListeners(
+	gr *v1alpha2.GRPCRoute,
+	gws map[types.NamespacedName]*gateway,
+	http2Enabled bool,
+) (ignored bool, r *route) {
+	ignored, r = bindRouteToListeners(gr, gr.Spec.ParentRefs, gr.Spec.Hostnames, gws)
+	if ignored || http2Enabled {
+		return ignored, r
+	}
+
+	for ref := range r.ValidSectionNameRefs {
+		delete(gws[ref.Gateway].Listeners[ref.SectionName].Routes, getNamespacedName(gr))
+		r.InvalidSectionNameRefs[ref] = struct{}{}
+	}
+	r.ValidSectionNameRefs = make(map[ParentRef]struct{})
+
+	return false, r
+}
+
+// bindTLSRouteToListeners tries to bind a TLSRoute to listener.
+// The possibilities are the same as for bindHTTPRouteToListeners.
+func bindTLSRouteToListeners(
+	tr *v1alpha2.TLSRoute,
+	gws map[types.NamespacedName]*gateway,
+) (ignored bool, r *route) {
+	return bindRouteToListeners(
+		tr,
+		convertParentReferences(tr.Spec.ParentRefs),
+		convertHostnames(tr.Spec.Hostnames),
+		gws,
+	)
+}
+
+// bindTCPRouteToListeners tries to bind a TCPRoute to listener.
+// The possibilities are the same as for bindHTTPRouteToListeners.
+func bindTCPRouteToListeners(
+	tr *v1alpha2.TCPRoute,
+	gws map[types.NamespacedName]*gateway,
+) (ignored bool, r *route) {
+	return bindRouteToListeners(tr, convertParentReferences(tr.Spec.ParentRefs), nil, gws)
+}
+
+// bindUDPRouteToListeners tries to bind a UDPRoute to listener.
+// The possibilities are the same as for bindHTTPRouteToListeners.
+func bindUDPRouteToListeners(
+	ur *v1alpha2.UDPRoute,
+	gws map[types.NamespacedName]*gateway,
+) (ignored bool, r *route) {
+	return bindRouteToListeners(ur, convertParentReferences(ur.Spec.ParentRefs), nil, gws)
+}
+
+// bindRouteToListeners binds a route with the parentRefs and hostnames to listeners.
+// A route can only be bound to the listeners with the protocol that supports the kind of the route.
+func bindRouteToListeners(
+	obj client.Object,
+	parentRefs []v1beta1.ParentReference,
+	hostnames []v1beta1.Hostname,
+	gws map[types.NamespacedName]*gateway,
+) (ignored bool, r *route) {
+	if len(parentRefs) == 0 {
+		// ignore route without refs
 		return true, nil
 	}
 
 	r = &route{
-		Source:                 ghr,
-		ValidSectionNameRefs:   make(map[string]struct{}),
-		InvalidSectionNameRefs: make(map[string]struct{}),
+		Source:                 obj,
+		ValidSectionNameRefs:   make(map[ParentRef]struct{}),
+		InvalidSectionNameRefs: make(map[ParentRef]struct{}),
 	}
 
 	// FIXME (pleshakov) Handle the case when parent refs are duplicated
 
 	processed := false
 
-	for _, p := range ghr.Spec.ParentRefs {
+	for _, p := range parentRefs {
 		// FIXME(pleshakov) Support empty section name
 		if p.SectionName == nil || *p.SectionName == "" {
 			continue
 		}
 
-		// if the namespace is missing, assume the namespace of the HTTPRoute
-		ns := ghr.Namespace
+		// if the namespace is missing, assume the namespace of the route
+		ns := obj.GetNamespace()
 		if p.Namespace != nil {
 			ns = string(*p.Namespace)
 		}
 
 		name := string(*p.SectionName)
 
-		// Below we will figure out what Gateway resource the parentRef references and act accordingly. There are 3 cases.
+		// Below we will figure out what Gateway resource the parentRef references and act accordingly. There are 2 cases.
 
-		// Case 1: the parentRef references the winning Gateway.
+		// Case 1: the parentRef references a Gateway of the NGINX Gateway.
 
-		if gw != nil && gw.Namespace == ns && gw.Name == string(p.Name) {
+		gwNsName := types.NamespacedName{Namespace: ns, Name: string(p.Name)}
+
+		gw, exists := gws[gwNsName]
+		if !exists {
+			// Case 2: the parentRef references some unrelated to this NGINX Gateway Gateway or other resource.
+
+			// Do nothing
+			continue
+		}
 
-			// Find a listener
+		// Find a listener
 
-			// FIXME(pleshakov)
-			// For now, let's do simple matching.
-			// However, we need to also support wildcard matching.
-			// More over, we need to handle cases when a Route host matches multiple HTTP listeners on the same port when
-			// sectionName is empty and only choose one listener.
-			// For example:
-			// - Route with host foo.example.com;
-			// - listener 1 for port 80 with hostname foo.example.com
-			// - listener 2 for port 80 with hostname *.example.com;
-			// In this case, the Route host foo.example.com should choose listener 1, as it is a more specific match.
+		// FIXME(pleshakov)
+		// For now, let's do simple matching.
+		// However, we need to also support wildcard matching.
+		// More over, we need to handle cases when a Route host matches multiple HTTP listeners on the same port when
+		// sectionName is empty and only choose one listener.
+		// For example:
+		// - Route with host foo.example.com;
+		// - listener 1 for port 80 with hostname foo.example.com
+		// - listener 2 for port 80 with hostname *.example.com;
+		// In this case, the Route host foo.example.com should choose listener 1, as it is a more specific match.
 
-			processed = true
+		processed = true
 
-			l, exists := listeners[name]
-			if !exists {
-				r.InvalidSectionNameRefs[name] = struct{}{}
+		ref := ParentRef{Gateway: gwNsName, SectionName: name}
+
+		l, exists := gw.Listeners[name]
+		if !exists {
+			r.InvalidSectionNameRefs[ref] = struct{}{}
+			continue
+		}
+
+		if !isRouteKindAllowed(l.Source.Protocol, obj) {
+			r.InvalidSectionNameRefs[ref] = struct{}{}
+			continue
+		}
+
+		if !isRouteKindWithHostnames(obj) {
+			r.ValidSectionNameRefs[ref] = struct{}{}
+			l.Routes[getNamespacedName(obj)] = r
+			continue
+		}
+
+		accepted := findAcceptedHostnames(l.Source.Hostname, hostnames)
+
+		if len(accepted) > 0 {
+			for _, h := range accepted {
+				l.AcceptedHostnames[h] = struct{}{}
+			}
+			r.ValidSectionNameRefs[ref] = struct{}{}
+			l.Routes[getNamespacedName(obj)] = r
+		} else {
+			r.InvalidSectionNameRefs[ref] = struct{}{}
+		}
+	}
+
+	if !processed {
+		return true, nil
+	}
+
+	return false, r
+}
+
+// detachConflictedRoutes detaches the routes that conflict with other routes attached to the same listener.
+// NGINX proxies all connections on the port of a TCP listener and all datagrams on the port of a UDP listener
+// to the backends of a single route, so only the oldest route stays attached to such listener.
+// HTTPRoutes and GRPCRoutes can't share a hostname of an HTTPS listener, so a route stays attached to such
+// listener only if none of its hostnames is used by an older route of the other kind.
+func detachConflictedRoutes(gws map[types.NamespacedName]*gateway) {
+	for gwNsName, gw := range gws {
+		for name, l := range gw.Listeners {
+			if len(l.Routes) < 2 {
 				continue
 			}
 
-			accepted := findAcceptedHostnames(l.Source.Hostname, ghr.Spec.Hostnames)
+			ref := ParentRef{Gateway: gwNsName, SectionName: name}
+
+			switch l.Source.Protocol {
+			case v1beta1.TCPProtocolType, v1beta1.UDPProtocolType:
+				detachAllButOldestRoute(ref, l)
+			case v1beta1.HTTPSProtocolType:
+				detachRoutesWithHostnamesOfOtherKind(ref, l)
+			}
+		}
+	}
+}
+
+func detachAllButOldestRoute(ref ParentRef, l *listener) {
+	routes := sortRoutes(l.Routes)
+	winner := routes[0].Source
+
+	for _, r := range routes[1:] {
+		detachRoute(ref, l, r, fmt.Sprintf(
+			"%s listener is already used by %s %s/%s: only one route can be attached to it",
+			l.Source.Protocol,
+			getRouteKind(winner),
+			winner.GetNamespace(),
+			winner.GetName(),
+		))
+	}
+}
+
+func detachRoutesWithHostnamesOfOtherKind(ref ParentRef, l *listener) {
+	attached := make([]*route, 0, len(l.Routes))
 
-			if len(accepted) > 0 {
-				for _, h := range accepted {
-					l.AcceptedHostnames[h] = struct{}{}
-				}
-				r.ValidSectionNameRefs[name] = struct{}{}
-				l.Routes[getNamespacedName(ghr)] = r
-			} else {
-				r.InvalidSectionNameRefs[name] = struct{}{}
+	for _, r := range sortRoutes(l.Routes) {
+		var winner client.Object
+
+		for _, a := range attached {
+			if getRouteKind(a.Source) != getRouteKind(r.Source) && shareHostnames(l, a.Source, r.Source) {
+				winner = a.Source
+				break
 			}
+		}
 
+		if winner == nil {
+			attached = append(attached, r)
 			continue
 		}
 
-		// Case 2: the parentRef references an ignored Gateway resource.
+		detachRoute(ref, l, r, fmt.Sprintf(
+			"%s listener is already used by %s %s/%s for the same hostname: "+
+				"HTTPRoutes and GRPCRoutes can't share a hostname",
+			l.Source.Protocol,
+			getRouteKind(winner),
+			winner.GetNamespace(),
+			winner.GetName(),
+		))
+	}
+}
+
+// sortRoutes returns the routes sorted from the oldest to the newest.
+func sortRoutes(routes map[types.NamespacedName]*route) []*route {
+	result := make([]*route, 0, len(routes))
+	for _, r := range routes {
+		result = append(result, r)
+	}
 
-		key := types.NamespacedName{Namespace: ns, Name: string(p.Name)}
+	sort.Slice(result, func(i, j int) bool {
+		return lessObject(result[i].Source, result[j].Source)
+	})
 
-		if _, exist := ignoredGws[key]; exist {
-			r.InvalidSectionNameRefs[name] = struct{}{}
+	return result
+}
 
-			processed = true
-			continue
+func detachRoute(ref ParentRef, l *listener, r *route, conflictMsg string) {
+	delete(l.Routes, getNamespacedName(r.Source))
+	delete(r.ValidSectionNameRefs, ref)
+
+	if r.ConflictedSectionNameRefs == nil {
+		r.ConflictedSectionNameRefs = make(map[ParentRef]string)
+	}
+	r.ConflictedSectionNameRefs[ref] = conflictMsg
+}
+
+// shareHostnames checks if the routes have a common hostname accepted by the listener.
+func shareHostnames(l *listener, obj1 client.Object, obj2 client.Object) bool {
+	hostnames := make(map[string]struct{})
+	for _, h := range findAcceptedHostnames(l.Source.Hostname, getRouteHostnames(obj1)) {
+		hostnames[h] = struct{}{}
+	}
+
+	for _, h := range findAcceptedHostnames(l.Source.Hostname, getRouteHostnames(obj2)) {
+		if _, exist := hostnames[h]; exist {
+			return true
 		}
+	}
 
-		// Case 3: the parentRef references some unrelated to this NGINX Gateway Gateway or other resource.
+	return false
+}
 
-		// Do nothing
+// getRouteHostnames returns the hostnames of an HTTPRoute or a GRPCRoute.
+func getRouteHostnames(obj client.Object) []v1beta1.Hostname {
+	switch r := obj.(type) {
+	case *v1beta1.HTTPRoute:
+		return r.Spec.Hostnames
+	case *v1alpha2.GRPCRoute:
+		return r.Spec.Hostnames
+	default:
+		return nil
 	}
+}
 
-	if !processed {
-		return true, nil
+// getRouteKind returns the kind of a route.
+func getRouteKind(obj client.Object) v1beta1.Kind {
+	switch obj.(type) {
+	case *v1beta1.HTTPRoute:
+		return "HTTPRoute"
+	case *v1alpha2.GRPCRoute:
+		return "GRPCRoute"
+	case *v1alpha2.TLSRoute:
+		return "TLSRoute"
+	case *v1alpha2.TCPRoute:
+		return "TCPRoute"
+	case *v1alpha2.UDPRoute:
+		return "UDPRoute"
+	default:
+		panic(fmt.Errorf("unknown route type %T", obj))
 	}
+}
 
-	return false, r
+// isRouteKindAllowed checks if a listener with the protocol allows routes of the kind of obj.
+// HTTP and HTTPS listeners allow HTTPRoutes. HTTPS listeners also allow GRPCRoutes, because NGINX supports HTTP/2,
+// which gRPC requires, only with TLS. TLS listeners allow TLSRoutes. TCP listeners allow TCPRoutes.
+// UDP listeners allow UDPRoutes.
+func isRouteKindAllowed(protocol v1beta1.ProtocolType, obj client.Object) bool {
+	switch obj.(type) {
+	case *v1beta1.HTTPRoute:
+		return protocol == v1beta1.HTTPProtocolType || protocol == v1beta1.HTTPSProtocolType
+	case *v1alpha2.GRPCRoute:
+		return protocol == v1beta1.HTTPSProtocolType
+	case *v1alpha2.TLSRoute:
+		return protocol == v1beta1.TLSProtocolType
+	case *v1alpha2.TCPRoute:
+		return protocol == v1beta1.TCPProtocolType
+	case *v1alpha2.UDPRoute:
+		return protocol == v1beta1.UDPProtocolType
+	default:
+		return false
+	}
+}
+
+// getSupportedKinds returns the kinds of routes that can attach to a listener with the protocol.
+// It follows isRouteKindAllowed.
+func getSupportedKinds(protocol v1beta1.ProtocolType) []v1beta1.RouteGroupKind {
+	var kind v1beta1.Kind
+
+	switch protocol {
+	case v1beta1.HTTPProtocolType:
+		kind = "HTTPRoute"
+	case v1beta1.HTTPSProtocolType:
+		return []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}}
+	case v1beta1.TLSProtocolType:
+		kind = "TLSRoute"
+	case v1beta1.TCPProtocolType:
+		kind = "TCPRoute"
+	case v1beta1.UDPProtocolType:
+		kind = "UDPRoute"
+	default:
+		return nil
+	}
+
+	return []v1beta1.RouteGroupKind{{Kind: kind}}
+}
+
+// isRouteKindWithHostnames checks if routes of the kind of obj are matched against the hostname of a listener.
+// TCPRoutes and UDPRoutes don't have hostnames, so they can bind to a listener regardless of its hostname.
+func isRouteKindWithHostnames(obj client.Object) bool {
+	switch obj.(type) {
+	case *v1alpha2.TCPRoute, *v1alpha2.UDPRoute:
+		return false
+	default:
+		return true
+	}
 }
 
 func findAcceptedHostnames(listenerHostname *v1beta1.Hostname, routeHostnames []v1beta1.Hostname) []string {
@@ -1,19 +1,32 @@
 package state
 
 import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // listener represents a listener of the Gateway resource.
-// FIXME(pleshakov) For now, we only support HTTP and HTTPS listeners.
+// FIXME(pleshakov) For now, we only support HTTP, HTTPS, TLS (passthrough), TCP and UDP listeners.
 type listener struct {
 	// Source holds the source of the listener from the Gateway resource.
 	Source v1beta1.Listener
 	// Valid shows whether the listener is valid.
 	Valid bool
-	// SecretPath is the path to the secret on disk.
-	SecretPath string
+	// Certificates are the paths to the certificate and key files of the valid certificateRefs on disk.
+	Certificates []CertificateFiles
+	// InvalidCertificateRefs describes why the invalid certificateRefs of the listener, as well as its CA bundle for
+	// verifying client certificates, are invalid.
+	// A listener with at least one valid certificateRef and a valid CA bundle (if any) is still valid.
+	InvalidCertificateRefs []string
+	// CertificateWarnings describes the problems with the valid certificates of the listener that don't make
+	// the listener invalid. For example, a certificate that doesn't cover the hostname of the listener.
+	CertificateWarnings []string
+	// TLSOptions holds the TLS options of the listener.
+	TLSOptions TLSOptions
 	// Routes holds the routes attached to the listener.
 	Routes map[types.NamespacedName]*route
 	// AcceptedHostnames is an intersection between the hostnames supported by the listener and the hostnames
@@ -21,13 +34,31 @@ type listener struct {
 	AcceptedHostnames map[string]struct{}
 }
 
+// listenerConfigurator configures the listeners of a protocol. A configurator is shared by all Gateways, so that
+// it detects the conflicts between the listeners of different Gateways.
 type listenerConfigurator interface {
-	configure(listener v1beta1.Listener) *listener
+	configure(gw *v1beta1.Gateway, listener v1beta1.Listener) *listener
 }
 
 type listenerConfiguratorFactory struct {
 	https *httpsListenerConfigurator
 	http  *httpListenerConfigurator
+	tls   *tlsPassthroughListenerConfigurator
+	tcp   *tcpListenerConfigurator
+	udp   *udpListenerConfigurator
+}
+
+// hostnameKey identifies a hostname of a listener. Listeners on different ports can use the same hostname.
+type hostnameKey struct {
+	hostname string
+	port     v1beta1.PortNumber
+}
+
+func newHostnameKey(l v1beta1.Listener) hostnameKey {
+	return hostnameKey{
+		hostname: getHostname(l.Hostname),
+		port:     l.Port,
+	}
 }
 
 func (f *listenerConfiguratorFactory) getConfiguratorForListener(l v1beta1.Listener) listenerConfigurator {
@@ -36,51 +67,92 @@ func (f *listenerConfiguratorFactory) getConfiguratorForListener(l v1beta1.Liste
 		return f.http
 	case v1beta1.HTTPSProtocolType:
 		return f.https
+	case v1beta1.TLSProtocolType:
+		return f.tls
+	case v1beta1.TCPProtocolType:
+		return f.tcp
+	case v1beta1.UDPProtocolType:
+		return f.udp
 	default:
 		return newInvalidProtocolListenerConfigurator()
 	}
 }
 
-func newListenerConfiguratorFactory(gw *v1beta1.Gateway, secretMemoryMgr SecretDiskMemoryManager) *listenerConfiguratorFactory {
+func newListenerConfiguratorFactory(secretMemoryMgr SecretDiskMemoryManager) *listenerConfiguratorFactory {
 	return &listenerConfiguratorFactory{
-		https: newHTTPSListenerConfigurator(gw, secretMemoryMgr),
+		https: newHTTPSListenerConfigurator(secretMemoryMgr),
 		http:  newHTTPListenerConfigurator(),
+		tls:   newTLSPassthroughListenerConfigurator(),
+		tcp:   newTCPListenerConfigurator(),
+		udp:   newUDPListenerConfigurator(),
 	}
 }
 
 type httpsListenerConfigurator struct {
-	gateway         *v1beta1.Gateway
 	secretMemoryMgr SecretDiskMemoryManager
-	usedHostnames   map[string]*listener
+	usedHostnames   map[hostnameKey]*listener
 }
 
-func newHTTPSListenerConfigurator(gateway *v1beta1.Gateway, secretMemoryMgr SecretDiskMemoryManager) *httpsListenerConfigurator {
+func newHTTPSListenerConfigurator(secretMemoryMgr SecretDiskMemoryManager) *httpsListenerConfigurator {
 	return &httpsListenerConfigurator{
-		gateway:         gateway,
 		secretMemoryMgr: secretMemoryMgr,
-		usedHostnames:   make(map[string]*listener),
+		usedHostnames:   make(map[hostnameKey]*listener),
 	}
 }
 
-func (c *httpsListenerConfigurator) configure(gl v1beta1.Listener) *listener {
-	var path string
-	var err error
+func (c *httpsListenerConfigurator) configure(gw *v1beta1.Gateway, gl v1beta1.Listener) *listener {
+	var certs []CertificateFiles
+	var invalidRefs []string
+	var warnings []string
+	var opts TLSOptions
 
-	valid := validateHTTPSListener(gl, c.gateway.Namespace)
+	valid := validateHTTPSListener(gl)
 
 	if valid {
+		var err error
+
+		opts, err = parseTLSOptions(gl.TLS.Options)
+		valid = err == nil
+	}
+
+	if valid {
+		// every valid certificate is served, so that, for example, RSA and ECDSA certificates can be used together
+		for i, ref := range gl.TLS.CertificateRefs {
+			files, cert, err := c.requestCertificate(gw.Namespace, ref)
+			if err != nil {
+				invalidRefs = append(invalidRefs, fmt.Sprintf("certificateRefs[%d] %s: %v", i, ref.Name, err))
+				continue
+			}
+
+			if gl.Hostname != nil && cert != nil {
+				if err := cert.VerifyHostname(string(*gl.Hostname)); err != nil {
+					warnings = append(warnings, fmt.Sprintf("certificateRefs[%d] %s: %v", i, ref.Name, err))
+				}
+			}
+
+			certs = append(certs, files)
+		}
+
+		valid = len(certs) > 0
+	}
+
+	if valid && opts.ClientCertificateSecret != "" {
 		nsname := types.NamespacedName{
-			Namespace: c.gateway.Namespace,
-			Name:      string(gl.TLS.CertificateRefs[0].Name),
+			Namespace: gw.Namespace,
+			Name:      opts.ClientCertificateSecret,
 		}
 
-		path, err = c.secretMemoryMgr.Request(nsname)
+		var err error
+
+		opts.ClientCertificatePath, err = c.secretMemoryMgr.RequestCA(nsname)
 		if err != nil {
+			// client certificates must be verified, so the listener can't serve traffic without the CA bundle
+			invalidRefs = append(invalidRefs, fmt.Sprintf("%s %s: %v", TLSOptionClientCertificate, nsname.Name, err))
 			valid = false
 		}
 	}
 
-	h := getHostname(gl.Hostname)
+	h := newHostnameKey(gl)
 
 	if holder, exist := c.usedHostnames[h]; exist {
 		valid = false
@@ -88,11 +160,14 @@ func (c *httpsListenerConfigurator) configure(gl v1beta1.Listener) *listener {
 	}
 
 	l := &listener{
-		Source:            gl,
-		Valid:             valid,
-		SecretPath:        path,
-		Routes:            make(map[types.NamespacedName]*route),
-		AcceptedHostnames: make(map[string]struct{}),
+		Source:                 gl,
+		Valid:                  valid,
+		Certificates:           certs,
+		InvalidCertificateRefs: invalidRefs,
+		CertificateWarnings:    warnings,
+		TLSOptions:             opts,
+		Routes:                 make(map[types.NamespacedName]*route),
+		AcceptedHostnames:      make(map[string]struct{}),
 	}
 
 	c.usedHostnames[h] = l
@@ -100,20 +175,75 @@ func (c *httpsListenerConfigurator) configure(gl v1beta1.Listener) *listener {
 	return l
 }
 
+// requestCertificate requests the Secret of the certificateRef and returns the paths to its files on disk along with
+// its certificate.
+func (c *httpsListenerConfigurator) requestCertificate(
+	gwNamespace string,
+	ref v1beta1.SecretObjectReference,
+) (CertificateFiles, *x509.Certificate, error) {
+	if err := validateCertificateRef(ref, gwNamespace); err != nil {
+		return CertificateFiles{}, nil, err
+	}
+
+	nsname := types.NamespacedName{
+		Namespace: gwNamespace,
+		Name:      string(ref.Name),
+	}
+
+	files, err := c.secretMemoryMgr.Request(nsname)
+	if err != nil {
+		return CertificateFiles{}, nil, err
+	}
+
+	return files, c.secretMemoryMgr.GetCertificate(nsname), nil
+}
+
 type httpListenerConfigurator struct {
-	usedHostnames map[string]*listener
+	usedHostnames map[hostnameKey]*listener
 }
 
 func newHTTPListenerConfigurator() *httpListenerConfigurator {
 	return &httpListenerConfigurator{
-		usedHostnames: make(map[string]*listener),
+		usedHostnames: make(map[hostnameKey]*listener),
 	}
 }
 
-func (c *httpListenerConfigurator) configure(gl v1beta1.Listener) *listener {
+func (c *httpListenerConfigurator) configure(_ *v1beta1.Gateway, gl v1beta1.Listener) *listener {
 	valid := validateHTTPListener(gl)
 
-	h := getHostname(gl.Hostname)
+	h := newHostnameKey(gl)
+
+	if holder, exist := c.usedHostnames[h]; exist {
+		valid = false
+		holder.Valid = false // all listeners for the same hostname become conflicted
+	}
+
+	l := &listener{
+		Source:            gl,
+		Valid:             valid,
+		Routes:            make(map[types.NamespacedName]*route),
+		AcceptedHostnames: make(map[string]struct{}),
+	}
+
+	c.usedHostnames[h] = l
+
+	return l
+}
+
+type tlsPassthroughListenerConfigurator struct {
+	usedHostnames map[hostnameKey]*listener
+}
+
+func newTLSPassthroughListenerConfigurator() *tlsPassthroughListenerConfigurator {
+	return &tlsPassthroughListenerConfigurator{
+		usedHostnames: make(map[hostnameKey]*listener),
+	}
+}
+
+func (c *tlsPassthroughListenerConfigurator) configure(_ *v1beta1.Gateway, gl v1beta1.Listener) *listener {
+	valid := validateTLSPassthroughListener(gl)
+
+	h := newHostnameKey(gl)
 
 	if holder, exist := c.usedHostnames[h]; exist {
 		valid = false
@@ -132,13 +262,75 @@ func (c *httpListenerConfigurator) configure(gl v1beta1.Listener) *listener {
 	return l
 }
 
+type tcpListenerConfigurator struct {
+	usedPorts map[v1beta1.PortNumber]*listener
+}
+
+func newTCPListenerConfigurator() *tcpListenerConfigurator {
+	return &tcpListenerConfigurator{
+		usedPorts: make(map[v1beta1.PortNumber]*listener),
+	}
+}
+
+func (c *tcpListenerConfigurator) configure(_ *v1beta1.Gateway, gl v1beta1.Listener) *listener {
+	valid := validateTCPListener(gl)
+
+	// TCP connections don't carry a hostname, so NGINX can't distinguish between TCP listeners on the same port.
+	if holder, exist := c.usedPorts[gl.Port]; exist {
+		valid = false
+		holder.Valid = false // all listeners for the same port become conflicted
+	}
+
+	l := &listener{
+		Source:            gl,
+		Valid:             valid,
+		Routes:            make(map[types.NamespacedName]*route),
+		AcceptedHostnames: make(map[string]struct{}),
+	}
+
+	c.usedPorts[gl.Port] = l
+
+	return l
+}
+
+type udpListenerConfigurator struct {
+	usedPorts map[v1beta1.PortNumber]*listener
+}
+
+func newUDPListenerConfigurator() *udpListenerConfigurator {
+	return &udpListenerConfigurator{
+		usedPorts: make(map[v1beta1.PortNumber]*listener),
+	}
+}
+
+func (c *udpListenerConfigurator) configure(_ *v1beta1.Gateway, gl v1beta1.Listener) *listener {
+	valid := validateUDPListener(gl)
+
+	// UDP datagrams don't carry a hostname, so NGINX can't distinguish between UDP listeners on the same port.
+	if holder, exist := c.usedPorts[gl.Port]; exist {
+		valid = false
+		holder.Valid = false // all listeners for the same port become conflicted
+	}
+
+	l := &listener{
+		Source:            gl,
+		Valid:             valid,
+		Routes:            make(map[types.NamespacedName]*route),
+		AcceptedHostnames: make(map[string]struct{}),
+	}
+
+	c.usedPorts[gl.Port] = l
+
+	return l
+}
+
 type invalidProtocolListenerConfigurator struct{}
 
 func newInvalidProtocolListenerConfigurator() *invalidProtocolListenerConfigurator {
 	return &invalidProtocolListenerConfigurator{}
 }
 
-func (c *invalidProtocolListenerConfigurator) configure(gl v1beta1.Listener) *listener {
+func (c *invalidProtocolListenerConfigurator) configure(_ *v1beta1.Gateway, gl v1beta1.Listener) *listener {
 	return &listener{
 		Source:            gl,
 		Valid:             false,
@@ -147,29 +339,110 @@ func (c *invalidProtocolListenerConfigurator) configure(gl v1beta1.Listener) *li
 	}
 }
 
-func validateHTTPListener(listener v1beta1.Listener) bool {
-	// FIXME(pleshakov): For now we require that all HTTP listeners bind to port 80
-	return listener.Port == 80
+// listenerPort identifies a port of a listener. UDP ports are separate from the ports of the other protocols,
+// which all use TCP.
+type listenerPort struct {
+	port v1beta1.PortNumber
+	udp  bool
+}
+
+// portConflictResolver invalidates the listeners that share a port but use different protocols.
+// NGINX can't serve different protocols on the same port. However, UDP listeners can share a port number with
+// the listeners of the other protocols.
+// It also invalidates the HTTPS listeners that share a port but use different TLS protocols or ciphers:
+// NGINX negotiates them in the default server of the port before it knows the server name of the request,
+// so all servers on the port must agree on them.
+// The listeners are resolved in the order of the Gateways, oldest first, so only the incoming conflicting listener
+// is invalidated, and the listeners that already use the port keep serving traffic.
+type portConflictResolver struct {
+	protocolsForPort  map[listenerPort]v1beta1.ProtocolType
+	tlsOptionsForPort map[listenerPort]TLSOptions
 }
 
-func validateHTTPSListener(listener v1beta1.Listener, gwNsname string) bool {
-	// FIXME(kate-osborn):
-	// 1. For now we require that all HTTPS listeners bind to port 443
-	// 2. Only TLSModeTerminate is supported.
-	if listener.Port != 443 || listener.TLS == nil || *listener.TLS.Mode != v1beta1.TLSModeTerminate || len(listener.TLS.CertificateRefs) == 0 {
-		return false
+func newPortConflictResolver() *portConflictResolver {
+	return &portConflictResolver{
+		protocolsForPort:  make(map[listenerPort]v1beta1.ProtocolType),
+		tlsOptionsForPort: make(map[listenerPort]TLSOptions),
+	}
+}
+
+func (r *portConflictResolver) resolve(l *listener) {
+	port := listenerPort{
+		port: l.Source.Port,
+		udp:  l.Source.Protocol == v1beta1.UDPProtocolType,
 	}
 
-	certRef := listener.TLS.CertificateRefs[0]
+	protocol, exist := r.protocolsForPort[port]
+	if !exist {
+		r.protocolsForPort[port] = l.Source.Protocol
+	} else if protocol != l.Source.Protocol {
+		l.Valid = false
+		return
+	}
+
+	// the TLS options of an invalid listener are not parsed, so they can't conflict
+	if l.Source.Protocol != v1beta1.HTTPSProtocolType || !l.Valid {
+		return
+	}
+
+	opts, exist := r.tlsOptionsForPort[port]
+	if !exist {
+		r.tlsOptionsForPort[port] = l.TLSOptions
+		return
+	}
+
+	if !equalHandshakeOptions(opts, l.TLSOptions) {
+		l.Valid = false
+	}
+}
+
+func validateListenerPort(port v1beta1.PortNumber) bool {
+	return port >= 1 && port <= 65535
+}
+
+func validateHTTPListener(listener v1beta1.Listener) bool {
+	return validateListenerPort(listener.Port)
+}
+
+// validateHTTPSListener validates an HTTPS listener. Only the Terminate mode is supported for HTTPS listeners.
+// The Passthrough mode is supported for TLS listeners.
+func validateHTTPSListener(listener v1beta1.Listener) bool {
+	return validateListenerPort(listener.Port) &&
+		listener.TLS != nil &&
+		listener.TLS.Mode != nil &&
+		*listener.TLS.Mode == v1beta1.TLSModeTerminate &&
+		len(listener.TLS.CertificateRefs) > 0
+}
+
+func validateCertificateRef(certRef v1beta1.SecretObjectReference, gwNsname string) error {
 	// certRef Kind has default of "Secret" so it's safe to directly access the Kind here
 	if *certRef.Kind != "Secret" {
-		return false
+		return fmt.Errorf("unsupported kind %s", *certRef.Kind)
 	}
 
 	// secret must be in the same namespace as the gateway
 	if certRef.Namespace != nil && string(*certRef.Namespace) != gwNsname {
-		return false
+		return errors.New("secret must be in the namespace of the Gateway")
 	}
 
-	return true
+	return nil
+}
+
+// validateTLSPassthroughListener validates a TLS listener. Only the Passthrough mode is supported for TLS listeners,
+// so the certificateRefs are ignored.
+func validateTLSPassthroughListener(listener v1beta1.Listener) bool {
+	return validateListenerPort(listener.Port) &&
+		listener.TLS != nil &&
+		listener.TLS.Mode != nil &&
+		*listener.TLS.Mode == v1beta1.TLSModePassthrough
+}
+
+// validateTCPListener validates a TCP listener. The hostname of a TCP listener is ignored.
+func validateTCPListener(listener v1beta1.Listener) bool {
+	return validateListenerPort(listener.Port) && listener.TLS == nil
+}
+
+// validateUDPListener validates a UDP listener. The hostname of a UDP listener is ignored.
+func validateUDPListener(listener v1beta1.Listener) bool {
+	return validateListenerPort(listener.Port) && listener.TLS == nil
 }
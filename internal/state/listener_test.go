@@ -24,7 +24,15 @@ func TestValidateHTTPListener(t *testing.T) {
 		},
 		{
 			l: v1beta1.Listener{
-				Port:     81,
+				Port:     8080,
+				Protocol: v1beta1.HTTPProtocolType,
+			},
+			expected: true,
+			msg:      "valid non-default port",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     0,
 				Protocol: v1beta1.HTTPProtocolType,
 			},
 			expected: false,
@@ -49,18 +57,6 @@ func TestValidateHTTPSListener(t *testing.T) {
 		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(gwNs)),
 	}
 
-	invalidSecretRefType := &v1beta1.SecretObjectReference{
-		Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("ConfigMap")),
-		Name:      "secret",
-		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(gwNs)),
-	}
-
-	invalidSecretRefTNamespace := &v1beta1.SecretObjectReference{
-		Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
-		Name:      "secret",
-		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer("diff-ns")),
-	}
-
 	tests := []struct {
 		l        v1beta1.Listener
 		expected bool
@@ -80,7 +76,19 @@ func TestValidateHTTPSListener(t *testing.T) {
 		},
 		{
 			l: v1beta1.Listener{
-				Port:     80,
+				Port:     8443,
+				Protocol: v1beta1.HTTPSProtocolType,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					CertificateRefs: []v1beta1.SecretObjectReference{*validSecretRef},
+				},
+			},
+			expected: true,
+			msg:      "valid non-default port",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     65536,
 				Protocol: v1beta1.HTTPSProtocolType,
 				TLS: &v1beta1.GatewayTLSConfig{
 					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
@@ -118,39 +126,411 @@ func TestValidateHTTPSListener(t *testing.T) {
 				Port:     443,
 				Protocol: v1beta1.HTTPSProtocolType,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
-					CertificateRefs: []v1beta1.SecretObjectReference{*invalidSecretRefType},
+					CertificateRefs: []v1beta1.SecretObjectReference{*validSecretRef},
 				},
 			},
 			expected: false,
-			msg:      "invalid cert ref kind",
+			msg:      "invalid - no tls mode",
 		},
 		{
 			l: v1beta1.Listener{
 				Port:     443,
 				Protocol: v1beta1.HTTPSProtocolType,
+			},
+			expected: false,
+			msg:      "invalid - no tls config",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateHTTPSListener(test.l)
+		if result != test.expected {
+			t.Errorf("validateHTTPSListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestValidateCertificateRef(t *testing.T) {
+	gwNs := "gateway-ns"
+
+	tests := []struct {
+		ref         v1beta1.SecretObjectReference
+		msg         string
+		expectedErr bool
+	}{
+		{
+			ref: v1beta1.SecretObjectReference{
+				Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+				Name:      "secret",
+				Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(gwNs)),
+			},
+			expectedErr: false,
+			msg:         "valid",
+		},
+		{
+			ref: v1beta1.SecretObjectReference{
+				Kind: (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+				Name: "secret",
+			},
+			expectedErr: false,
+			msg:         "valid - no namespace",
+		},
+		{
+			ref: v1beta1.SecretObjectReference{
+				Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("ConfigMap")),
+				Name:      "secret",
+				Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(gwNs)),
+			},
+			expectedErr: true,
+			msg:         "invalid cert ref kind",
+		},
+		{
+			ref: v1beta1.SecretObjectReference{
+				Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+				Name:      "secret",
+				Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer("diff-ns")),
+			},
+			expectedErr: true,
+			msg:         "invalid cert ref namespace",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateCertificateRef(test.ref, gwNs)
+		if test.expectedErr && err == nil {
+			t.Errorf("validateCertificateRef() returned no error for the case of %q", test.msg)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("validateCertificateRef() returned unexpected error %v for the case of %q", err, test.msg)
+		}
+	}
+}
+
+func TestValidateTLSPassthroughListener(t *testing.T) {
+	tests := []struct {
+		l        v1beta1.Listener
+		expected bool
+		msg      string
+	}{
+		{
+			l: v1beta1.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
-					CertificateRefs: []v1beta1.SecretObjectReference{*invalidSecretRefTNamespace},
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+				},
+			},
+			expected: true,
+			msg:      "valid",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     0,
+				Protocol: v1beta1.TLSProtocolType,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
 				},
 			},
 			expected: false,
-			msg:      "invalid cert ref namespace",
+			msg:      "invalid port",
 		},
 		{
 			l: v1beta1.Listener{
 				Port:     443,
-				Protocol: v1beta1.HTTPSProtocolType,
+				Protocol: v1beta1.TLSProtocolType,
 			},
 			expected: false,
-			msg:      "invalid - no tls config",
+			msg:      "missing tls config",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+				},
+			},
+			expected: false,
+			msg:      "unsupported terminate mode",
 		},
 	}
 
 	for _, test := range tests {
-		result := validateHTTPSListener(test.l, gwNs)
+		result := validateTLSPassthroughListener(test.l)
 		if result != test.expected {
-			t.Errorf("validateHTTPSListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+			t.Errorf("validateTLSPassthroughListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestValidateTCPListener(t *testing.T) {
+	tests := []struct {
+		l        v1beta1.Listener
+		expected bool
+		msg      string
+	}{
+		{
+			l: v1beta1.Listener{
+				Port:     5432,
+				Protocol: v1beta1.TCPProtocolType,
+			},
+			expected: true,
+			msg:      "valid",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     0,
+				Protocol: v1beta1.TCPProtocolType,
+			},
+			expected: false,
+			msg:      "invalid port",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     5432,
+				Protocol: v1beta1.TCPProtocolType,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+				},
+			},
+			expected: false,
+			msg:      "tls config is set",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateTCPListener(test.l)
+		if result != test.expected {
+			t.Errorf("validateTCPListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestValidateUDPListener(t *testing.T) {
+	tests := []struct {
+		l        v1beta1.Listener
+		expected bool
+		msg      string
+	}{
+		{
+			l: v1beta1.Listener{
+				Port:     53,
+				Protocol: v1beta1.UDPProtocolType,
+			},
+			expected: true,
+			msg:      "valid",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     65536,
+				Protocol: v1beta1.UDPProtocolType,
+			},
+			expected: false,
+			msg:      "invalid port",
+		},
+		{
+			l: v1beta1.Listener{
+				Port:     53,
+				Protocol: v1beta1.UDPProtocolType,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+				},
+			},
+			expected: false,
+			msg:      "tls config is set",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateUDPListener(test.l)
+		if result != test.expected {
+			t.Errorf("validateUDPListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestTCPListenerConfigurator(t *testing.T) {
+	configurator := newTCPListenerConfigurator()
+
+	tcp5432 := configurator.configure(nil, v1beta1.Listener{Name: "tcp-5432", Port: 5432, Protocol: v1beta1.TCPProtocolType})
+	anotherTCP5432 := configurator.configure(nil, v1beta1.Listener{Name: "another-tcp-5432", Port: 5432, Protocol: v1beta1.TCPProtocolType})
+	tcp6379 := configurator.configure(nil, v1beta1.Listener{Name: "tcp-6379", Port: 6379, Protocol: v1beta1.TCPProtocolType})
+
+	tests := []struct {
+		l        *listener
+		expected bool
+		msg      string
+	}{
+		{
+			l:        tcp5432,
+			expected: false,
+			msg:      "tcp listener conflicted with a later tcp listener on the same port",
+		},
+		{
+			l:        anotherTCP5432,
+			expected: false,
+			msg:      "another tcp listener on the same port",
+		},
+		{
+			l:        tcp6379,
+			expected: true,
+			msg:      "tcp listener on a different port",
+		},
+	}
+
+	for _, test := range tests {
+		if test.l.Valid != test.expected {
+			t.Errorf("tcpListenerConfigurator.configure() set Valid to %v but expected %v for the case of %q", test.l.Valid, test.expected, test.msg)
+		}
+	}
+}
+
+func TestPortConflictResolver(t *testing.T) {
+	createListener := func(port v1beta1.PortNumber, protocol v1beta1.ProtocolType) *listener {
+		return &listener{
+			Source: v1beta1.Listener{
+				Port:     port,
+				Protocol: protocol,
+			},
+			Valid: true,
+		}
+	}
+
+	http80 := createListener(80, v1beta1.HTTPProtocolType)
+	https80 := createListener(80, v1beta1.HTTPSProtocolType)
+	anotherHTTP80 := createListener(80, v1beta1.HTTPProtocolType)
+	http8080 := createListener(8080, v1beta1.HTTPProtocolType)
+	https443 := createListener(443, v1beta1.HTTPSProtocolType)
+	anotherHTTPS443 := createListener(443, v1beta1.HTTPSProtocolType)
+	http9000 := createListener(9000, v1beta1.HTTPProtocolType)
+	tcp9000 := createListener(9000, v1beta1.TCPProtocolType)
+	udp9000 := createListener(9000, v1beta1.UDPProtocolType)
+	tcp53 := createListener(53, v1beta1.TCPProtocolType)
+	udp53 := createListener(53, v1beta1.UDPProtocolType)
+
+	tls12 := TLSOptions{Protocols: []string{"TLSv1.2"}}
+
+	https8443 := createListener(8443, v1beta1.HTTPSProtocolType)
+	https8443.TLSOptions = tls12
+	anotherHTTPS8443 := createListener(8443, v1beta1.HTTPSProtocolType)
+	anotherHTTPS8443.TLSOptions = TLSOptions{Protocols: []string{"TLSv1.2"}, SessionTimeout: "10m"}
+	https9443 := createListener(9443, v1beta1.HTTPSProtocolType)
+	https9443.TLSOptions = tls12
+	tls13HTTPS9443 := createListener(9443, v1beta1.HTTPSProtocolType)
+	tls13HTTPS9443.TLSOptions = TLSOptions{Protocols: []string{"TLSv1.3"}}
+	invalidHTTPS9443 := createListener(9443, v1beta1.HTTPSProtocolType)
+	invalidHTTPS9443.Valid = false
+	https10443 := createListener(10443, v1beta1.HTTPSProtocolType)
+	https10443.TLSOptions = tls12
+	invalidHTTPS10443 := createListener(10443, v1beta1.HTTPSProtocolType)
+	invalidHTTPS10443.Valid = false
+
+	resolver := newPortConflictResolver()
+
+	listeners := []*listener{
+		http80, http8080, https443, https80, anotherHTTP80, anotherHTTPS443, http9000, tcp9000, udp9000, tcp53, udp53,
+		https8443, anotherHTTPS8443, https9443, tls13HTTPS9443, invalidHTTPS9443, https10443, invalidHTTPS10443,
+	}
+
+	for _, l := range listeners {
+		resolver.resolve(l)
+	}
+
+	tests := []struct {
+		l        *listener
+		expected bool
+		msg      string
+	}{
+		{
+			l:        http80,
+			expected: true,
+			msg:      "http listener that uses the port first",
+		},
+		{
+			l:        https80,
+			expected: false,
+			msg:      "https listener on the http port",
+		},
+		{
+			l:        anotherHTTP80,
+			expected: true,
+			msg:      "another http listener on the http port",
+		},
+		{
+			l:        http8080,
+			expected: true,
+			msg:      "http listener on a non-default port",
+		},
+		{
+			l:        https443,
+			expected: true,
+			msg:      "https listener",
+		},
+		{
+			l:        anotherHTTPS443,
+			expected: true,
+			msg:      "another https listener on the same port",
+		},
+		{
+			l:        http9000,
+			expected: true,
+			msg:      "http listener that uses the port before a tcp listener",
+		},
+		{
+			l:        tcp9000,
+			expected: false,
+			msg:      "tcp listener on the http port",
+		},
+		{
+			l:        udp9000,
+			expected: true,
+			msg:      "udp listener on the http port",
+		},
+		{
+			l:        tcp53,
+			expected: true,
+			msg:      "tcp listener on the udp port",
+		},
+		{
+			l:        udp53,
+			expected: true,
+			msg:      "udp listener on the tcp port",
+		},
+		{
+			l:        https8443,
+			expected: true,
+			msg:      "https listener with tls options",
+		},
+		{
+			l:        anotherHTTPS8443,
+			expected: true,
+			msg:      "https listener with the same tls protocols and ciphers on the same port",
+		},
+		{
+			l:        https9443,
+			expected: true,
+			msg:      "https listener that uses the port before a listener with different tls protocols",
+		},
+		{
+			l:        tls13HTTPS9443,
+			expected: false,
+			msg:      "https listener with different tls protocols on the same port",
+		},
+		{
+			l:        invalidHTTPS9443,
+			expected: false,
+			msg:      "invalid https listener on the same port",
+		},
+		{
+			l:        https10443,
+			expected: true,
+			msg:      "https listener with an invalid https listener on the same port",
+		},
+	}
+
+	for _, test := range tests {
+		if test.l.Valid != test.expected {
+			t.Errorf("portConflictResolver.resolve() set Valid to %v but expected %v for the case of %q", test.l.Valid, test.expected, test.msg)
 		}
 	}
 }
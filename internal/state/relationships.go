@@ -0,0 +1,143 @@
+package state
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// relationshipTracker tracks the relationships between the resources in the store and the Gateways of
+// the GatewayClass, so that the ChangeProcessor can ignore the changes to the resources that affect neither
+// the configuration nor the statuses:
+// - The Gateways of other GatewayClasses.
+// - The routes that don't reference any Gateway of the GatewayClass.
+// - The Secrets that are not referenced by the listeners.
+// - The Services that are not referenced by the backends of the routes.
+//
+// The relationships with the Gateways are determined from the store, so they are always up-to-date.
+// The referenced Secrets and Services are determined from the graph, so they are as of the last Process call.
+// It is enough, because a new reference can only be added by a change to a Gateway or a route, which
+// triggers building a new graph.
+type relationshipTracker struct {
+	store            *store
+	gatewayClassName string
+	// referencedSecrets are the Secrets referenced by the listeners of the Gateways.
+	referencedSecrets map[types.NamespacedName]struct{}
+	// referencedServices are the Services referenced by the backends of the routes bound to the Gateways.
+	referencedServices map[types.NamespacedName]struct{}
+}
+
+func newRelationshipTracker(store *store, gatewayClassName string) *relationshipTracker {
+	return &relationshipTracker{
+		store:              store,
+		gatewayClassName:   gatewayClassName,
+		referencedSecrets:  make(map[types.NamespacedName]struct{}),
+		referencedServices: make(map[types.NamespacedName]struct{}),
+	}
+}
+
+// update updates the referenced Secrets and Services from the graph.
+func (t *relationshipTracker) update(g *graph) {
+	t.referencedSecrets = getReferencedSecrets(g.Gateways)
+	t.referencedServices = getReferencedServices(g)
+}
+
+// isGatewayRelevant tells if the Gateway belongs to the GatewayClass.
+func (t *relationshipTracker) isGatewayRelevant(gw *v1beta1.Gateway) bool {
+	return string(gw.Spec.GatewayClassName) == t.gatewayClassName
+}
+
+// isRouteRelevant tells if any of the parentRefs of the route in the namespace references a Gateway of
+// the GatewayClass.
+func (t *relationshipTracker) isRouteRelevant(namespace string, parentRefs []v1beta1.ParentReference) bool {
+	for _, ref := range parentRefs {
+		// if the namespace is missing, assume the namespace of the route
+		ns := namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+
+		gw, exist := t.store.gateways[types.NamespacedName{Namespace: ns, Name: string(ref.Name)}]
+		if exist && t.isGatewayRelevant(gw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *relationshipTracker) isSecretReferenced(nsname types.NamespacedName) bool {
+	_, referenced := t.referencedSecrets[nsname]
+	return referenced
+}
+
+func (t *relationshipTracker) isServiceReferenced(nsname types.NamespacedName) bool {
+	_, referenced := t.referencedServices[nsname]
+	return referenced
+}
+
+// getReferencedServices returns the Services referenced by the backends of the routes bound to the Gateways,
+// including the Services that don't exist, so that creating a missing Service updates the backends.
+func getReferencedServices(g *graph) map[types.NamespacedName]struct{} {
+	services := make(map[types.NamespacedName]struct{})
+
+	add := func(namespace string, ref v1beta1.BackendObjectReference) {
+		if ref.Kind != nil && *ref.Kind != "Service" {
+			return
+		}
+
+		ns := namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+
+		services[types.NamespacedName{Namespace: ns, Name: string(ref.Name)}] = struct{}{}
+	}
+
+	for _, routes := range []map[types.NamespacedName]*route{
+		g.Routes,
+		g.GRPCRoutes,
+		g.TLSRoutes,
+		g.TCPRoutes,
+		g.UDPRoutes,
+	} {
+		for _, r := range routes {
+			ns := r.Source.GetNamespace()
+
+			switch s := r.Source.(type) {
+			case *v1beta1.HTTPRoute:
+				for _, rule := range s.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						add(ns, ref.BackendObjectReference)
+					}
+				}
+			case *v1alpha2.GRPCRoute:
+				for _, rule := range s.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						add(ns, convertBackendObjectReference(ref.BackendObjectReference))
+					}
+				}
+			case *v1alpha2.TLSRoute:
+				for _, rule := range s.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						add(ns, convertBackendObjectReference(ref.BackendObjectReference))
+					}
+				}
+			case *v1alpha2.TCPRoute:
+				for _, rule := range s.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						add(ns, convertBackendObjectReference(ref.BackendObjectReference))
+					}
+				}
+			case *v1alpha2.UDPRoute:
+				for _, rule := range s.Spec.Rules {
+					for _, ref := range rule.BackendRefs {
+						add(ns, convertBackendObjectReference(ref.BackendObjectReference))
+					}
+				}
+			}
+		}
+	}
+
+	return services
+}
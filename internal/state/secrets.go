@@ -1,14 +1,19 @@
 package state
 
 import (
-	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -17,8 +22,18 @@ import (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . FileManager
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 io/fs.DirEntry
 
-// tlsSecretFileMode defines the default file mode for files with TLS Secrets.
-const tlsSecretFileMode = 0o600
+const (
+	// certificateFileMode defines the file mode for files with certificates and CA bundles.
+	certificateFileMode = 0o644
+	// privateKeyFileMode defines the file mode for files with private keys.
+	privateKeyFileMode = 0o600
+)
+
+// tmpFileSuffix is the suffix of the temporary file a secret file is written to before it is renamed.
+const tmpFileSuffix = ".tmp"
+
+// CACertKey is the key of the CA bundle in a Secret.
+const CACertKey = "ca.crt"
 
 // SecretStore stores secrets.
 type SecretStore interface {
@@ -40,6 +55,8 @@ type Secret struct {
 	Secret *apiv1.Secret
 	// Valid is whether the Kubernetes Secret is valid.
 	Valid bool
+	// Certificate is the parsed leaf certificate of a valid Secret.
+	Certificate *x509.Certificate
 }
 
 func NewSecretStore() *SecretStoreImpl {
@@ -54,8 +71,8 @@ func (s SecretStoreImpl) Upsert(secret *apiv1.Secret) {
 		Name:      secret.Name,
 	}
 
-	valid := isSecretValid(secret)
-	s.secrets[nsname] = &Secret{Secret: secret, Valid: valid}
+	cert, valid := parseTLSSecret(secret)
+	s.secrets[nsname] = &Secret{Secret: secret, Valid: valid, Certificate: cert}
 }
 
 func (s SecretStoreImpl) Delete(nsname types.NamespacedName) {
@@ -69,10 +86,42 @@ func (s SecretStoreImpl) Get(nsname types.NamespacedName) *Secret {
 // SecretDiskMemoryManager manages secrets that are requested by Gateway resources.
 type SecretDiskMemoryManager interface {
 	// Request marks the secret as requested so that it can be written to disk before reloading NGINX.
-	// Returns the path to the secret and an error if the secret does not exist in the secret store or the secret is invalid.
-	Request(nsname types.NamespacedName) (string, error)
+	// Returns the paths to the certificate and key files of the secret and an error if the secret does not exist
+	// in the secret store or the secret is invalid.
+	Request(nsname types.NamespacedName) (CertificateFiles, error)
+	// RequestCA marks the CA bundle of the secret as requested so that it can be written to disk before reloading NGINX.
+	// Returns the path to the CA bundle and an error if the secret does not exist in the secret store or
+	// doesn't contain a valid CA bundle.
+	RequestCA(nsname types.NamespacedName) (string, error)
+	// GetCertificate returns the parsed leaf certificate of the requested secret.
+	// Returns nil if the secret wasn't requested.
+	GetCertificate(nsname types.NamespacedName) *x509.Certificate
 	// WriteAllRequestedSecrets writes all requested secrets to disk.
+	// The files that already exist on disk are not rewritten. The files of the secrets that are no longer requested
+	// are not removed, so that NGINX can use them until it is reloaded.
+	// The requests are reset even if writing fails, so the secrets must be requested again before the next call.
 	WriteAllRequestedSecrets() error
+	// NextCertificateValidityChange returns the earliest time after which the validity of a certificate requested
+	// since the last WriteAllRequestedSecrets call changes: a not yet valid certificate becomes valid or a valid
+	// certificate expires. Returns the zero time if there are no such certificates.
+	NextCertificateValidityChange() time.Time
+	// RemoveUnusedSecrets removes the files from disk that were not written by the last WriteAllRequestedSecrets call.
+	// Must be called only after NGINX is successfully reloaded, so that NGINX no longer uses the removed files.
+	RemoveUnusedSecrets() error
+}
+
+// CertificateFiles holds the paths to the files of a TLS secret on disk.
+type CertificateFiles struct {
+	// Certificate is the path to the certificate file.
+	Certificate string
+	// Key is the path to the private key file.
+	Key string
+}
+
+// CertificateExpiryRecorder records the expiry times of the certificates of the Secrets written to disk.
+type CertificateExpiryRecorder interface {
+	// RecordCertificateExpiries replaces the previously recorded expiry times with the passed ones.
+	RecordCertificateExpiries(expiries map[types.NamespacedName]time.Time)
 }
 
 // FileManager is an interface that exposes File I/O operations.
@@ -88,19 +137,39 @@ type FileManager interface {
 	Chmod(file *os.File, mode os.FileMode) error
 	// Write writes contents to the file.
 	Write(file *os.File, contents []byte) error
+	// Close closes the file.
+	Close(file *os.File) error
+	// Rename renames (moves) oldpath to newpath. If newpath already exists, Rename replaces it.
+	Rename(oldpath, newpath string) error
 }
 
 // FIXME(kate-osborn): Is it necessary to make this concurrent-safe?
 type SecretDiskMemoryManagerImpl struct {
 	requestedSecrets map[types.NamespacedName]requestedSecret
-	secretStore      SecretStore
-	fileManager      FileManager
-	secretDirectory  string
+	requestedCAs     map[types.NamespacedName]requestedSecret
+	// writtenFiles are the names of the files written by the last WriteAllRequestedSecrets call.
+	// nil means WriteAllRequestedSecrets hasn't succeeded yet.
+	writtenFiles    map[string]struct{}
+	secretStore     SecretStore
+	fileManager     FileManager
+	expiryRecorder  CertificateExpiryRecorder
+	now             func() metav1.Time
+	secretDirectory string
+	// nextValidityChange is the earliest validity change of the certificates requested since the last
+	// WriteAllRequestedSecrets call. The zero time means there are no such certificates.
+	nextValidityChange time.Time
 }
 
 type requestedSecret struct {
-	secret *apiv1.Secret
-	path   string
+	certificate *x509.Certificate
+	files       []secretFile
+}
+
+// secretFile is a file with the contents of a secret.
+type secretFile struct {
+	path     string
+	contents []byte
+	mode     os.FileMode
 }
 
 // SecretDiskMemoryManagerOption is a function that modifies the configuration of the SecretDiskMemoryManager.
@@ -114,12 +183,29 @@ func WithSecretFileManager(fileManager FileManager) SecretDiskMemoryManagerOptio
 	}
 }
 
+// WithCertificateExpiryRecorder sets the recorder of the certificate expiry times.
+func WithCertificateExpiryRecorder(recorder CertificateExpiryRecorder) SecretDiskMemoryManagerOption {
+	return func(mm *SecretDiskMemoryManagerImpl) {
+		mm.expiryRecorder = recorder
+	}
+}
+
+// WithSecretNowFunc sets the function that returns the current time, which is used to check the validity period
+// of certificates. Used to inject the Now method of a fake clock for unit tests.
+func WithSecretNowFunc(now func() metav1.Time) SecretDiskMemoryManagerOption {
+	return func(mm *SecretDiskMemoryManagerImpl) {
+		mm.now = now
+	}
+}
+
 func NewSecretDiskMemoryManager(secretDirectory string, secretStore SecretStore, options ...SecretDiskMemoryManagerOption) *SecretDiskMemoryManagerImpl {
 	sm := &SecretDiskMemoryManagerImpl{
 		requestedSecrets: make(map[types.NamespacedName]requestedSecret),
+		requestedCAs:     make(map[types.NamespacedName]requestedSecret),
 		secretStore:      secretStore,
 		secretDirectory:  secretDirectory,
 		fileManager:      newStdLibFileManager(),
+		now:              metav1.Now,
 	}
 
 	for _, o := range options {
@@ -129,87 +215,274 @@ func NewSecretDiskMemoryManager(secretDirectory string, secretStore SecretStore,
 	return sm
 }
 
-func (s *SecretDiskMemoryManagerImpl) Request(nsname types.NamespacedName) (string, error) {
+func (s *SecretDiskMemoryManagerImpl) Request(nsname types.NamespacedName) (CertificateFiles, error) {
 	secret := s.secretStore.Get(nsname)
 	if secret == nil {
-		return "", fmt.Errorf("secret %s does not exist", nsname)
+		return CertificateFiles{}, fmt.Errorf("secret %s does not exist", nsname)
 	}
 
 	if !secret.Valid {
-		return "", fmt.Errorf("secret %s is not valid; must be of type %s and contain a valid X509 key pair", nsname, apiv1.SecretTypeTLS)
+		return CertificateFiles{}, fmt.Errorf("secret %s is not valid; must be of type %s and contain a valid X509 key pair", nsname, apiv1.SecretTypeTLS)
+	}
+
+	now := s.now().Time
+
+	// the change is tracked for the rejected certificates too, so that they are accepted once they become valid
+	s.trackValidityChange(secret.Certificate, now)
+
+	if err := validateCertificatePeriod(secret.Certificate, now); err != nil {
+		return CertificateFiles{}, fmt.Errorf("secret %s is not valid; %w", nsname, err)
 	}
 
-	ss := requestedSecret{
-		secret: secret.Secret,
-		path:   path.Join(s.secretDirectory, generateFilepathForSecret(nsname)),
+	cert := secret.Secret.Data[apiv1.TLSCertKey]
+	key := secret.Secret.Data[apiv1.TLSPrivateKeyKey]
+
+	prefix := path.Join(s.secretDirectory, generateFilenamePrefix(nsname, cert, key))
+
+	files := CertificateFiles{
+		Certificate: prefix + ".crt",
+		Key:         prefix + ".key",
 	}
 
-	s.requestedSecrets[nsname] = ss
+	s.requestedSecrets[nsname] = requestedSecret{
+		certificate: secret.Certificate,
+		files: []secretFile{
+			{path: files.Certificate, contents: cert, mode: certificateFileMode},
+			{path: files.Key, contents: key, mode: privateKeyFileMode},
+		},
+	}
 
-	return ss.path, nil
+	return files, nil
+}
+
+func (s *SecretDiskMemoryManagerImpl) RequestCA(nsname types.NamespacedName) (string, error) {
+	secret := s.secretStore.Get(nsname)
+	if secret == nil {
+		return "", fmt.Errorf("secret %s does not exist", nsname)
+	}
+
+	if err := validateCABundle(secret.Secret); err != nil {
+		return "", fmt.Errorf("secret %s is not valid: %w", nsname, err)
+	}
+
+	bundle := secret.Secret.Data[CACertKey]
+
+	f := secretFile{
+		path:     path.Join(s.secretDirectory, generateFilenamePrefix(nsname, bundle)+"_ca.crt"),
+		contents: bundle,
+		mode:     certificateFileMode,
+	}
+
+	s.requestedCAs[nsname] = requestedSecret{
+		files: []secretFile{f},
+	}
+
+	return f.path, nil
+}
+
+func (s *SecretDiskMemoryManagerImpl) GetCertificate(nsname types.NamespacedName) *x509.Certificate {
+	return s.requestedSecrets[nsname].certificate
 }
 
 func (s *SecretDiskMemoryManagerImpl) WriteAllRequestedSecrets() error {
-	// Remove all existing secrets from secrets directory
+	// The secrets are requested again when the configuration is rebuilt. Otherwise, the secrets that are
+	// no longer referenced would be written on the next call.
+	defer s.resetRequests()
+
 	dir, err := s.fileManager.ReadDir(s.secretDirectory)
 	if err != nil {
-		return fmt.Errorf("failed to remove all secrets from %s: %w", s.secretDirectory, err)
+		return fmt.Errorf("failed to read secrets directory %s: %w", s.secretDirectory, err)
 	}
 
+	existing := make(map[string]struct{}, len(dir))
 	for _, d := range dir {
-		filepath := path.Join(s.secretDirectory, d.Name())
-		if err := s.fileManager.Remove(filepath); err != nil {
-			return fmt.Errorf("failed to remove secret %s: %w", filepath, err)
-		}
+		existing[d.Name()] = struct{}{}
 	}
 
-	// Write all secrets to secrets directory
-	for nsname, ss := range s.requestedSecrets {
+	written := make(map[string]struct{})
 
-		file, err := s.fileManager.Create(ss.path)
-		if err != nil {
-			return fmt.Errorf("failed to create file %s for secret %s: %w", ss.path, nsname, err)
-		}
+	for _, requested := range []map[types.NamespacedName]requestedSecret{s.requestedSecrets, s.requestedCAs} {
+		for nsname, ss := range requested {
+			for _, f := range ss.files {
+				name := path.Base(f.path)
+				written[name] = struct{}{}
 
-		if err = s.fileManager.Chmod(file, tlsSecretFileMode); err != nil {
-			return fmt.Errorf("failed to change mode of file %s for secret %s: %w", ss.path, nsname, err)
+				// The name of a file includes the hash of its contents, so an existing file doesn't need to be rewritten.
+				if _, exists := existing[name]; exists {
+					continue
+				}
+
+				if err := s.writeFile(nsname, f); err != nil {
+					return err
+				}
+			}
 		}
+	}
 
-		contents := generateCertAndKeyFileContent(ss.secret)
+	s.writtenFiles = written
 
-		err = s.fileManager.Write(file, contents)
-		if err != nil {
-			return fmt.Errorf("failed to write secret %s to file %s: %w", nsname, ss.path, err)
+	if s.expiryRecorder != nil {
+		expiries := make(map[types.NamespacedName]time.Time, len(s.requestedSecrets))
+		for nsname, ss := range s.requestedSecrets {
+			if ss.certificate != nil {
+				expiries[nsname] = ss.certificate.NotAfter
+			}
 		}
+
+		s.expiryRecorder.RecordCertificateExpiries(expiries)
 	}
 
-	// reset stored secrets
+	return nil
+}
+
+func (s *SecretDiskMemoryManagerImpl) resetRequests() {
 	s.requestedSecrets = make(map[types.NamespacedName]requestedSecret)
+	s.requestedCAs = make(map[types.NamespacedName]requestedSecret)
+	s.nextValidityChange = time.Time{}
+}
+
+func (s *SecretDiskMemoryManagerImpl) NextCertificateValidityChange() time.Time {
+	return s.nextValidityChange
+}
+
+// trackValidityChange updates the next validity change with the next validity change of the certificate.
+func (s *SecretDiskMemoryManagerImpl) trackValidityChange(cert *x509.Certificate, now time.Time) {
+	if cert == nil {
+		return
+	}
+
+	var change time.Time
+
+	switch {
+	case now.Before(cert.NotBefore):
+		change = cert.NotBefore
+	case !now.After(cert.NotAfter):
+		// NotAfter has a precision of one second and the certificate is still valid at NotAfter
+		change = cert.NotAfter.Add(time.Second)
+	default:
+		// the certificate has expired and will never become valid
+		return
+	}
+
+	if s.nextValidityChange.IsZero() || change.Before(s.nextValidityChange) {
+		s.nextValidityChange = change
+	}
+}
+
+func (s *SecretDiskMemoryManagerImpl) RemoveUnusedSecrets() error {
+	if s.writtenFiles == nil {
+		return nil
+	}
+
+	dir, err := s.fileManager.ReadDir(s.secretDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read secrets directory %s: %w", s.secretDirectory, err)
+	}
+
+	for _, d := range dir {
+		if _, used := s.writtenFiles[d.Name()]; used {
+			continue
+		}
+
+		filepath := path.Join(s.secretDirectory, d.Name())
+		if err := s.fileManager.Remove(filepath); err != nil {
+			return fmt.Errorf("failed to remove unused secret file %s: %w", filepath, err)
+		}
+	}
 
 	return nil
 }
 
-func isSecretValid(secret *apiv1.Secret) bool {
+// writeFile writes the file atomically: the contents are written to a temporary file, which is renamed afterwards.
+// As a result, NGINX never reads a partially written file.
+func (s *SecretDiskMemoryManagerImpl) writeFile(nsname types.NamespacedName, f secretFile) error {
+	tmpPath := f.path + tmpFileSuffix
+
+	file, err := s.fileManager.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s for secret %s: %w", tmpPath, nsname, err)
+	}
+
+	if err = s.fileManager.Chmod(file, f.mode); err != nil {
+		_ = s.fileManager.Close(file)
+		return fmt.Errorf("failed to change mode of file %s for secret %s: %w", tmpPath, nsname, err)
+	}
+
+	if err = s.fileManager.Write(file, f.contents); err != nil {
+		_ = s.fileManager.Close(file)
+		return fmt.Errorf("failed to write secret %s to file %s: %w", nsname, tmpPath, err)
+	}
+
+	if err = s.fileManager.Close(file); err != nil {
+		return fmt.Errorf("failed to close file %s for secret %s: %w", tmpPath, nsname, err)
+	}
+
+	if err = s.fileManager.Rename(tmpPath, f.path); err != nil {
+		return fmt.Errorf("failed to rename file %s to %s for secret %s: %w", tmpPath, f.path, nsname, err)
+	}
+
+	return nil
+}
+
+// parseTLSSecret parses the leaf certificate of a TLS Secret.
+// Returns false if the Secret is not a TLS Secret or doesn't contain a valid X509 key pair.
+func parseTLSSecret(secret *apiv1.Secret) (*x509.Certificate, bool) {
 	if secret.Type != apiv1.SecretTypeTLS {
-		return false
+		return nil, false
 	}
 
 	// A TLS Secret is guaranteed to have these data fields.
-	_, err := tls.X509KeyPair(secret.Data[apiv1.TLSCertKey], secret.Data[apiv1.TLSPrivateKeyKey])
+	pair, err := tls.X509KeyPair(secret.Data[apiv1.TLSCertKey], secret.Data[apiv1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, false
+	}
 
-	return err == nil
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, false
+	}
+
+	return cert, true
 }
 
-func generateCertAndKeyFileContent(secret *apiv1.Secret) []byte {
-	var res bytes.Buffer
+// validateCertificatePeriod ensures the certificate is within its validity period.
+func validateCertificatePeriod(cert *x509.Certificate, now time.Time) error {
+	if cert == nil {
+		return nil
+	}
 
-	res.Write(secret.Data[apiv1.TLSCertKey])
-	res.WriteString("\n")
-	res.Write(secret.Data[apiv1.TLSPrivateKeyKey])
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
 
-	return res.Bytes()
+	return nil
 }
 
-func generateFilepathForSecret(nsname types.NamespacedName) string {
-	return nsname.Namespace + "_" + nsname.Name
+// validateCABundle ensures the secret includes a CA bundle with at least one PEM-encoded certificate.
+func validateCABundle(secret *apiv1.Secret) error {
+	bundle, ok := secret.Data[CACertKey]
+	if !ok {
+		return fmt.Errorf("must contain %s", CACertKey)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return errors.New("must contain PEM-encoded certificates")
+	}
+
+	return nil
+}
+
+// generateFilenamePrefix generates the prefix of the file name for the contents of a secret.
+// The prefix includes the hash of the contents, so that a changed secret is written to a new file.
+func generateFilenamePrefix(nsname types.NamespacedName, contents ...[]byte) string {
+	h := sha256.New()
+	for _, c := range contents {
+		h.Write(c)
+	}
+
+	return nsname.Namespace + "_" + nsname.Name + "_" + hex.EncodeToString(h.Sum(nil))[:16]
 }
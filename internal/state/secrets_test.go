@@ -2,10 +2,13 @@
 package state_test
 
 import (
+	"crypto/x509"
 	"errors"
 	"io/fs"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,6 +18,7 @@ import (
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status/statusfakes"
 )
 
 var (
@@ -132,6 +136,37 @@ var (
 		},
 		Type: apiv1.SecretTypeTLS,
 	}
+
+	caSecret = &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "ca",
+		},
+		Data: map[string][]byte{
+			state.CACertKey: cert,
+		},
+		Type: apiv1.SecretTypeOpaque,
+	}
+	invalidCASecretNoKey = &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "ca-no-key",
+		},
+		Data: map[string][]byte{
+			"bundle.pem": cert,
+		},
+		Type: apiv1.SecretTypeOpaque,
+	}
+	invalidCASecretCert = &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "ca-invalid-cert",
+		},
+		Data: map[string][]byte{
+			state.CACertKey: invalidCert,
+		},
+		Type: apiv1.SecretTypeOpaque,
+	}
 )
 
 var _ = Describe("SecretDiskMemoryManager", func() {
@@ -155,92 +190,328 @@ var _ = Describe("SecretDiskMemoryManager", func() {
 	})
 
 	Describe("Manages secrets on disk", Ordered, func() {
-		testRequest := func(s *apiv1.Secret, expPath string, expErr bool) {
+		var files1, files2, files3 state.CertificateFiles
+
+		testRequest := func(s *apiv1.Secret, expErr bool) state.CertificateFiles {
 			nsname := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
-			actualPath, err := memMgr.Request(nsname)
+			actualFiles, err := memMgr.Request(nsname)
 
 			if expErr {
 				Expect(err).To(HaveOccurred())
-				Expect(actualPath).To(BeEmpty())
+				Expect(actualFiles).To(BeZero())
 			} else {
 				Expect(err).ToNot(HaveOccurred())
-				Expect(actualPath).To(Equal(expPath))
+
+				prefix := path.Join(tmpSecretsDir, s.Namespace+"_"+s.Name) + `_[0-9a-f]{16}`
+				Expect(actualFiles.Certificate).To(MatchRegexp("^" + prefix + `\.crt$`))
+				Expect(actualFiles.Key).To(Equal(strings.TrimSuffix(actualFiles.Certificate, ".crt") + ".key"))
 			}
+
+			return actualFiles
 		}
 
-		It("should return an error and empty path when secret does not exist", func() {
+		expectFiles := func(expected ...state.CertificateFiles) {
+			expectedFileNames := []string{}
+			for _, f := range expected {
+				expectedFileNames = append(expectedFileNames, path.Base(f.Certificate), path.Base(f.Key))
+			}
+
+			// read all files from directory
+			dir, err := os.ReadDir(tmpSecretsDir)
+			Expect(err).ToNot(HaveOccurred())
+
+			actualFilenames := make([]string, 0, len(dir))
+			for _, d := range dir {
+				actualFilenames = append(actualFilenames, d.Name())
+			}
+
+			Expect(actualFilenames).To(ConsistOf(expectedFileNames))
+		}
+
+		It("should return an error and empty paths when secret does not exist", func() {
 			fakeStore.GetReturns(nil)
 
-			testRequest(secret1, "", true)
+			testRequest(secret1, true)
 		})
-		It("request should return the file path for a valid secret", func() {
+		It("request should return the file paths for a valid secret", func() {
 			fakeStore.GetReturns(&state.Secret{Secret: secret1, Valid: true})
-			expectedPath := path.Join(tmpSecretsDir, "test_secret1")
 
-			testRequest(secret1, expectedPath, false)
+			files1 = testRequest(secret1, false)
 		})
 
-		It("request should return the file path for another valid secret", func() {
+		It("request should return the file paths for another valid secret", func() {
 			fakeStore.GetReturns(&state.Secret{Secret: secret2, Valid: true})
-			expectedPath := path.Join(tmpSecretsDir, "test_secret2")
 
-			testRequest(secret2, expectedPath, false)
+			files2 = testRequest(secret2, false)
 		})
 
-		It("request should return an error and empty path when secret is invalid", func() {
+		It("request should return an error and empty paths when secret is invalid", func() {
 			fakeStore.GetReturns(&state.Secret{Secret: invalidSecretType, Valid: false})
 
-			testRequest(invalidSecretType, "", true)
+			testRequest(invalidSecretType, true)
 		})
 
 		It("should write all requested secrets", func() {
 			err := memMgr.WriteAllRequestedSecrets()
 			Expect(err).ToNot(HaveOccurred())
 
-			expectedFileNames := []string{"test_secret1", "test_secret2"}
+			expectFiles(files1, files2)
 
-			// read all files from directory
-			dir, err := os.ReadDir(tmpSecretsDir)
+			certInfo, err := os.Stat(files1.Certificate)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(certInfo.Mode().Perm()).To(Equal(os.FileMode(0o644)))
 
-			// test that the files exist that we expect
-			Expect(dir).To(HaveLen(2))
-			actualFilenames := []string{dir[0].Name(), dir[1].Name()}
-			Expect(actualFilenames).To(ConsistOf(expectedFileNames))
+			keyInfo, err := os.Stat(files1.Key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keyInfo.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+			contents, err := os.ReadFile(files1.Certificate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(Equal(secret1.Data[apiv1.TLSCertKey]))
+
+			contents, err = os.ReadFile(files1.Key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(Equal(secret1.Data[apiv1.TLSPrivateKeyKey]))
 		})
 
-		It("request should return the file path for secret after write", func() {
+		It("request should return the file paths for secret after write", func() {
 			fakeStore.GetReturns(&state.Secret{Secret: secret3, Valid: true})
-			expectedPath := path.Join(tmpSecretsDir, "test_secret3")
 
-			testRequest(secret3, expectedPath, false)
+			files3 = testRequest(secret3, false)
 		})
 
-		It("should write all requested secrets", func() {
+		It("should write all requested secrets without removing the previously written ones", func() {
 			err := memMgr.WriteAllRequestedSecrets()
 			Expect(err).ToNot(HaveOccurred())
 
-			// read all files from directory
-			dir, err := os.ReadDir(tmpSecretsDir)
+			// the previously written secrets are removed only after NGINX is reloaded
+			expectFiles(files1, files2, files3)
+		})
+
+		It("should remove the secrets not written by the last write", func() {
+			err := memMgr.RemoveUnusedSecrets()
+			Expect(err).ToNot(HaveOccurred())
+
+			// only the secrets stored after the last write should remain on disk.
+			expectFiles(files3)
+		})
+
+		It("should not rewrite the existing files of an unchanged secret", func() {
+			// modify the file on disk to detect a rewrite
+			Expect(os.WriteFile(files3.Certificate, []byte("unchanged"), 0o644)).To(Succeed())
+
+			fakeStore.GetReturns(&state.Secret{Secret: secret3, Valid: true})
+			Expect(testRequest(secret3, false)).To(Equal(files3))
+
+			err := memMgr.WriteAllRequestedSecrets()
 			Expect(err).ToNot(HaveOccurred())
 
-			// only the secrets stored after the last write should be written to disk.
-			Expect(dir).To(HaveLen(1))
-			Expect(dir[0].Name()).To(Equal("test_secret3"))
+			contents, err := os.ReadFile(files3.Certificate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("unchanged"))
 		})
+
+		It("should write a changed secret to new files", func() {
+			changed := secret3.DeepCopy()
+			changed.Data[apiv1.TLSCertKey] = append(changed.Data[apiv1.TLSCertKey], '\n')
+
+			fakeStore.GetReturns(&state.Secret{Secret: changed, Valid: true})
+
+			changedFiles := testRequest(changed, false)
+			Expect(changedFiles.Certificate).ToNot(Equal(files3.Certificate))
+			Expect(changedFiles.Key).ToNot(Equal(files3.Key))
+
+			Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+			expectFiles(files3, changedFiles)
+
+			Expect(memMgr.RemoveUnusedSecrets()).To(Succeed())
+			expectFiles(changedFiles)
+		})
+
 		When("no secrets are requested", func() {
-			It("write all secrets should remove all existing secrets and write no additional secrets", func() {
+			It("write all secrets and removing unused secrets should remove all existing secrets", func() {
 				err := memMgr.WriteAllRequestedSecrets()
 				Expect(err).ToNot(HaveOccurred())
 
-				// read all files from directory
-				dir, err := os.ReadDir(tmpSecretsDir)
-				Expect(err).ToNot(HaveOccurred())
+				Expect(memMgr.RemoveUnusedSecrets()).To(Succeed())
 
 				// no secrets should exist
-				Expect(dir).To(BeEmpty())
+				expectFiles()
+			})
+		})
+	})
+	Describe("Manages CA bundles on disk", Ordered, func() {
+		var caPath string
+
+		testRequestCA := func(s *apiv1.Secret, expErr bool) string {
+			nsname := types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+			actualPath, err := memMgr.RequestCA(nsname)
+
+			if expErr {
+				Expect(err).To(HaveOccurred())
+				Expect(actualPath).To(BeEmpty())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actualPath).To(MatchRegexp("^" + path.Join(tmpSecretsDir, "test_ca") + `_[0-9a-f]{16}_ca\.crt$`))
+			}
+
+			return actualPath
+		}
+
+		It("should return an error and empty path when secret does not exist", func() {
+			fakeStore.GetReturns(nil)
+
+			testRequestCA(caSecret, true)
+		})
+		It("should return an error and empty path when secret has no CA bundle", func() {
+			fakeStore.GetReturns(&state.Secret{Secret: invalidCASecretNoKey})
+
+			testRequestCA(invalidCASecretNoKey, true)
+		})
+		It("should return an error and empty path when CA bundle is invalid", func() {
+			fakeStore.GetReturns(&state.Secret{Secret: invalidCASecretCert})
+
+			testRequestCA(invalidCASecretCert, true)
+		})
+		It("should return the file path for a valid CA bundle", func() {
+			fakeStore.GetReturns(&state.Secret{Secret: caSecret})
+
+			caPath = testRequestCA(caSecret, false)
+		})
+		It("should write the requested CA bundles along with the requested secrets", func() {
+			fakeStore.GetReturns(&state.Secret{Secret: secret1, Valid: true})
+
+			nsname := types.NamespacedName{Namespace: secret1.Namespace, Name: secret1.Name}
+			files, err := memMgr.Request(nsname)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = memMgr.WriteAllRequestedSecrets()
+			Expect(err).ToNot(HaveOccurred())
+
+			dir, err := os.ReadDir(tmpSecretsDir)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(dir).To(HaveLen(3))
+			actualFilenames := []string{dir[0].Name(), dir[1].Name(), dir[2].Name()}
+			Expect(actualFilenames).To(ConsistOf(path.Base(caPath), path.Base(files.Certificate), path.Base(files.Key)))
+
+			info, err := os.Stat(caPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o644)))
+
+			contents, err := os.ReadFile(caPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(Equal(cert))
+		})
+	})
+	Describe("Checks the validity period of certificates", func() {
+		var (
+			fakeStore      *statefakes.FakeSecretStore
+			fakeClock      *statusfakes.FakeClock
+			recorder       *fakeExpiryRecorder
+			memMgr         *state.SecretDiskMemoryManagerImpl
+			secretNsName   types.NamespacedName
+			now, notBefore time.Time
+			notAfter       time.Time
+		)
+
+		BeforeEach(func() {
+			fakeStore = &statefakes.FakeSecretStore{}
+			fakeClock = &statusfakes.FakeClock{}
+			recorder = &fakeExpiryRecorder{}
+			memMgr = state.NewSecretDiskMemoryManager(
+				tmpSecretsDir,
+				fakeStore,
+				state.WithSecretNowFunc(fakeClock.Now),
+				state.WithCertificateExpiryRecorder(recorder),
+			)
+
+			secretNsName = types.NamespacedName{Namespace: secret1.Namespace, Name: secret1.Name}
+
+			now = time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+			notBefore = now.Add(-24 * time.Hour)
+			notAfter = now.Add(24 * time.Hour)
+
+			fakeStore.GetReturns(&state.Secret{
+				Secret: secret1,
+				Valid:  true,
+				Certificate: &x509.Certificate{
+					NotBefore: notBefore,
+					NotAfter:  notAfter,
+				},
 			})
 		})
+
+		It("should return the paths and record the expiry for a certificate within its validity period", func() {
+			fakeClock.NowReturns(metav1.NewTime(now))
+
+			actualFiles, err := memMgr.Request(secretNsName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualFiles).ToNot(BeZero())
+			Expect(memMgr.GetCertificate(secretNsName).NotAfter).To(Equal(notAfter))
+
+			Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+			Expect(recorder.expiries).To(Equal(map[types.NamespacedName]time.Time{secretNsName: notAfter}))
+		})
+
+		It("should return an error for an expired certificate", func() {
+			fakeClock.NowReturns(metav1.NewTime(notAfter.Add(time.Second)))
+
+			actualFiles, err := memMgr.Request(secretNsName)
+			Expect(err).To(MatchError(ContainSubstring("certificate expired at 2022-10-02T00:00:00Z")))
+			Expect(actualFiles).To(BeZero())
+			Expect(memMgr.GetCertificate(secretNsName)).To(BeNil())
+		})
+
+		It("should return an error for a certificate that is not yet valid", func() {
+			fakeClock.NowReturns(metav1.NewTime(notBefore.Add(-time.Second)))
+
+			actualFiles, err := memMgr.Request(secretNsName)
+			Expect(err).To(MatchError(ContainSubstring("certificate is not valid before 2022-09-30T00:00:00Z")))
+			Expect(actualFiles).To(BeZero())
+		})
+
+		DescribeTable("next certificate validity change",
+			func(now, expected time.Time) {
+				fakeClock.NowReturns(metav1.NewTime(now))
+
+				_, _ = memMgr.Request(secretNsName)
+				Expect(memMgr.NextCertificateValidityChange()).To(Equal(expected))
+
+				Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+				Expect(memMgr.NextCertificateValidityChange()).To(BeZero())
+			},
+			Entry("valid certificate expires after NotAfter",
+				time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 10, 2, 0, 0, 1, 0, time.UTC)),
+			Entry("not yet valid certificate becomes valid at NotBefore",
+				time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)),
+			Entry("expired certificate never changes",
+				time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+				time.Time{}),
+		)
+
+		It("should return the earliest validity change of the requested certificates", func() {
+			fakeClock.NowReturns(metav1.NewTime(now))
+
+			_, err := memMgr.Request(secretNsName)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeStore.GetReturns(&state.Secret{
+				Secret: secret2,
+				Valid:  true,
+				Certificate: &x509.Certificate{
+					NotBefore: notBefore,
+					NotAfter:  now.Add(time.Hour),
+				},
+			})
+
+			_, err = memMgr.Request(types.NamespacedName{Namespace: secret2.Namespace, Name: secret2.Name})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(memMgr.NextCertificateValidityChange()).To(Equal(now.Add(time.Hour + time.Second)))
+		})
 	})
 	Describe("Write all requested secrets", func() {
 		var (
@@ -264,23 +535,22 @@ var _ = Describe("SecretDiskMemoryManager", func() {
 
 		DescribeTable("error cases", Ordered,
 			func(e error, preparer func(e error)) {
+				// a failed write resets the requests
+				_, err := memMgr.Request(types.NamespacedName{Namespace: secret1.Namespace, Name: secret1.Name})
+				Expect(err).ToNot(HaveOccurred())
+
 				preparer(e)
 
-				err := memMgr.WriteAllRequestedSecrets()
+				err = memMgr.WriteAllRequestedSecrets()
 				Expect(err).To(MatchError(e))
 			},
 			Entry("read directory error", errors.New("read dir"),
 				func(e error) {
 					fakeFileManager.ReadDirReturns(nil, e)
 				}),
-			Entry("remove file error", errors.New("remove file"),
-				func(e error) {
-					fakeFileManager.ReadDirReturns(fakeDirEntries, nil)
-					fakeFileManager.RemoveReturns(e)
-				}),
 			Entry("create file error", errors.New("create error"),
 				func(e error) {
-					fakeFileManager.RemoveReturns(nil)
+					fakeFileManager.ReadDirReturns(fakeDirEntries, nil)
 					fakeFileManager.CreateReturns(nil, e)
 				}),
 			Entry("chmod error", errors.New("chmod"),
@@ -293,10 +563,80 @@ var _ = Describe("SecretDiskMemoryManager", func() {
 					fakeFileManager.ChmodReturns(nil)
 					fakeFileManager.WriteReturns(e)
 				}),
+			Entry("close error", errors.New("close"),
+				func(e error) {
+					fakeFileManager.WriteReturns(nil)
+					fakeFileManager.CloseReturns(e)
+				}),
+			Entry("rename error", errors.New("rename"),
+				func(e error) {
+					fakeFileManager.CloseReturns(nil)
+					fakeFileManager.RenameReturns(e)
+				}),
+		)
+
+		It("should write a file to a temporary file and rename it", func() {
+			Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+
+			Expect(fakeFileManager.CreateCallCount()).To(Equal(2))
+			Expect(fakeFileManager.RenameCallCount()).To(Equal(2))
+
+			for i := 0; i < 2; i++ {
+				tmpPath, newPath := fakeFileManager.RenameArgsForCall(i)
+				Expect(fakeFileManager.CreateArgsForCall(i)).To(Equal(tmpPath))
+				Expect(tmpPath).To(Equal(newPath + ".tmp"))
+			}
+		})
+
+		It("should reset the requested secrets when writing fails", func() {
+			fakeFileManager.ReadDirReturnsOnCall(0, nil, errors.New("read dir"))
+
+			Expect(memMgr.WriteAllRequestedSecrets()).ToNot(Succeed())
+			Expect(memMgr.NextCertificateValidityChange()).To(BeZero())
+
+			fakeFileManager.ReadDirReturns(fakeDirEntries, nil)
+
+			Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+			Expect(fakeFileManager.CreateCallCount()).To(BeZero())
+		})
+
+		It("should not remove files before secrets are written", func() {
+			Expect(memMgr.RemoveUnusedSecrets()).To(Succeed())
+
+			Expect(fakeFileManager.ReadDirCallCount()).To(BeZero())
+			Expect(fakeFileManager.RemoveCallCount()).To(BeZero())
+		})
+
+		DescribeTable("remove unused secrets error cases", Ordered,
+			func(e error, preparer func(e error)) {
+				Expect(memMgr.WriteAllRequestedSecrets()).To(Succeed())
+
+				preparer(e)
+
+				err := memMgr.RemoveUnusedSecrets()
+				Expect(err).To(MatchError(e))
+			},
+			Entry("read directory error", errors.New("read dir"),
+				func(e error) {
+					fakeFileManager.ReadDirReturnsOnCall(1, nil, e)
+				}),
+			Entry("remove file error", errors.New("remove file"),
+				func(e error) {
+					fakeFileManager.ReadDirReturns(fakeDirEntries, nil)
+					fakeFileManager.RemoveReturns(e)
+				}),
 		)
 	})
 })
 
+type fakeExpiryRecorder struct {
+	expiries map[types.NamespacedName]time.Time
+}
+
+func (r *fakeExpiryRecorder) RecordCertificateExpiries(expiries map[types.NamespacedName]time.Time) {
+	r.expiries = expiries
+}
+
 var _ = Describe("SecretStore", func() {
 	var store state.SecretStore
 	var invalidToValidSecret, validToInvalidSecret *apiv1.Secret
@@ -318,8 +658,10 @@ var _ = Describe("SecretStore", func() {
 			actualSecret := store.Get(nsname)
 			if valid {
 				Expect(actualSecret.Valid).To(BeTrue())
+				Expect(actualSecret.Certificate).ToNot(BeNil())
 			} else {
 				Expect(actualSecret.Valid).To(BeFalse())
+				Expect(actualSecret.Certificate).To(BeNil())
 			}
 			Expect(actualSecret.Secret).To(Equal(s))
 		}
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func sortMatchRules(matchRules []MatchRule) {
@@ -61,6 +62,41 @@ func higherPriority(rule1, rule2 MatchRule) bool {
 	return lessObjectMeta(&rule1.Source.ObjectMeta, &rule2.Source.ObjectMeta)
 }
 
+// sortGRPCMatchRules sorts the gRPC routing rules the same way sortMatchRules sorts the HTTP routing rules.
+// The match with the largest number of header matches wins. If still tied, the match of the oldest route wins.
+func sortGRPCMatchRules(matchRules []GRPCMatchRule) {
+	sort.SliceStable(
+		matchRules, func(i, j int) bool {
+			l1 := len(matchRules[i].GetMatch().Headers)
+			l2 := len(matchRules[j].GetMatch().Headers)
+
+			if l1 != l2 {
+				return l1 > l2
+			}
+
+			return lessObjectMeta(&matchRules[i].Source.ObjectMeta, &matchRules[j].Source.ObjectMeta)
+		},
+	)
+}
+
+// sortGRPCMethodRules sorts the gRPC method rules by their precedence from the spec: the rule with the largest
+// number of characters in the service wins, then the rule with the largest number of characters in the method.
+// NGINX checks the locations of the rules without a service or a method in the order of the configuration.
+func sortGRPCMethodRules(rules []GRPCMethodRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].Service) != len(rules[j].Service) {
+			return len(rules[i].Service) > len(rules[j].Service)
+		}
+		if len(rules[i].Method) != len(rules[j].Method) {
+			return len(rules[i].Method) > len(rules[j].Method)
+		}
+		if rules[i].Service != rules[j].Service {
+			return rules[i].Service < rules[j].Service
+		}
+		return rules[i].Method < rules[j].Method
+	})
+}
+
 func lessObjectMeta(meta1 *metav1.ObjectMeta, meta2 *metav1.ObjectMeta) bool {
 	if meta1.CreationTimestamp.Equal(&meta2.CreationTimestamp) {
 		if meta1.Namespace == meta2.Namespace {
@@ -71,3 +107,19 @@ func lessObjectMeta(meta1 *metav1.ObjectMeta, meta2 *metav1.ObjectMeta) bool {
 
 	return meta1.CreationTimestamp.Before(&meta2.CreationTimestamp)
 }
+
+// lessObject is the same as lessObjectMeta for objects of any kind.
+func lessObject(obj1 client.Object, obj2 client.Object) bool {
+	meta1 := metav1.ObjectMeta{
+		Namespace:         obj1.GetNamespace(),
+		Name:              obj1.GetName(),
+		CreationTimestamp: obj1.GetCreationTimestamp(),
+	}
+	meta2 := metav1.ObjectMeta{
+		Namespace:         obj2.GetNamespace(),
+		Name:              obj2.GetName(),
+		CreationTimestamp: obj2.GetCreationTimestamp(),
+	}
+
+	return lessObjectMeta(&meta1, &meta2)
+}
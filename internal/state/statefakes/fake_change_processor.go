@@ -10,6 +10,10 @@ import (
 )
 
 type FakeChangeProcessor struct {
+	CaptureCertificateValidityChangeStub        func()
+	captureCertificateValidityChangeMutex       sync.RWMutex
+	captureCertificateValidityChangeArgsForCall []struct {
+	}
 	CaptureDeleteChangeStub        func(client.Object, types.NamespacedName)
 	captureDeleteChangeMutex       sync.RWMutex
 	captureDeleteChangeArgsForCall []struct {
@@ -39,6 +43,30 @@ type FakeChangeProcessor struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeChangeProcessor) CaptureCertificateValidityChange() {
+	fake.captureCertificateValidityChangeMutex.Lock()
+	fake.captureCertificateValidityChangeArgsForCall = append(fake.captureCertificateValidityChangeArgsForCall, struct {
+	}{})
+	stub := fake.CaptureCertificateValidityChangeStub
+	fake.recordInvocation("CaptureCertificateValidityChange", []interface{}{})
+	fake.captureCertificateValidityChangeMutex.Unlock()
+	if stub != nil {
+		fake.CaptureCertificateValidityChangeStub()
+	}
+}
+
+func (fake *FakeChangeProcessor) CaptureCertificateValidityChangeCallCount() int {
+	fake.captureCertificateValidityChangeMutex.RLock()
+	defer fake.captureCertificateValidityChangeMutex.RUnlock()
+	return len(fake.captureCertificateValidityChangeArgsForCall)
+}
+
+func (fake *FakeChangeProcessor) CaptureCertificateValidityChangeCalls(stub func()) {
+	fake.captureCertificateValidityChangeMutex.Lock()
+	defer fake.captureCertificateValidityChangeMutex.Unlock()
+	fake.CaptureCertificateValidityChangeStub = stub
+}
+
 func (fake *FakeChangeProcessor) CaptureDeleteChange(arg1 client.Object, arg2 types.NamespacedName) {
 	fake.captureDeleteChangeMutex.Lock()
 	fake.captureDeleteChangeArgsForCall = append(fake.captureDeleteChangeArgsForCall, struct {
@@ -166,6 +194,8 @@ func (fake *FakeChangeProcessor) ProcessReturnsOnCall(i int, result1 bool, resul
 func (fake *FakeChangeProcessor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.captureCertificateValidityChangeMutex.RLock()
+	defer fake.captureCertificateValidityChangeMutex.RUnlock()
 	fake.captureDeleteChangeMutex.RLock()
 	defer fake.captureDeleteChangeMutex.RUnlock()
 	fake.captureUpsertChangeMutex.RLock()
@@ -10,11 +10,11 @@ import (
 )
 
 type FakeFileManager struct {
-	ChmodStub        func(*os.File, fs.FileMode) error
+	ChmodStub        func(*os.File, os.FileMode) error
 	chmodMutex       sync.RWMutex
 	chmodArgsForCall []struct {
 		arg1 *os.File
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}
 	chmodReturns struct {
 		result1 error
@@ -22,6 +22,17 @@ type FakeFileManager struct {
 	chmodReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CloseStub        func(*os.File) error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+		arg1 *os.File
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateStub        func(string) (*os.File, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
@@ -59,6 +70,18 @@ type FakeFileManager struct {
 	removeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RenameStub        func(string, string) error
+	renameMutex       sync.RWMutex
+	renameArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	renameReturns struct {
+		result1 error
+	}
+	renameReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteStub        func(*os.File, []byte) error
 	writeMutex       sync.RWMutex
 	writeArgsForCall []struct {
@@ -75,12 +98,12 @@ type FakeFileManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFileManager) Chmod(arg1 *os.File, arg2 fs.FileMode) error {
+func (fake *FakeFileManager) Chmod(arg1 *os.File, arg2 os.FileMode) error {
 	fake.chmodMutex.Lock()
 	ret, specificReturn := fake.chmodReturnsOnCall[len(fake.chmodArgsForCall)]
 	fake.chmodArgsForCall = append(fake.chmodArgsForCall, struct {
 		arg1 *os.File
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}{arg1, arg2})
 	stub := fake.ChmodStub
 	fakeReturns := fake.chmodReturns
@@ -101,13 +124,13 @@ func (fake *FakeFileManager) ChmodCallCount() int {
 	return len(fake.chmodArgsForCall)
 }
 
-func (fake *FakeFileManager) ChmodCalls(stub func(*os.File, fs.FileMode) error) {
+func (fake *FakeFileManager) ChmodCalls(stub func(*os.File, os.FileMode) error) {
 	fake.chmodMutex.Lock()
 	defer fake.chmodMutex.Unlock()
 	fake.ChmodStub = stub
 }
 
-func (fake *FakeFileManager) ChmodArgsForCall(i int) (*os.File, fs.FileMode) {
+func (fake *FakeFileManager) ChmodArgsForCall(i int) (*os.File, os.FileMode) {
 	fake.chmodMutex.RLock()
 	defer fake.chmodMutex.RUnlock()
 	argsForCall := fake.chmodArgsForCall[i]
@@ -137,6 +160,67 @@ func (fake *FakeFileManager) ChmodReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFileManager) Close(arg1 *os.File) error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+		arg1 *os.File
+	}{arg1})
+	stub := fake.CloseStub
+	fakeReturns := fake.closeReturns
+	fake.recordInvocation("Close", []interface{}{arg1})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManager) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeFileManager) CloseCalls(stub func(*os.File) error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeFileManager) CloseArgsForCall(i int) *os.File {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	argsForCall := fake.closeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileManager) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileManager) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileManager) Create(arg1 string) (*os.File, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
@@ -326,6 +410,68 @@ func (fake *FakeFileManager) RemoveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFileManager) Rename(arg1 string, arg2 string) error {
+	fake.renameMutex.Lock()
+	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
+	fake.renameArgsForCall = append(fake.renameArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RenameStub
+	fakeReturns := fake.renameReturns
+	fake.recordInvocation("Rename", []interface{}{arg1, arg2})
+	fake.renameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManager) RenameCallCount() int {
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
+	return len(fake.renameArgsForCall)
+}
+
+func (fake *FakeFileManager) RenameCalls(stub func(string, string) error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = stub
+}
+
+func (fake *FakeFileManager) RenameArgsForCall(i int) (string, string) {
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
+	argsForCall := fake.renameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileManager) RenameReturns(result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	fake.renameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileManager) RenameReturnsOnCall(i int, result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	if fake.renameReturnsOnCall == nil {
+		fake.renameReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renameReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileManager) Write(arg1 *os.File, arg2 []byte) error {
 	var arg2Copy []byte
 	if arg2 != nil {
@@ -398,12 +544,16 @@ func (fake *FakeFileManager) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.chmodMutex.RLock()
 	defer fake.chmodMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	fake.readDirMutex.RLock()
 	defer fake.readDirMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
 	fake.writeMutex.RLock()
 	defer fake.writeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
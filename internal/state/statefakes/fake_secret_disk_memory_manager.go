@@ -2,23 +2,69 @@
 package statefakes
 
 import (
+	"crypto/x509"
 	"sync"
+	"time"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 type FakeSecretDiskMemoryManager struct {
-	RequestStub        func(types.NamespacedName) (string, error)
+	GetCertificateStub        func(types.NamespacedName) *x509.Certificate
+	getCertificateMutex       sync.RWMutex
+	getCertificateArgsForCall []struct {
+		arg1 types.NamespacedName
+	}
+	getCertificateReturns struct {
+		result1 *x509.Certificate
+	}
+	getCertificateReturnsOnCall map[int]struct {
+		result1 *x509.Certificate
+	}
+	NextCertificateValidityChangeStub        func() time.Time
+	nextCertificateValidityChangeMutex       sync.RWMutex
+	nextCertificateValidityChangeArgsForCall []struct {
+	}
+	nextCertificateValidityChangeReturns struct {
+		result1 time.Time
+	}
+	nextCertificateValidityChangeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	RemoveUnusedSecretsStub        func() error
+	removeUnusedSecretsMutex       sync.RWMutex
+	removeUnusedSecretsArgsForCall []struct {
+	}
+	removeUnusedSecretsReturns struct {
+		result1 error
+	}
+	removeUnusedSecretsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RequestStub        func(types.NamespacedName) (state.CertificateFiles, error)
 	requestMutex       sync.RWMutex
 	requestArgsForCall []struct {
 		arg1 types.NamespacedName
 	}
 	requestReturns struct {
-		result1 string
+		result1 state.CertificateFiles
 		result2 error
 	}
 	requestReturnsOnCall map[int]struct {
+		result1 state.CertificateFiles
+		result2 error
+	}
+	RequestCAStub        func(types.NamespacedName) (string, error)
+	requestCAMutex       sync.RWMutex
+	requestCAArgsForCall []struct {
+		arg1 types.NamespacedName
+	}
+	requestCAReturns struct {
+		result1 string
+		result2 error
+	}
+	requestCAReturnsOnCall map[int]struct {
 		result1 string
 		result2 error
 	}
@@ -36,7 +82,174 @@ type FakeSecretDiskMemoryManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSecretDiskMemoryManager) Request(arg1 types.NamespacedName) (string, error) {
+func (fake *FakeSecretDiskMemoryManager) GetCertificate(arg1 types.NamespacedName) *x509.Certificate {
+	fake.getCertificateMutex.Lock()
+	ret, specificReturn := fake.getCertificateReturnsOnCall[len(fake.getCertificateArgsForCall)]
+	fake.getCertificateArgsForCall = append(fake.getCertificateArgsForCall, struct {
+		arg1 types.NamespacedName
+	}{arg1})
+	stub := fake.GetCertificateStub
+	fakeReturns := fake.getCertificateReturns
+	fake.recordInvocation("GetCertificate", []interface{}{arg1})
+	fake.getCertificateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretDiskMemoryManager) GetCertificateCallCount() int {
+	fake.getCertificateMutex.RLock()
+	defer fake.getCertificateMutex.RUnlock()
+	return len(fake.getCertificateArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) GetCertificateCalls(stub func(types.NamespacedName) *x509.Certificate) {
+	fake.getCertificateMutex.Lock()
+	defer fake.getCertificateMutex.Unlock()
+	fake.GetCertificateStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) GetCertificateArgsForCall(i int) types.NamespacedName {
+	fake.getCertificateMutex.RLock()
+	defer fake.getCertificateMutex.RUnlock()
+	argsForCall := fake.getCertificateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretDiskMemoryManager) GetCertificateReturns(result1 *x509.Certificate) {
+	fake.getCertificateMutex.Lock()
+	defer fake.getCertificateMutex.Unlock()
+	fake.GetCertificateStub = nil
+	fake.getCertificateReturns = struct {
+		result1 *x509.Certificate
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) GetCertificateReturnsOnCall(i int, result1 *x509.Certificate) {
+	fake.getCertificateMutex.Lock()
+	defer fake.getCertificateMutex.Unlock()
+	fake.GetCertificateStub = nil
+	if fake.getCertificateReturnsOnCall == nil {
+		fake.getCertificateReturnsOnCall = make(map[int]struct {
+			result1 *x509.Certificate
+		})
+	}
+	fake.getCertificateReturnsOnCall[i] = struct {
+		result1 *x509.Certificate
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) NextCertificateValidityChange() time.Time {
+	fake.nextCertificateValidityChangeMutex.Lock()
+	ret, specificReturn := fake.nextCertificateValidityChangeReturnsOnCall[len(fake.nextCertificateValidityChangeArgsForCall)]
+	fake.nextCertificateValidityChangeArgsForCall = append(fake.nextCertificateValidityChangeArgsForCall, struct {
+	}{})
+	stub := fake.NextCertificateValidityChangeStub
+	fakeReturns := fake.nextCertificateValidityChangeReturns
+	fake.recordInvocation("NextCertificateValidityChange", []interface{}{})
+	fake.nextCertificateValidityChangeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretDiskMemoryManager) NextCertificateValidityChangeCallCount() int {
+	fake.nextCertificateValidityChangeMutex.RLock()
+	defer fake.nextCertificateValidityChangeMutex.RUnlock()
+	return len(fake.nextCertificateValidityChangeArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) NextCertificateValidityChangeCalls(stub func() time.Time) {
+	fake.nextCertificateValidityChangeMutex.Lock()
+	defer fake.nextCertificateValidityChangeMutex.Unlock()
+	fake.NextCertificateValidityChangeStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) NextCertificateValidityChangeReturns(result1 time.Time) {
+	fake.nextCertificateValidityChangeMutex.Lock()
+	defer fake.nextCertificateValidityChangeMutex.Unlock()
+	fake.NextCertificateValidityChangeStub = nil
+	fake.nextCertificateValidityChangeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) NextCertificateValidityChangeReturnsOnCall(i int, result1 time.Time) {
+	fake.nextCertificateValidityChangeMutex.Lock()
+	defer fake.nextCertificateValidityChangeMutex.Unlock()
+	fake.NextCertificateValidityChangeStub = nil
+	if fake.nextCertificateValidityChangeReturnsOnCall == nil {
+		fake.nextCertificateValidityChangeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextCertificateValidityChangeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) RemoveUnusedSecrets() error {
+	fake.removeUnusedSecretsMutex.Lock()
+	ret, specificReturn := fake.removeUnusedSecretsReturnsOnCall[len(fake.removeUnusedSecretsArgsForCall)]
+	fake.removeUnusedSecretsArgsForCall = append(fake.removeUnusedSecretsArgsForCall, struct {
+	}{})
+	stub := fake.RemoveUnusedSecretsStub
+	fakeReturns := fake.removeUnusedSecretsReturns
+	fake.recordInvocation("RemoveUnusedSecrets", []interface{}{})
+	fake.removeUnusedSecretsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretDiskMemoryManager) RemoveUnusedSecretsCallCount() int {
+	fake.removeUnusedSecretsMutex.RLock()
+	defer fake.removeUnusedSecretsMutex.RUnlock()
+	return len(fake.removeUnusedSecretsArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) RemoveUnusedSecretsCalls(stub func() error) {
+	fake.removeUnusedSecretsMutex.Lock()
+	defer fake.removeUnusedSecretsMutex.Unlock()
+	fake.RemoveUnusedSecretsStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) RemoveUnusedSecretsReturns(result1 error) {
+	fake.removeUnusedSecretsMutex.Lock()
+	defer fake.removeUnusedSecretsMutex.Unlock()
+	fake.RemoveUnusedSecretsStub = nil
+	fake.removeUnusedSecretsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) RemoveUnusedSecretsReturnsOnCall(i int, result1 error) {
+	fake.removeUnusedSecretsMutex.Lock()
+	defer fake.removeUnusedSecretsMutex.Unlock()
+	fake.RemoveUnusedSecretsStub = nil
+	if fake.removeUnusedSecretsReturnsOnCall == nil {
+		fake.removeUnusedSecretsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeUnusedSecretsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) Request(arg1 types.NamespacedName) (state.CertificateFiles, error) {
 	fake.requestMutex.Lock()
 	ret, specificReturn := fake.requestReturnsOnCall[len(fake.requestArgsForCall)]
 	fake.requestArgsForCall = append(fake.requestArgsForCall, struct {
@@ -61,7 +274,7 @@ func (fake *FakeSecretDiskMemoryManager) RequestCallCount() int {
 	return len(fake.requestArgsForCall)
 }
 
-func (fake *FakeSecretDiskMemoryManager) RequestCalls(stub func(types.NamespacedName) (string, error)) {
+func (fake *FakeSecretDiskMemoryManager) RequestCalls(stub func(types.NamespacedName) (state.CertificateFiles, error)) {
 	fake.requestMutex.Lock()
 	defer fake.requestMutex.Unlock()
 	fake.RequestStub = stub
@@ -74,27 +287,91 @@ func (fake *FakeSecretDiskMemoryManager) RequestArgsForCall(i int) types.Namespa
 	return argsForCall.arg1
 }
 
-func (fake *FakeSecretDiskMemoryManager) RequestReturns(result1 string, result2 error) {
+func (fake *FakeSecretDiskMemoryManager) RequestReturns(result1 state.CertificateFiles, result2 error) {
 	fake.requestMutex.Lock()
 	defer fake.requestMutex.Unlock()
 	fake.RequestStub = nil
 	fake.requestReturns = struct {
-		result1 string
+		result1 state.CertificateFiles
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeSecretDiskMemoryManager) RequestReturnsOnCall(i int, result1 string, result2 error) {
+func (fake *FakeSecretDiskMemoryManager) RequestReturnsOnCall(i int, result1 state.CertificateFiles, result2 error) {
 	fake.requestMutex.Lock()
 	defer fake.requestMutex.Unlock()
 	fake.RequestStub = nil
 	if fake.requestReturnsOnCall == nil {
 		fake.requestReturnsOnCall = make(map[int]struct {
-			result1 string
+			result1 state.CertificateFiles
 			result2 error
 		})
 	}
 	fake.requestReturnsOnCall[i] = struct {
+		result1 state.CertificateFiles
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCA(arg1 types.NamespacedName) (string, error) {
+	fake.requestCAMutex.Lock()
+	ret, specificReturn := fake.requestCAReturnsOnCall[len(fake.requestCAArgsForCall)]
+	fake.requestCAArgsForCall = append(fake.requestCAArgsForCall, struct {
+		arg1 types.NamespacedName
+	}{arg1})
+	stub := fake.RequestCAStub
+	fakeReturns := fake.requestCAReturns
+	fake.recordInvocation("RequestCA", []interface{}{arg1})
+	fake.requestCAMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCACallCount() int {
+	fake.requestCAMutex.RLock()
+	defer fake.requestCAMutex.RUnlock()
+	return len(fake.requestCAArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCACalls(stub func(types.NamespacedName) (string, error)) {
+	fake.requestCAMutex.Lock()
+	defer fake.requestCAMutex.Unlock()
+	fake.RequestCAStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCAArgsForCall(i int) types.NamespacedName {
+	fake.requestCAMutex.RLock()
+	defer fake.requestCAMutex.RUnlock()
+	argsForCall := fake.requestCAArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCAReturns(result1 string, result2 error) {
+	fake.requestCAMutex.Lock()
+	defer fake.requestCAMutex.Unlock()
+	fake.RequestCAStub = nil
+	fake.requestCAReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCAReturnsOnCall(i int, result1 string, result2 error) {
+	fake.requestCAMutex.Lock()
+	defer fake.requestCAMutex.Unlock()
+	fake.RequestCAStub = nil
+	if fake.requestCAReturnsOnCall == nil {
+		fake.requestCAReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.requestCAReturnsOnCall[i] = struct {
 		result1 string
 		result2 error
 	}{result1, result2}
@@ -156,8 +433,16 @@ func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecretsReturnsOnCall(i
 func (fake *FakeSecretDiskMemoryManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getCertificateMutex.RLock()
+	defer fake.getCertificateMutex.RUnlock()
+	fake.nextCertificateValidityChangeMutex.RLock()
+	defer fake.nextCertificateValidityChangeMutex.RUnlock()
+	fake.removeUnusedSecretsMutex.RLock()
+	defer fake.removeUnusedSecretsMutex.RUnlock()
 	fake.requestMutex.RLock()
 	defer fake.requestMutex.RUnlock()
+	fake.requestCAMutex.RLock()
+	defer fake.requestCAMutex.RUnlock()
 	fake.writeAllRequestedSecretsMutex.RLock()
 	defer fake.writeAllRequestedSecretsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -1,33 +1,49 @@
 package state
 
-import "k8s.io/apimachinery/pkg/types"
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
 
 // ListenerStatuses holds the statuses of listeners where the key is the name of a listener in the Gateway resource.
 type ListenerStatuses map[string]ListenerStatus
 
+// GatewayStatuses holds the statuses of Gateways where the key is the namespaced name of a Gateway.
+type GatewayStatuses map[types.NamespacedName]GatewayStatus
+
 // HTTPRouteStatuses holds the statuses of HTTPRoutes where the key is the namespaced name of an HTTPRoute.
 type HTTPRouteStatuses map[types.NamespacedName]HTTPRouteStatus
 
+// GRPCRouteStatuses holds the statuses of GRPCRoutes where the key is the namespaced name of a GRPCRoute.
+type GRPCRouteStatuses map[types.NamespacedName]GRPCRouteStatus
+
+// TLSRouteStatuses holds the statuses of TLSRoutes where the key is the namespaced name of a TLSRoute.
+type TLSRouteStatuses map[types.NamespacedName]TLSRouteStatus
+
+// TCPRouteStatuses holds the statuses of TCPRoutes where the key is the namespaced name of a TCPRoute.
+type TCPRouteStatuses map[types.NamespacedName]TCPRouteStatus
+
+// UDPRouteStatuses holds the statuses of UDPRoutes where the key is the namespaced name of a UDPRoute.
+type UDPRouteStatuses map[types.NamespacedName]UDPRouteStatus
+
 // Statuses holds the status-related information about Gateway API resources.
 type Statuses struct {
-	GatewayClassStatus     *GatewayClassStatus
-	GatewayStatus          *GatewayStatus
-	IgnoredGatewayStatuses IgnoredGatewayStatuses
-	HTTPRouteStatuses      HTTPRouteStatuses
+	GatewayClassStatus *GatewayClassStatus
+	GatewayStatuses    GatewayStatuses
+	HTTPRouteStatuses  HTTPRouteStatuses
+	GRPCRouteStatuses  GRPCRouteStatuses
+	TLSRouteStatuses   TLSRouteStatuses
+	TCPRouteStatuses   TCPRouteStatuses
+	UDPRouteStatuses   UDPRouteStatuses
 }
 
-// GatewayStatus holds the status of the winning Gateway resource.
+// GatewayStatus holds the status of a Gateway resource.
 type GatewayStatus struct {
-	NsName           types.NamespacedName
 	ListenerStatuses ListenerStatuses
-}
-
-// IgnoredGatewayStatuses holds the statuses of the ignored Gateway resources.
-type IgnoredGatewayStatuses map[types.NamespacedName]IgnoredGatewayStatus
-
-// IgnoredGatewayStatus holds the status of an ignored Gateway resource.
-type IgnoredGatewayStatus struct {
-	ObservedGeneration int64
+	// Addresses are the addresses of the NGINX data plane bound to the Gateway.
+	Addresses []v1beta1.GatewayAddress
+	// InvalidAddresses describes the addresses from the spec of the Gateway that can't be honoured.
+	InvalidAddresses []string
 }
 
 // ListenerStatus holds the status-related information about a listener in the Gateway resource.
@@ -36,19 +52,51 @@ type ListenerStatus struct {
 	Valid bool
 	// AttachedRoutes is the number of routes attached to the listener.
 	AttachedRoutes int32
+	// SupportedKinds are the kinds of routes that can attach to the listener.
+	SupportedKinds []v1beta1.RouteGroupKind
+	// InvalidCertificateRefs describes the invalid certificateRefs of the listener, one entry per ref.
+	InvalidCertificateRefs []string
+	// CertificateWarnings describes the problems with the valid certificates of the listener.
+	CertificateWarnings []string
 }
 
-// ParentStatuses holds the statuses of parents where the key is the section name in a parentRef.
-type ParentStatuses map[string]ParentStatus
+// ParentRef identifies the parent of a route -- a listener of a Gateway.
+type ParentRef struct {
+	// Gateway is the namespaced name of the Gateway.
+	Gateway types.NamespacedName
+	// SectionName is the name of the listener of the Gateway.
+	SectionName string
+}
+
+// ParentStatuses holds the statuses of parents where the key is the Gateway and the section name in a parentRef.
+type ParentStatuses map[ParentRef]ParentStatus
 
 type HTTPRouteStatus struct {
 	ParentStatuses ParentStatuses
 }
 
-// ParentStatus holds status-related information related to how the HTTPRoute binds to a specific parentRef.
+type GRPCRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+type TLSRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+type TCPRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+type UDPRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+// ParentStatus holds status-related information related to how a route binds to a specific parentRef.
 type ParentStatus struct {
 	// Attached is true if the route attaches to the parent (listener).
 	Attached bool
+	// ConflictMsg explains why the route is not attached when another route is attached to the parent instead.
+	ConflictMsg string
 }
 
 // GatewayClassStatus holds status-related infortmation about the GatewayClass resource.
@@ -57,6 +105,8 @@ type GatewayClassStatus struct {
 	Valid bool
 	// ErrorMsg describe the error when the resource is invalid.
 	ErrorMsg string
+	// InvalidParameters shows whether the resource is invalid because of its parametersRef.
+	InvalidParameters bool
 	// ObservedGeneration is the generation of the resource that was processed.
 	ObservedGeneration int64
 }
@@ -64,58 +114,103 @@ type GatewayClassStatus struct {
 // buildStatuses builds statuses from a graph.
 func buildStatuses(graph *graph) Statuses {
 	statuses := Statuses{
-		HTTPRouteStatuses:      make(map[types.NamespacedName]HTTPRouteStatus),
-		IgnoredGatewayStatuses: make(map[types.NamespacedName]IgnoredGatewayStatus),
+		GatewayStatuses:   make(map[types.NamespacedName]GatewayStatus),
+		HTTPRouteStatuses: make(map[types.NamespacedName]HTTPRouteStatus),
+		GRPCRouteStatuses: make(map[types.NamespacedName]GRPCRouteStatus),
+		TLSRouteStatuses:  make(map[types.NamespacedName]TLSRouteStatus),
+		TCPRouteStatuses:  make(map[types.NamespacedName]TCPRouteStatus),
+		UDPRouteStatuses:  make(map[types.NamespacedName]UDPRouteStatus),
 	}
 
 	if graph.GatewayClass != nil {
 		statuses.GatewayClassStatus = &GatewayClassStatus{
 			Valid:              graph.GatewayClass.Valid,
 			ErrorMsg:           graph.GatewayClass.ErrorMsg,
+			InvalidParameters:  graph.GatewayClass.InvalidParameters,
 			ObservedGeneration: graph.GatewayClass.Source.Generation,
 		}
 	}
 
 	gcValidAndExist := graph.GatewayClass != nil && graph.GatewayClass.Valid
 
-	if graph.Gateway != nil {
+	for nsname, gw := range graph.Gateways {
 		listenerStatuses := make(map[string]ListenerStatus)
 
-		for name, l := range graph.Gateway.Listeners {
+		for name, l := range gw.Listeners {
 			listenerStatuses[name] = ListenerStatus{
-				Valid:          l.Valid && gcValidAndExist,
-				AttachedRoutes: int32(len(l.Routes)),
+				Valid:                  l.Valid && gcValidAndExist,
+				AttachedRoutes:         int32(len(l.Routes)),
+				SupportedKinds:         getSupportedKinds(l.Source.Protocol),
+				InvalidCertificateRefs: l.InvalidCertificateRefs,
+				CertificateWarnings:    l.CertificateWarnings,
 			}
 		}
 
-		statuses.GatewayStatus = &GatewayStatus{
-			NsName:           getNamespacedName(graph.Gateway.Source),
+		gs := GatewayStatus{
 			ListenerStatuses: listenerStatuses,
+			InvalidAddresses: gw.InvalidAddresses,
 		}
-	}
 
-	for nsname, gw := range graph.IgnoredGateways {
-		statuses.IgnoredGatewayStatuses[nsname] = IgnoredGatewayStatus{ObservedGeneration: gw.Generation}
+		// the addresses are only bound to a Gateway that is served by the data plane
+		if gcValidAndExist && len(gw.InvalidAddresses) == 0 {
+			gs.Addresses = graph.Addresses
+		}
+
+		statuses.GatewayStatuses[nsname] = gs
 	}
 
 	for nsname, r := range graph.Routes {
-		parentStatuses := make(map[string]ParentStatus)
+		statuses.HTTPRouteStatuses[nsname] = HTTPRouteStatus{
+			ParentStatuses: buildParentStatuses(r, gcValidAndExist),
+		}
+	}
 
-		for ref := range r.ValidSectionNameRefs {
-			parentStatuses[ref] = ParentStatus{
-				Attached: gcValidAndExist, // Attached only when GatewayClass is valid and exists
-			}
+	for nsname, r := range graph.GRPCRoutes {
+		statuses.GRPCRouteStatuses[nsname] = GRPCRouteStatus{
+			ParentStatuses: buildParentStatuses(r, gcValidAndExist),
 		}
-		for ref := range r.InvalidSectionNameRefs {
-			parentStatuses[ref] = ParentStatus{
-				Attached: false,
-			}
+	}
+
+	for nsname, r := range graph.TLSRoutes {
+		statuses.TLSRouteStatuses[nsname] = TLSRouteStatus{
+			ParentStatuses: buildParentStatuses(r, gcValidAndExist),
 		}
+	}
 
-		statuses.HTTPRouteStatuses[nsname] = HTTPRouteStatus{
-			ParentStatuses: parentStatuses,
+	for nsname, r := range graph.TCPRoutes {
+		statuses.TCPRouteStatuses[nsname] = TCPRouteStatus{
+			ParentStatuses: buildParentStatuses(r, gcValidAndExist),
+		}
+	}
+
+	for nsname, r := range graph.UDPRoutes {
+		statuses.UDPRouteStatuses[nsname] = UDPRouteStatus{
+			ParentStatuses: buildParentStatuses(r, gcValidAndExist),
 		}
 	}
 
 	return statuses
 }
+
+func buildParentStatuses(r *route, gcValidAndExist bool) ParentStatuses {
+	parentStatuses := make(map[ParentRef]ParentStatus)
+
+	for ref := range r.ValidSectionNameRefs {
+		parentStatuses[ref] = ParentStatus{
+			Attached: gcValidAndExist, // Attached only when GatewayClass is valid and exists
+		}
+	}
+	for ref := range r.InvalidSectionNameRefs {
+		parentStatuses[ref] = ParentStatus{
+			Attached: false,
+		}
+	}
+	for ref, msg := range r.ConflictedSectionNameRefs {
+		parentStatuses[ref] = ParentStatus{
+			Attached:    false,
+			ConflictMsg: msg,
+		}
+	}
+
+	return parentStatuses
+}
@@ -10,8 +10,14 @@ import (
 )
 
 func TestBuildStatuses(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
 	listeners := map[string]*listener{
 		"listener-80-1": {
+			Source: v1beta1.Listener{
+				Protocol: v1beta1.HTTPProtocolType,
+			},
 			Valid: true,
 			Routes: map[types.NamespacedName]*route{
 				{Namespace: "test", Name: "hr-1"}: {},
@@ -19,22 +25,84 @@ func TestBuildStatuses(t *testing.T) {
 		},
 	}
 
+	otherListeners := map[string]*listener{
+		"listener-8080-1": {
+			Valid:  true,
+			Routes: map[types.NamespacedName]*route{},
+		},
+	}
+
+	invalidOtherListeners := map[string]*listener{
+		"listener-8080-1": {
+			Valid:  false,
+			Routes: map[types.NamespacedName]*route{},
+		},
+	}
+
+	addresses := []v1beta1.GatewayAddress{NewGatewayAddress("10.0.0.1")}
+
 	routes := map[types.NamespacedName]*route{
 		{Namespace: "test", Name: "hr-1"}: {
-			ValidSectionNameRefs: map[string]struct{}{
-				"listener-80-1": {},
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-2"}: {},
+			},
+		},
+	}
+
+	grpcRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "gr-1"}: {
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 			},
-			InvalidSectionNameRefs: map[string]struct{}{
-				"listener-80-2": {},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+			ConflictedSectionNameRefs: map[ParentRef]string{
+				{Gateway: gwNsName, SectionName: "listener-443-2"}: "conflict",
 			},
 		},
 	}
 
+	tlsRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "tr-1"}: {
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-tls"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+		},
+	}
+
+	tcpRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "tcpr-1"}: {
+			ValidSectionNameRefs: map[ParentRef]struct{}{},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-tcp"}: {},
+			},
+		},
+		{Namespace: "test", Name: "tcpr-2"}: {
+			ValidSectionNameRefs:   map[ParentRef]struct{}{},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+			ConflictedSectionNameRefs: map[ParentRef]string{
+				{Gateway: gwNsName, SectionName: "listener-tcp-2"}: "conflict",
+			},
+		},
+	}
+
+	udpRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "ur-1"}: {
+			ValidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-udp"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{},
+		},
+	}
+
 	routesAllRefsInvalid := map[types.NamespacedName]*route{
 		{Namespace: "test", Name: "hr-1"}: {
-			InvalidSectionNameRefs: map[string]struct{}{
-				"listener-80-2": {},
-				"listener-80-1": {},
+			InvalidSectionNameRefs: map[ParentRef]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-2"}: {},
+				{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 			},
 		},
 	}
@@ -46,11 +114,10 @@ func TestBuildStatuses(t *testing.T) {
 		},
 	}
 
-	ignoredGw := &v1beta1.Gateway{
+	otherGw := &v1beta1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:  "test",
-			Name:       "ignored-gateway",
-			Generation: 1,
+			Namespace: "test",
+			Name:      "other-gateway",
 		},
 	}
 
@@ -67,85 +134,165 @@ func TestBuildStatuses(t *testing.T) {
 					},
 					Valid: true,
 				},
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1beta1.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Addresses: addresses,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
+					otherGwNsName: {
+						Source:           otherGw,
+						Listeners:        invalidOtherListeners,
+						InvalidAddresses: []string{"invalid address"},
+					},
 				},
-				Routes: routes,
+				Routes:     routes,
+				GRPCRoutes: grpcRoutes,
+				TLSRoutes:  tlsRoutes,
+				TCPRoutes:  tcpRoutes,
+				UDPRoutes:  udpRoutes,
 			},
 			expected: Statuses{
 				GatewayClassStatus: &GatewayClassStatus{
 					Valid:              true,
 					ObservedGeneration: 1,
 				},
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          true,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          true,
+								AttachedRoutes: 1,
+								SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+							},
 						},
+						Addresses: addresses,
+					},
+					otherGwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-8080-1": {
+								Valid:          false,
+								AttachedRoutes: 0,
+							},
+						},
+						InvalidAddresses: []string{"invalid address"},
 					},
-				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
 				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: true,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{
+					{Namespace: "test", Name: "gr-1"}: {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-443-1"}: {
+								Attached: true,
+							},
+							{Gateway: gwNsName, SectionName: "listener-443-2"}: {
+								Attached:    false,
+								ConflictMsg: "conflict",
+							},
+						},
+					},
+				},
+				TLSRouteStatuses: map[types.NamespacedName]TLSRouteStatus{
+					{Namespace: "test", Name: "tr-1"}: {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-tls"}: {
+								Attached: true,
+							},
+						},
+					},
+				},
+				TCPRouteStatuses: map[types.NamespacedName]TCPRouteStatus{
+					{Namespace: "test", Name: "tcpr-1"}: {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-tcp"}: {
+								Attached: false,
+							},
+						},
+					},
+					{Namespace: "test", Name: "tcpr-2"}: {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-tcp-2"}: {
+								Attached:    false,
+								ConflictMsg: "conflict",
+							},
+						},
+					},
+				},
+				UDPRouteStatuses: map[types.NamespacedName]UDPRouteStatus{
+					{Namespace: "test", Name: "ur-1"}: {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-udp"}: {
+								Attached: true,
+							},
+						},
+					},
+				},
 			},
 			msg: "normal case",
 		},
 		{
 			graph: &graph{
 				GatewayClass: nil,
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1beta1.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
+					otherGwNsName: {
+						Source:    otherGw,
+						Listeners: otherListeners,
+					},
 				},
 				Routes: routes,
 			},
 			expected: Statuses{
 				GatewayClassStatus: nil,
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          false,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          false,
+								AttachedRoutes: 1,
+								SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+							},
+						},
+					},
+					otherGwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-8080-1": {
+								Valid:          false,
+								AttachedRoutes: 0,
+							},
 						},
 					},
-				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
 				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: false,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
 			msg: "gatewayclass doesn't exist",
 		},
@@ -158,12 +305,16 @@ func TestBuildStatuses(t *testing.T) {
 					Valid:    false,
 					ErrorMsg: "error",
 				},
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1beta1.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Addresses: addresses,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
+					otherGwNsName: {
+						Source:    otherGw,
+						Listeners: otherListeners,
+					},
 				},
 				Routes: routes,
 			},
@@ -173,30 +324,41 @@ func TestBuildStatuses(t *testing.T) {
 					ErrorMsg:           "error",
 					ObservedGeneration: 1,
 				},
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          false,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          false,
+								AttachedRoutes: 1,
+								SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+							},
+						},
+					},
+					otherGwNsName: {
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-8080-1": {
+								Valid:          false,
+								AttachedRoutes: 0,
+							},
 						},
 					},
-				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
 				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: false,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
 			msg: "gatewayclass is not valid",
 		},
@@ -208,31 +370,33 @@ func TestBuildStatuses(t *testing.T) {
 					},
 					Valid: true,
 				},
-				Gateway:         nil,
-				IgnoredGateways: nil,
-				Routes:          routesAllRefsInvalid,
+				Gateways: nil,
+				Routes:   routesAllRefsInvalid,
 			},
 			expected: Statuses{
 				GatewayClassStatus: &GatewayClassStatus{
 					Valid:              true,
 					ObservedGeneration: 1,
 				},
-				GatewayStatus:          nil,
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{},
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: map[ParentRef]ParentStatus{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: false,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
-			msg: "gateway and ignored gateways don't exist",
+			msg: "gateways don't exist",
 		},
 	}
 
@@ -1,8 +1,12 @@
 package state
 
 import (
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // store contains the resources that represent the state of the Gateway.
@@ -10,11 +14,30 @@ type store struct {
 	gc         *v1beta1.GatewayClass
 	gateways   map[types.NamespacedName]*v1beta1.Gateway
 	httpRoutes map[types.NamespacedName]*v1beta1.HTTPRoute
+	grpcRoutes map[types.NamespacedName]*v1alpha2.GRPCRoute
+	tlsRoutes  map[types.NamespacedName]*v1alpha2.TLSRoute
+	tcpRoutes  map[types.NamespacedName]*v1alpha2.TCPRoute
+	udpRoutes  map[types.NamespacedName]*v1alpha2.UDPRoute
+	secrets    map[types.NamespacedName]*apiv1.Secret
+	// services are used only to detect the changes to the Services referenced by the backends.
+	// The backends are resolved using the ServiceStore.
+	services       map[types.NamespacedName]*apiv1.Service
+	gatewayConfigs map[string]*nginxgwv1alpha1.GatewayConfig
+	// dataPlaneService is the Service of the NGINX data plane. It is nil if the Service doesn't exist.
+	dataPlaneService *apiv1.Service
 }
 
 func newStore() *store {
 	return &store{
 		gateways:   make(map[types.NamespacedName]*v1beta1.Gateway),
 		httpRoutes: make(map[types.NamespacedName]*v1beta1.HTTPRoute),
+		grpcRoutes: make(map[types.NamespacedName]*v1alpha2.GRPCRoute),
+		tlsRoutes:  make(map[types.NamespacedName]*v1alpha2.TLSRoute),
+		tcpRoutes:  make(map[types.NamespacedName]*v1alpha2.TCPRoute),
+		udpRoutes:  make(map[types.NamespacedName]*v1alpha2.UDPRoute),
+		secrets:    make(map[types.NamespacedName]*apiv1.Secret),
+		services:   make(map[types.NamespacedName]*apiv1.Service),
+		// GatewayConfig resources are cluster-scoped, so they are stored by their names
+		gatewayConfigs: make(map[string]*nginxgwv1alpha1.GatewayConfig),
 	}
 }
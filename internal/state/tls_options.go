@@ -0,0 +1,216 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// tlsOptionPrefix is the prefix of the implementation-specific TLS options of NGINX Gateway.
+	// Options with other prefixes belong to other implementations and are ignored.
+	tlsOptionPrefix = "nginx.org/"
+
+	// TLSOptionProtocols is a space-separated list of the enabled TLS protocols. For example, "TLSv1.2 TLSv1.3".
+	TLSOptionProtocols v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-protocols"
+	// TLSOptionCiphers is the list of the enabled ciphers in the OpenSSL format.
+	// For example, "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256".
+	TLSOptionCiphers v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-ciphers"
+	// TLSOptionPreferServerCiphers specifies whether the server ciphers are preferred over the client ciphers.
+	// Allowed values are "true" and "false".
+	TLSOptionPreferServerCiphers v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-prefer-server-ciphers"
+	// TLSOptionSessionCache is the type and the size of the session cache. For example, "shared:SSL:10m" or "off".
+	TLSOptionSessionCache v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-session-cache"
+	// TLSOptionSessionTimeout is the time during which a client may reuse the session parameters. For example, "10m".
+	TLSOptionSessionTimeout v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-session-timeout"
+	// TLSOptionClientCertificate is the name of a Secret in the namespace of the Gateway with the CA bundle
+	// (the ca.crt key) for verifying client certificates. Setting it enables client certificate verification.
+	TLSOptionClientCertificate v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-client-certificate"
+	// TLSOptionVerifyClient is the mode of client certificate verification. Allowed values are "on" (default) and
+	// "optional".
+	TLSOptionVerifyClient v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-verify-client"
+	// TLSOptionVerifyDepth is the verification depth of the client certificate chain.
+	TLSOptionVerifyDepth v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-verify-depth"
+	// TLSOptionForwardClientCertificate specifies whether the result of the verification, the subject DN and
+	// the client certificate are forwarded to the backends as request headers. Allowed values are "true" and "false".
+	TLSOptionForwardClientCertificate v1beta1.AnnotationKey = tlsOptionPrefix + "ssl-forward-client-certificate"
+)
+
+var (
+	supportedTLSProtocols = map[string]struct{}{
+		"TLSv1":   {},
+		"TLSv1.1": {},
+		"TLSv1.2": {},
+		"TLSv1.3": {},
+	}
+
+	supportedVerifyClientModes = map[string]struct{}{
+		"on":       {},
+		"optional": {},
+	}
+
+	tlsCiphersRegexp        = regexp.MustCompile(`^[A-Za-z0-9!+@=:._-]+$`)
+	tlsSessionCacheRegexp   = regexp.MustCompile(`^(off|none|((builtin(:\d+)?|shared:\w+:\d+[kKmM]?)( |$))+)$`)
+	tlsSessionTimeoutRegexp = regexp.MustCompile(`^\d+(ms|s|m|h|d)?$`)
+)
+
+// TLSOptions holds the TLS options of an HTTPS listener.
+// The zero value of a field means the option is not set and NGINX uses its default.
+type TLSOptions struct {
+	// Protocols are the enabled TLS protocols.
+	Protocols []string
+	// Ciphers are the enabled ciphers.
+	Ciphers string
+	// PreferServerCiphers specifies whether the server ciphers are preferred over the client ciphers.
+	PreferServerCiphers *bool
+	// SessionCache is the type and the size of the session cache.
+	SessionCache string
+	// SessionTimeout is the time during which a client may reuse the session parameters.
+	SessionTimeout string
+	// ClientCertificateSecret is the name of the Secret with the CA bundle for verifying client certificates.
+	ClientCertificateSecret string
+	// ClientCertificatePath is the path to the CA bundle on disk.
+	// It is set once the Secret of ClientCertificateSecret is requested.
+	ClientCertificatePath string
+	// VerifyClient is the mode of client certificate verification.
+	VerifyClient string
+	// VerifyDepth is the verification depth of the client certificate chain.
+	VerifyDepth *int32
+	// ForwardClientCertificate specifies whether the client certificate information is forwarded to the backends.
+	ForwardClientCertificate bool
+}
+
+// parseTLSOptions parses and validates the TLS options of a listener.
+// It returns an error for an option with the NGINX Gateway prefix that is unknown or has an invalid value.
+func parseTLSOptions(options map[v1beta1.AnnotationKey]v1beta1.AnnotationValue) (TLSOptions, error) {
+	var opts TLSOptions
+
+	// sort keys for a predictable error
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := v1beta1.AnnotationKey(k)
+		value := string(options[key])
+
+		switch key {
+		case TLSOptionProtocols:
+			protocols := strings.Fields(value)
+			if len(protocols) == 0 {
+				return TLSOptions{}, fmt.Errorf("option %s must not be empty", key)
+			}
+
+			for _, p := range protocols {
+				if _, ok := supportedTLSProtocols[p]; !ok {
+					return TLSOptions{}, fmt.Errorf("option %s has unsupported protocol %q", key, p)
+				}
+			}
+
+			opts.Protocols = protocols
+		case TLSOptionCiphers:
+			if !tlsCiphersRegexp.MatchString(value) {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.Ciphers = value
+		case TLSOptionPreferServerCiphers:
+			prefer, err := strconv.ParseBool(value)
+			if err != nil {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.PreferServerCiphers = &prefer
+		case TLSOptionSessionCache:
+			if !tlsSessionCacheRegexp.MatchString(value) || strings.HasSuffix(value, " ") {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.SessionCache = value
+		case TLSOptionSessionTimeout:
+			if !tlsSessionTimeoutRegexp.MatchString(value) {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.SessionTimeout = value
+		case TLSOptionClientCertificate:
+			if value == "" {
+				return TLSOptions{}, fmt.Errorf("option %s must not be empty", key)
+			}
+
+			opts.ClientCertificateSecret = value
+		case TLSOptionVerifyClient:
+			if _, ok := supportedVerifyClientModes[value]; !ok {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.VerifyClient = value
+		case TLSOptionVerifyDepth:
+			depth, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || depth < 0 {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			d := int32(depth)
+			opts.VerifyDepth = &d
+		case TLSOptionForwardClientCertificate:
+			forward, err := strconv.ParseBool(value)
+			if err != nil {
+				return TLSOptions{}, fmt.Errorf("option %s has invalid value %q", key, value)
+			}
+
+			opts.ForwardClientCertificate = forward
+		default:
+			if strings.HasPrefix(k, tlsOptionPrefix) {
+				return TLSOptions{}, fmt.Errorf("option %s is not supported", key)
+			}
+		}
+	}
+
+	if opts.ClientCertificateSecret == "" &&
+		(opts.VerifyClient != "" || opts.VerifyDepth != nil || opts.ForwardClientCertificate) {
+		return TLSOptions{}, fmt.Errorf("client certificate verification options require option %s",
+			TLSOptionClientCertificate)
+	}
+
+	if opts.ClientCertificateSecret != "" && opts.VerifyClient == "" {
+		opts.VerifyClient = "on"
+	}
+
+	return opts, nil
+}
+
+// equalHandshakeOptions reports whether the TLS options a and b enable the same TLS protocols and ciphers.
+// The order of the protocols doesn't matter.
+func equalHandshakeOptions(a, b TLSOptions) bool {
+	if a.Ciphers != b.Ciphers {
+		return false
+	}
+
+	toSet := func(protocols []string) map[string]struct{} {
+		set := make(map[string]struct{}, len(protocols))
+		for _, p := range protocols {
+			set[p] = struct{}{}
+		}
+		return set
+	}
+
+	aProtocols, bProtocols := toSet(a.Protocols), toSet(b.Protocols)
+	if len(aProtocols) != len(bProtocols) {
+		return false
+	}
+
+	for p := range aProtocols {
+		if _, exist := bProtocols[p]; !exist {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,221 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+)
+
+func TestParseTLSOptions(t *testing.T) {
+	tests := []struct {
+		options     map[v1beta1.AnnotationKey]v1beta1.AnnotationValue
+		expected    TLSOptions
+		msg         string
+		expectedErr bool
+	}{
+		{
+			options:  nil,
+			expected: TLSOptions{},
+			msg:      "no options",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionProtocols:           "TLSv1.2  TLSv1.3",
+				TLSOptionCiphers:             "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:!aNULL",
+				TLSOptionPreferServerCiphers: "true",
+				TLSOptionSessionCache:        "builtin:1000 shared:SSL:10m",
+				TLSOptionSessionTimeout:      "10m",
+			},
+			expected: TLSOptions{
+				Protocols:           []string{"TLSv1.2", "TLSv1.3"},
+				Ciphers:             "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:!aNULL",
+				PreferServerCiphers: helpers.GetBoolPointer(true),
+				SessionCache:        "builtin:1000 shared:SSL:10m",
+				SessionTimeout:      "10m",
+			},
+			msg: "all options",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionSessionCache:           "off",
+				"example.com/some-other-option": "value",
+			},
+			expected: TLSOptions{
+				SessionCache: "off",
+			},
+			msg: "options of other implementations are ignored",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionClientCertificate: "ca",
+			},
+			expected: TLSOptions{
+				ClientCertificateSecret: "ca",
+				VerifyClient:            "on",
+			},
+			msg: "client certificate verification with defaults",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionClientCertificate:        "ca",
+				TLSOptionVerifyClient:             "optional",
+				TLSOptionVerifyDepth:              "2",
+				TLSOptionForwardClientCertificate: "true",
+			},
+			expected: TLSOptions{
+				ClientCertificateSecret:  "ca",
+				VerifyClient:             "optional",
+				VerifyDepth:              helpers.GetInt32Pointer(2),
+				ForwardClientCertificate: true,
+			},
+			msg: "client certificate verification with all options",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionVerifyClient: "on",
+			},
+			expectedErr: true,
+			msg:         "client certificate verification option without client certificate",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionClientCertificate: "ca",
+				TLSOptionVerifyClient:      "off",
+			},
+			expectedErr: true,
+			msg:         "invalid verify client",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionClientCertificate: "ca",
+				TLSOptionVerifyDepth:       "-1",
+			},
+			expectedErr: true,
+			msg:         "invalid verify depth",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionClientCertificate:        "ca",
+				TLSOptionForwardClientCertificate: "yes",
+			},
+			expectedErr: true,
+			msg:         "invalid forward client certificate",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionProtocols: "SSLv3 TLSv1.2",
+			},
+			expectedErr: true,
+			msg:         "unsupported protocol",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionProtocols: " ",
+			},
+			expectedErr: true,
+			msg:         "empty protocols",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionCiphers: "HIGH; return 200",
+			},
+			expectedErr: true,
+			msg:         "invalid ciphers",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionPreferServerCiphers: "maybe",
+			},
+			expectedErr: true,
+			msg:         "invalid prefer server ciphers",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionSessionCache: "shared:SSL",
+			},
+			expectedErr: true,
+			msg:         "invalid session cache",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				TLSOptionSessionTimeout: "10 minutes",
+			},
+			expectedErr: true,
+			msg:         "invalid session timeout",
+		},
+		{
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				"nginx.org/ssl-stapling": "on",
+			},
+			expectedErr: true,
+			msg:         "unsupported option",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := parseTLSOptions(test.options)
+
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("parseTLSOptions() returned no error for the case of %q", test.msg)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("parseTLSOptions() returned unexpected error %v for the case of %q", err, test.msg)
+		}
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("parseTLSOptions() mismatch for the case of %q (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestEqualHandshakeOptions(t *testing.T) {
+	tests := []struct {
+		a, b     TLSOptions
+		expected bool
+		msg      string
+	}{
+		{
+			a:        TLSOptions{},
+			b:        TLSOptions{SessionTimeout: "10m"},
+			expected: true,
+			msg:      "no protocols and ciphers",
+		},
+		{
+			a:        TLSOptions{Protocols: []string{"TLSv1.2", "TLSv1.3"}, Ciphers: "HIGH:!aNULL"},
+			b:        TLSOptions{Protocols: []string{"TLSv1.3", "TLSv1.2"}, Ciphers: "HIGH:!aNULL"},
+			expected: true,
+			msg:      "same protocols in a different order and same ciphers",
+		},
+		{
+			a:        TLSOptions{Protocols: []string{"TLSv1.2", "TLSv1.3"}},
+			b:        TLSOptions{Protocols: []string{"TLSv1.2"}},
+			expected: false,
+			msg:      "different protocols",
+		},
+		{
+			a:        TLSOptions{Protocols: []string{"TLSv1.2"}},
+			b:        TLSOptions{},
+			expected: false,
+			msg:      "protocols set only for one",
+		},
+		{
+			a:        TLSOptions{Ciphers: "HIGH:!aNULL"},
+			b:        TLSOptions{Ciphers: "HIGH"},
+			expected: false,
+			msg:      "different ciphers",
+		},
+	}
+
+	for _, test := range tests {
+		result := equalHandshakeOptions(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("equalHandshakeOptions() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
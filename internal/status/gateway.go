@@ -2,6 +2,7 @@ package status
 
 import (
 	"sort"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -9,16 +10,6 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
 
-const (
-	// GetawayReasonGatewayConflict indicates there are multiple Gateway resources for NGINX Gateway to choose from,
-	// and NGINX Gateway ignored the resource in question and picked another Gateway as the winner.
-	// NGINX Gateway will use this reason with GatewayConditionReady (false).
-	GetawayReasonGatewayConflict v1beta1.GatewayConditionReason = "GatewayConflict"
-
-	// GatewayMessageGatewayConflict is message that describes GetawayReasonGatewayConflict.
-	GatewayMessageGatewayConflict = "The resource is ignored due to a conflicting Gateway resource"
-)
-
 // prepareGatewayStatus prepares the status for a Gateway resource.
 // FIXME(pleshakov): Be compliant with in the Gateway API.
 // Currently, we only support simple valid/invalid status per each listener.
@@ -56,40 +47,49 @@ func prepareGatewayStatus(gatewayStatus state.GatewayStatus, transitionTime meta
 			ObservedGeneration: 123,
 			LastTransitionTime: transitionTime,
 			Reason:             string(reason),
-			Message:            "", // FIXME(pleshakov) Come up with a good message
+			// FIXME(pleshakov) Come up with a good message for the listeners without warnings
+			Message: strings.Join(s.CertificateWarnings, "; "),
+		}
+
+		conds := []metav1.Condition{cond}
+
+		// FIXME(pleshakov) Report ResolvedRefs (true) for the listeners without invalid refs.
+		if len(s.InvalidCertificateRefs) > 0 {
+			conds = append(conds, metav1.Condition{
+				Type:               string(v1beta1.ListenerConditionResolvedRefs),
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: 123,
+				LastTransitionTime: transitionTime,
+				Reason:             string(v1beta1.ListenerReasonInvalidCertificateRef),
+				Message:            strings.Join(s.InvalidCertificateRefs, "; "),
+			})
 		}
 
 		listenerStatuses = append(listenerStatuses, v1beta1.ListenerStatus{
-			Name: v1beta1.SectionName(name),
-			SupportedKinds: []v1beta1.RouteGroupKind{
-				{
-					Kind: "HTTPRoute", // FIXME(pleshakov) Set it based on the listener
-				},
-			},
+			Name:           v1beta1.SectionName(name),
+			SupportedKinds: s.SupportedKinds,
 			AttachedRoutes: s.AttachedRoutes,
-			Conditions:     []metav1.Condition{cond},
+			Conditions:     conds,
 		})
 	}
 
-	return v1beta1.GatewayStatus{
-		Listeners:  listenerStatuses,
-		Conditions: nil, // FIXME(pleshakov) Create conditions for the Gateway resource.
+	// FIXME(pleshakov) Create conditions for the Gateway resource other than the one about the invalid addresses.
+	var conds []metav1.Condition
+
+	if len(gatewayStatus.InvalidAddresses) > 0 {
+		conds = append(conds, metav1.Condition{
+			Type:               string(v1beta1.GatewayConditionReady),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: 123,
+			LastTransitionTime: transitionTime,
+			Reason:             string(v1beta1.GatewayReasonAddressNotAssigned),
+			Message:            strings.Join(gatewayStatus.InvalidAddresses, "; "),
+		})
 	}
-}
 
-// prepareIgnoredGatewayStatus prepares the status for an ignored Gateway resource.
-// TODO: is it reasonable to not set the listener statuses?
-func prepareIgnoredGatewayStatus(status state.IgnoredGatewayStatus, transitionTime metav1.Time) v1beta1.GatewayStatus {
 	return v1beta1.GatewayStatus{
-		Conditions: []metav1.Condition{
-			{
-				Type:               string(v1beta1.GatewayConditionReady),
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: status.ObservedGeneration,
-				LastTransitionTime: transitionTime,
-				Reason:             string(GetawayReasonGatewayConflict),
-				Message:            GatewayMessageGatewayConflict,
-			},
-		},
+		Addresses:  gatewayStatus.Addresses,
+		Listeners:  listenerStatuses,
+		Conditions: conds,
 	}
 }
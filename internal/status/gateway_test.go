@@ -17,10 +17,27 @@ func TestPrepareGatewayStatus(t *testing.T) {
 			"valid-listener": {
 				Valid:          true,
 				AttachedRoutes: 2,
+				SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
 			},
 			"invalid-listener": {
 				Valid:          false,
 				AttachedRoutes: 1,
+				SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "TLSRoute"}},
+			},
+			"listener-with-warnings": {
+				Valid:               true,
+				AttachedRoutes:      1,
+				SupportedKinds:      []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+				CertificateWarnings: []string{"warning 1", "warning 2"},
+			},
+			"listener-with-invalid-refs": {
+				Valid:          true,
+				AttachedRoutes: 1,
+				SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+				InvalidCertificateRefs: []string{
+					"certificateRefs[1] bad-secret: secret test/bad-secret does not exist",
+					"certificateRefs[2] config: unsupported kind ConfigMap",
+				},
 			},
 		},
 	}
@@ -33,7 +50,7 @@ func TestPrepareGatewayStatus(t *testing.T) {
 				Name: "invalid-listener",
 				SupportedKinds: []v1beta1.RouteGroupKind{
 					{
-						Kind: "HTTPRoute",
+						Kind: "TLSRoute",
 					},
 				},
 				AttachedRoutes: 1,
@@ -47,6 +64,52 @@ func TestPrepareGatewayStatus(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "listener-with-invalid-refs",
+				SupportedKinds: []v1beta1.RouteGroupKind{
+					{
+						Kind: "HTTPRoute",
+					},
+				},
+				AttachedRoutes: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(v1beta1.ListenerConditionReady),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 123,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.ListenerReasonReady),
+					},
+					{
+						Type:               string(v1beta1.ListenerConditionResolvedRefs),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 123,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.ListenerReasonInvalidCertificateRef),
+						Message: "certificateRefs[1] bad-secret: secret test/bad-secret does not exist; " +
+							"certificateRefs[2] config: unsupported kind ConfigMap",
+					},
+				},
+			},
+			{
+				Name: "listener-with-warnings",
+				SupportedKinds: []v1beta1.RouteGroupKind{
+					{
+						Kind: "HTTPRoute",
+					},
+				},
+				AttachedRoutes: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(v1beta1.ListenerConditionReady),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 123,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.ListenerReasonReady),
+						Message:            "warning 1; warning 2",
+					},
+				},
+			},
 			{
 				Name: "valid-listener",
 				SupportedKinds: []v1beta1.RouteGroupKind{
@@ -74,28 +137,51 @@ func TestPrepareGatewayStatus(t *testing.T) {
 	}
 }
 
-func TestPrepareIgnoredGatewayStatus(t *testing.T) {
-	status := state.IgnoredGatewayStatus{
-		ObservedGeneration: 1,
-	}
+func TestPrepareGatewayStatusAddresses(t *testing.T) {
+	addresses := []v1beta1.GatewayAddress{state.NewGatewayAddress("10.0.0.1")}
 
 	transitionTime := metav1.NewTime(time.Now())
 
-	expected := v1beta1.GatewayStatus{
-		Conditions: []metav1.Condition{
-			{
-				Type:               string(v1beta1.GatewayConditionReady),
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: status.ObservedGeneration,
-				LastTransitionTime: transitionTime,
-				Reason:             string(GetawayReasonGatewayConflict),
-				Message:            GatewayMessageGatewayConflict,
+	tests := []struct {
+		status   state.GatewayStatus
+		expected v1beta1.GatewayStatus
+		msg      string
+	}{
+		{
+			status: state.GatewayStatus{
+				Addresses: addresses,
+			},
+			expected: v1beta1.GatewayStatus{
+				Addresses: addresses,
+				Listeners: []v1beta1.ListenerStatus{},
 			},
+			msg: "addresses",
+		},
+		{
+			status: state.GatewayStatus{
+				InvalidAddresses: []string{"invalid address 1", "invalid address 2"},
+			},
+			expected: v1beta1.GatewayStatus{
+				Listeners: []v1beta1.ListenerStatus{},
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(v1beta1.GatewayConditionReady),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 123,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.GatewayReasonAddressNotAssigned),
+						Message:            "invalid address 1; invalid address 2",
+					},
+				},
+			},
+			msg: "invalid addresses",
 		},
 	}
 
-	result := prepareIgnoredGatewayStatus(status, transitionTime)
-	if diff := cmp.Diff(expected, result); diff != "" {
-		t.Errorf("prepareIgnoredGatewayStatus() mismatch (-want +got):\n%s", diff)
+	for _, test := range tests {
+		result := prepareGatewayStatus(test.status, transitionTime)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("prepareGatewayStatus() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
 	}
 }
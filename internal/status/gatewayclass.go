@@ -16,12 +16,18 @@ func prepareGatewayClassStatus(status state.GatewayClassStatus, transitionTime m
 		msg        string
 	)
 
+	reason := v1beta1.GatewayClassReasonAccepted
+
 	if status.Valid {
 		condStatus = metav1.ConditionTrue
 		msg = "GatewayClass has been accepted"
 	} else {
 		condStatus = metav1.ConditionFalse
 		msg = fmt.Sprintf("GatewayClass has been rejected: %s", status.ErrorMsg)
+
+		if status.InvalidParameters {
+			reason = v1beta1.GatewayClassReasonInvalidParameters
+		}
 	}
 
 	cond := metav1.Condition{
@@ -29,7 +35,7 @@ func prepareGatewayClassStatus(status state.GatewayClassStatus, transitionTime m
 		Status:             condStatus,
 		ObservedGeneration: status.ObservedGeneration,
 		LastTransitionTime: transitionTime,
-		Reason:             string(v1beta1.GatewayClassReasonAccepted),
+		Reason:             string(reason),
 		Message:            msg,
 	}
 
@@ -58,6 +58,27 @@ func TestPrepareGatewayClassStatus(t *testing.T) {
 			},
 			msg: "invalid GatewayClass",
 		},
+		{
+			status: state.GatewayClassStatus{
+				Valid:              false,
+				ErrorMsg:           "error",
+				InvalidParameters:  true,
+				ObservedGeneration: 3,
+			},
+			expected: v1beta1.GatewayClassStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 3,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.GatewayClassReasonInvalidParameters),
+						Message:            "GatewayClass has been rejected: error",
+					},
+				},
+			},
+			msg: "GatewayClass with invalid parameters",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,24 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// prepareGRPCRouteStatus prepares the status for a GRPCRoute resource.
+// It has the same limitations as prepareHTTPRouteStatus.
+func prepareGRPCRouteStatus(
+	status state.GRPCRouteStatus,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.GRPCRouteStatus {
+	parents := prepareRouteParentStatuses(status.ParentStatuses, gatewayCtlrName, transitionTime)
+
+	return v1alpha2.GRPCRouteStatus{
+		RouteStatus: v1alpha2.RouteStatus{
+			Parents: convertRouteParentStatuses(parents),
+		},
+	}
+}
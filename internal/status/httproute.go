@@ -4,7 +4,6 @@ import (
 	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
@@ -16,41 +15,69 @@ import (
 // Extend support to cover more cases.
 func prepareHTTPRouteStatus(
 	status state.HTTPRouteStatus,
-	gwNsName types.NamespacedName,
 	gatewayCtlrName string,
 	transitionTime metav1.Time,
 ) v1beta1.HTTPRouteStatus {
-	parents := make([]v1beta1.RouteParentStatus, 0, len(status.ParentStatuses))
+	return v1beta1.HTTPRouteStatus{
+		RouteStatus: v1beta1.RouteStatus{
+			Parents: prepareRouteParentStatuses(status.ParentStatuses, gatewayCtlrName, transitionTime),
+		},
+	}
+}
+
+// prepareRouteParentStatuses prepares the statuses of the parents of a route.
+// It is used for all kinds of routes.
+func prepareRouteParentStatuses(
+	parentStatuses state.ParentStatuses,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) []v1beta1.RouteParentStatus {
+	parents := make([]v1beta1.RouteParentStatus, 0, len(parentStatuses))
 
-	// FIXME(pleshakov) Maintain the order from the HTTPRoute resource
-	names := make([]string, 0, len(status.ParentStatuses))
-	for name := range status.ParentStatuses {
-		names = append(names, name)
+	// FIXME(pleshakov) Maintain the order from the route resource
+	refs := make([]state.ParentRef, 0, len(parentStatuses))
+	for ref := range parentStatuses {
+		refs = append(refs, ref)
 	}
-	sort.Strings(names)
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Gateway != refs[j].Gateway {
+			if refs[i].Gateway.Namespace != refs[j].Gateway.Namespace {
+				return refs[i].Gateway.Namespace < refs[j].Gateway.Namespace
+			}
+			return refs[i].Gateway.Name < refs[j].Gateway.Name
+		}
+		return refs[i].SectionName < refs[j].SectionName
+	})
 
-	for _, name := range names {
-		ps := status.ParentStatuses[name]
+	for _, ref := range refs {
+		ps := parentStatuses[ref]
 
 		var (
-			status metav1.ConditionStatus
-			reason string // FIXME(pleshakov) use RouteConditionReason once we upgrade to v1beta1
+			status  metav1.ConditionStatus
+			reason  string // FIXME(pleshakov) use RouteConditionReason once we upgrade to v1beta1
+			message string
 		)
 
-		if ps.Attached {
+		switch {
+		case ps.Attached:
 			status = metav1.ConditionTrue
 			reason = "Accepted" // FIXME(pleshakov): use RouteReasonAccepted once we upgrade to v1beta1
-		} else {
+		case ps.ConflictMsg != "":
+			status = metav1.ConditionFalse
+			reason = "Conflicted"
+			message = ps.ConflictMsg
+		default:
 			status = metav1.ConditionFalse
 			reason = "NotAttached" // FIXME(pleshakov): use a more specific message from the defined constants (available in v1beta1)
 		}
 
-		sectionName := name
+		ns := ref.Gateway.Namespace
+		sectionName := ref.SectionName
 
 		p := v1beta1.RouteParentStatus{
 			ParentRef: v1beta1.ParentReference{
-				Namespace:   (*v1beta1.Namespace)(&gwNsName.Namespace),
-				Name:        v1beta1.ObjectName(gwNsName.Name),
+				Namespace:   (*v1beta1.Namespace)(&ns),
+				Name:        v1beta1.ObjectName(ref.Gateway.Name),
 				SectionName: (*v1beta1.SectionName)(&sectionName),
 			},
 			ControllerName: v1beta1.GatewayController(gatewayCtlrName),
@@ -58,20 +85,16 @@ func prepareHTTPRouteStatus(
 				{
 					Type:   string(v1beta1.RouteConditionAccepted),
 					Status: status,
-					// FIXME(pleshakov) Set the observed generation to the last processed generation of the HTTPRoute resource.
+					// FIXME(pleshakov) Set the observed generation to the last processed generation of the route resource.
 					ObservedGeneration: 123,
 					LastTransitionTime: transitionTime,
 					Reason:             reason,
-					Message:            "", // FIXME(pleshakov): Figure out a good message
+					Message:            message, // FIXME(pleshakov): Figure out a good message for the other cases
 				},
 			},
 		}
 		parents = append(parents, p)
 	}
 
-	return v1beta1.HTTPRouteStatus{
-		RouteStatus: v1beta1.RouteStatus{
-			Parents: parents,
-		},
-	}
+	return parents
 }
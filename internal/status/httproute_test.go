@@ -14,18 +14,23 @@ import (
 )
 
 func TestPrepareHTTPRouteStatus(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	otherGwNsName := types.NamespacedName{Namespace: "test", Name: "other-gateway"}
+
 	status := state.HTTPRouteStatus{
-		ParentStatuses: map[string]state.ParentStatus{
-			"attached": {
+		ParentStatuses: state.ParentStatuses{
+			{Gateway: gwNsName, SectionName: "attached"}: {
 				Attached: true,
 			},
-			"not-attached": {
+			{Gateway: gwNsName, SectionName: "not-attached"}: {
 				Attached: false,
 			},
+			{Gateway: otherGwNsName, SectionName: "attached"}: {
+				Attached: true,
+			},
 		},
 	}
 
-	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
 	gatewayCtlrName := "test.example.com"
 
 	transitionTime := metav1.NewTime(time.Now())
@@ -67,11 +72,28 @@ func TestPrepareHTTPRouteStatus(t *testing.T) {
 						},
 					},
 				},
+				{
+					ParentRef: v1beta1.ParentReference{
+						Namespace:   (*v1beta1.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "other-gateway",
+						SectionName: (*v1beta1.SectionName)(helpers.GetStringPointer("attached")),
+					},
+					ControllerName: v1beta1.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
 			},
 		},
 	}
 
-	result := prepareHTTPRouteStatus(status, gwNsName, gatewayCtlrName, transitionTime)
+	result := prepareHTTPRouteStatus(status, gatewayCtlrName, transitionTime)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("prepareHTTPRouteStatus() mismatch (-want +got):\n%s", diff)
 	}
@@ -0,0 +1,102 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+func TestPrepareTCPRouteStatus(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	status := state.TCPRouteStatus{
+		ParentStatuses: state.ParentStatuses{
+			{Gateway: gwNsName, SectionName: "attached"}: {
+				Attached: true,
+			},
+			{Gateway: gwNsName, SectionName: "not-attached"}: {
+				Attached: false,
+			},
+			{Gateway: gwNsName, SectionName: "conflicted"}: {
+				Attached:    false,
+				ConflictMsg: "TCP listener is already used by TCPRoute test/tcpr-1: only one route can be attached to it",
+			},
+		},
+	}
+
+	gatewayCtlrName := "test.example.com"
+
+	transitionTime := metav1.NewTime(time.Now())
+
+	expected := v1alpha2.TCPRouteStatus{
+		RouteStatus: v1alpha2.RouteStatus{
+			Parents: []v1alpha2.RouteParentStatus{
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("attached")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1alpha2.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("conflicted")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1alpha2.RouteConditionAccepted),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Conflicted",
+							Message: "TCP listener is already used by TCPRoute test/tcpr-1: " +
+								"only one route can be attached to it",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("not-attached")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1alpha2.RouteConditionAccepted),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "NotAttached",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := prepareTCPRouteStatus(status, gatewayCtlrName, transitionTime)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("prepareTCPRouteStatus() mismatch (-want +got):\n%s", diff)
+	}
+}
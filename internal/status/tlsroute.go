@@ -0,0 +1,45 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// prepareTLSRouteStatus prepares the status for a TLSRoute resource.
+// It has the same limitations as prepareHTTPRouteStatus.
+func prepareTLSRouteStatus(
+	status state.TLSRouteStatus,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.TLSRouteStatus {
+	parents := prepareRouteParentStatuses(status.ParentStatuses, gatewayCtlrName, transitionTime)
+
+	return v1alpha2.TLSRouteStatus{
+		RouteStatus: v1alpha2.RouteStatus{
+			Parents: convertRouteParentStatuses(parents),
+		},
+	}
+}
+
+// convertRouteParentStatuses converts the parent statuses for v1alpha2 routes (like TLSRoute),
+// which use their own copies of the common types.
+func convertRouteParentStatuses(parents []v1beta1.RouteParentStatus) []v1alpha2.RouteParentStatus {
+	alphaParents := make([]v1alpha2.RouteParentStatus, 0, len(parents))
+
+	for _, p := range parents {
+		alphaParents = append(alphaParents, v1alpha2.RouteParentStatus{
+			ParentRef: v1alpha2.ParentReference{
+				Namespace:   (*v1alpha2.Namespace)(p.ParentRef.Namespace),
+				Name:        v1alpha2.ObjectName(p.ParentRef.Name),
+				SectionName: (*v1alpha2.SectionName)(p.ParentRef.SectionName),
+			},
+			ControllerName: v1alpha2.GatewayController(p.ControllerName),
+			Conditions:     p.Conditions,
+		})
+	}
+
+	return alphaParents
+}
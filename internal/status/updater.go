@@ -7,6 +7,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
@@ -93,14 +94,7 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 		})
 	}
 
-	if statuses.GatewayStatus != nil {
-		upd.update(ctx, statuses.GatewayStatus.NsName, &v1beta1.Gateway{}, func(object client.Object) {
-			gw := object.(*v1beta1.Gateway)
-			gw.Status = prepareGatewayStatus(*statuses.GatewayStatus, upd.cfg.Clock.Now())
-		})
-	}
-
-	for nsname, gs := range statuses.IgnoredGatewayStatuses {
+	for nsname, gs := range statuses.GatewayStatuses {
 		select {
 		case <-ctx.Done():
 			return
@@ -109,7 +103,7 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 
 		upd.update(ctx, nsname, &v1beta1.Gateway{}, func(object client.Object) {
 			gw := object.(*v1beta1.Gateway)
-			gw.Status = prepareIgnoredGatewayStatus(gs, upd.cfg.Clock.Now())
+			gw.Status = prepareGatewayStatus(gs, upd.cfg.Clock.Now())
 		})
 	}
 
@@ -122,8 +116,59 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 
 		upd.update(ctx, nsname, &v1beta1.HTTPRoute{}, func(object client.Object) {
 			hr := object.(*v1beta1.HTTPRoute)
-			// statuses.GatewayStatus is never nil when len(statuses.HTTPRouteStatuses) > 0
-			hr.Status = prepareHTTPRouteStatus(rs, statuses.GatewayStatus.NsName, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+			hr.Status = prepareHTTPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.GRPCRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.GRPCRoute{}, func(object client.Object) {
+			gr := object.(*v1alpha2.GRPCRoute)
+			gr.Status = prepareGRPCRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.TLSRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.TLSRoute{}, func(object client.Object) {
+			tr := object.(*v1alpha2.TLSRoute)
+			tr.Status = prepareTLSRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.TCPRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.TCPRoute{}, func(object client.Object) {
+			tr := object.(*v1alpha2.TCPRoute)
+			tr.Status = prepareTCPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.UDPRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.UDPRoute{}, func(object client.Object) {
+			ur := object.(*v1alpha2.UDPRoute)
+			ur.Status = prepareUDPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
 		})
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -35,6 +36,7 @@ var _ = Describe("Updater", func() {
 		scheme := runtime.NewScheme()
 
 		Expect(gatewayv1beta1.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1alpha2.AddToScheme(scheme)).Should(Succeed())
 
 		client = fake.NewClientBuilder().
 			WithScheme(scheme).
@@ -60,9 +62,13 @@ var _ = Describe("Updater", func() {
 
 	Describe("Process status updates", Ordered, func() {
 		var (
-			gc            *v1beta1.GatewayClass
-			gw, ignoredGw *v1beta1.Gateway
-			hr            *v1beta1.HTTPRoute
+			gc          *v1beta1.GatewayClass
+			gw, otherGw *v1beta1.Gateway
+			hr          *v1beta1.HTTPRoute
+			gr          *v1alpha2.GRPCRoute
+			tr          *v1alpha2.TLSRoute
+			tcpr        *v1alpha2.TCPRoute
+			udpr        *v1alpha2.UDPRoute
 
 			createStatuses = func(valid bool, generation int64) state.Statuses {
 				var gcErrorMsg string
@@ -76,24 +82,66 @@ var _ = Describe("Updater", func() {
 						ErrorMsg:           gcErrorMsg,
 						ObservedGeneration: generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"http": {
-								Valid:          valid,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"http": {
+									Valid:          valid,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
 							},
 						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
-						{Namespace: "test", Name: "ignored-gateway"}: {
-							ObservedGeneration: generation,
+						{Namespace: "test", Name: "other-gateway"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"http": {
+									Valid:          valid,
+									AttachedRoutes: 1,
+									SupportedKinds: []v1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+								},
+							},
 						},
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "route1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"http": {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "http"}: {
+									Attached: valid,
+								},
+							},
+						},
+					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{
+						{Namespace: "test", Name: "grpc-route1"}: {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "https"}: {
+									Attached: valid,
+								},
+							},
+						},
+					},
+					TLSRouteStatuses: map[types.NamespacedName]state.TLSRouteStatus{
+						{Namespace: "test", Name: "tls-route1"}: {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "tls"}: {
+									Attached: valid,
+								},
+							},
+						},
+					},
+					TCPRouteStatuses: map[types.NamespacedName]state.TCPRouteStatus{
+						{Namespace: "test", Name: "tcp-route1"}: {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "tcp"}: {
+									Attached: valid,
+								},
+							},
+						},
+					},
+					UDPRouteStatuses: map[types.NamespacedName]state.UDPRouteStatus{
+						{Namespace: "test", Name: "udp-route1"}: {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "udp"}: {
 									Attached: valid,
 								},
 							},
@@ -126,11 +174,11 @@ var _ = Describe("Updater", func() {
 				}
 			}
 
-			createExpectedGw = func(status metav1.ConditionStatus, reason string) *v1beta1.Gateway {
+			createExpectedGw = func(name string, status metav1.ConditionStatus, reason string) *v1beta1.Gateway {
 				return &v1beta1.Gateway{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "test",
-						Name:      "gateway",
+						Name:      name,
 					},
 					TypeMeta: metav1.TypeMeta{
 						Kind:       "Gateway",
@@ -161,31 +209,6 @@ var _ = Describe("Updater", func() {
 				}
 			}
 
-			createExpectedIgnoredGw = func() *v1beta1.Gateway {
-				return &v1beta1.Gateway{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "test",
-						Name:      "ignored-gateway",
-					},
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "Gateway",
-						APIVersion: "gateway.networking.k8s.io/v1beta1",
-					},
-					Status: v1beta1.GatewayStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:               string(v1beta1.GatewayConditionReady),
-								Status:             metav1.ConditionFalse,
-								ObservedGeneration: 1,
-								LastTransitionTime: fakeClockTime,
-								Reason:             string(status.GetawayReasonGatewayConflict),
-								Message:            status.GatewayMessageGatewayConflict,
-							},
-						},
-					},
-				}
-			}
-
 			createExpectedHR = func() *v1beta1.HTTPRoute {
 				return &v1beta1.HTTPRoute{
 					ObjectMeta: metav1.ObjectMeta{
@@ -221,6 +244,149 @@ var _ = Describe("Updater", func() {
 					},
 				}
 			}
+
+			createExpectedGR = func() *v1alpha2.GRPCRoute {
+				return &v1alpha2.GRPCRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "grpc-route1",
+					},
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "GRPCRoute",
+						APIVersion: "gateway.networking.k8s.io/v1alpha2",
+					},
+					Status: v1alpha2.GRPCRouteStatus{
+						RouteStatus: v1alpha2.RouteStatus{
+							Parents: []v1alpha2.RouteParentStatus{
+								{
+									ControllerName: v1alpha2.GatewayController(gatewayCtrlName),
+									ParentRef: v1alpha2.ParentReference{
+										Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+										Name:        "gateway",
+										SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("https")),
+									},
+									Conditions: []metav1.Condition{
+										{
+											Type:               string(v1alpha2.RouteConditionAccepted),
+											Status:             metav1.ConditionTrue,
+											ObservedGeneration: 123,
+											LastTransitionTime: fakeClockTime,
+											Reason:             "Accepted",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+			createExpectedTR = func() *v1alpha2.TLSRoute {
+				return &v1alpha2.TLSRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "tls-route1",
+					},
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "TLSRoute",
+						APIVersion: "gateway.networking.k8s.io/v1alpha2",
+					},
+					Status: v1alpha2.TLSRouteStatus{
+						RouteStatus: v1alpha2.RouteStatus{
+							Parents: []v1alpha2.RouteParentStatus{
+								{
+									ControllerName: v1alpha2.GatewayController(gatewayCtrlName),
+									ParentRef: v1alpha2.ParentReference{
+										Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+										Name:        "gateway",
+										SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("tls")),
+									},
+									Conditions: []metav1.Condition{
+										{
+											Type:               string(v1alpha2.RouteConditionAccepted),
+											Status:             metav1.ConditionTrue,
+											ObservedGeneration: 123,
+											LastTransitionTime: fakeClockTime,
+											Reason:             "Accepted",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			createExpectedTCPR = func() *v1alpha2.TCPRoute {
+				return &v1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "tcp-route1",
+					},
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "TCPRoute",
+						APIVersion: "gateway.networking.k8s.io/v1alpha2",
+					},
+					Status: v1alpha2.TCPRouteStatus{
+						RouteStatus: v1alpha2.RouteStatus{
+							Parents: []v1alpha2.RouteParentStatus{
+								{
+									ControllerName: v1alpha2.GatewayController(gatewayCtrlName),
+									ParentRef: v1alpha2.ParentReference{
+										Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+										Name:        "gateway",
+										SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("tcp")),
+									},
+									Conditions: []metav1.Condition{
+										{
+											Type:               string(v1alpha2.RouteConditionAccepted),
+											Status:             metav1.ConditionTrue,
+											ObservedGeneration: 123,
+											LastTransitionTime: fakeClockTime,
+											Reason:             "Accepted",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			createExpectedUDPR = func() *v1alpha2.UDPRoute {
+				return &v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "udp-route1",
+					},
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "UDPRoute",
+						APIVersion: "gateway.networking.k8s.io/v1alpha2",
+					},
+					Status: v1alpha2.UDPRouteStatus{
+						RouteStatus: v1alpha2.RouteStatus{
+							Parents: []v1alpha2.RouteParentStatus{
+								{
+									ControllerName: v1alpha2.GatewayController(gatewayCtrlName),
+									ParentRef: v1alpha2.ParentReference{
+										Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+										Name:        "gateway",
+										SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("udp")),
+									},
+									Conditions: []metav1.Condition{
+										{
+											Type:               string(v1alpha2.RouteConditionAccepted),
+											Status:             metav1.ConditionTrue,
+											ObservedGeneration: 123,
+											LastTransitionTime: fakeClockTime,
+											Reason:             "Accepted",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
 		)
 
 		BeforeAll(func() {
@@ -243,10 +409,10 @@ var _ = Describe("Updater", func() {
 					APIVersion: "gateway.networking.k8s.io/v1beta1",
 				},
 			}
-			ignoredGw = &v1beta1.Gateway{
+			otherGw = &v1beta1.Gateway{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test",
-					Name:      "ignored-gateway",
+					Name:      "other-gateway",
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Gateway",
@@ -263,13 +429,57 @@ var _ = Describe("Updater", func() {
 					APIVersion: "gateway.networking.k8s.io/v1beta1",
 				},
 			}
+			gr = &v1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "grpc-route1",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GRPCRoute",
+					APIVersion: "gateway.networking.k8s.io/v1alpha2",
+				},
+			}
+			tr = &v1alpha2.TLSRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "tls-route1",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "TLSRoute",
+					APIVersion: "gateway.networking.k8s.io/v1alpha2",
+				},
+			}
+			tcpr = &v1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "tcp-route1",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "TCPRoute",
+					APIVersion: "gateway.networking.k8s.io/v1alpha2",
+				},
+			}
+			udpr = &v1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "udp-route1",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "UDPRoute",
+					APIVersion: "gateway.networking.k8s.io/v1alpha2",
+				},
+			}
 		})
 
 		It("should create resources in the API server", func() {
 			Expect(client.Create(context.Background(), gc)).Should(Succeed())
 			Expect(client.Create(context.Background(), gw)).Should(Succeed())
-			Expect(client.Create(context.Background(), ignoredGw)).Should(Succeed())
+			Expect(client.Create(context.Background(), otherGw)).Should(Succeed())
 			Expect(client.Create(context.Background(), hr)).Should(Succeed())
+			Expect(client.Create(context.Background(), gr)).Should(Succeed())
+			Expect(client.Create(context.Background(), tr)).Should(Succeed())
+			Expect(client.Create(context.Background(), tcpr)).Should(Succeed())
+			Expect(client.Create(context.Background(), udpr)).Should(Succeed())
 		})
 
 		It("should update statuses", func() {
@@ -288,40 +498,80 @@ var _ = Describe("Updater", func() {
 			Expect(helpers.Diff(expectedGc, latestGc)).To(BeEmpty())
 		})
 
-		It("should have the updated status of Gateway in the API server", func() {
-			latestGw := &v1beta1.Gateway{}
-			expectedGw := createExpectedGw(metav1.ConditionTrue, string(v1beta1.ListenerReasonReady))
+		DescribeTable("should have the updated status of Gateways in the API server",
+			func(name string) {
+				latestGw := &v1beta1.Gateway{}
+				expectedGw := createExpectedGw(name, metav1.ConditionTrue, string(v1beta1.ListenerReasonReady))
+
+				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: name}, latestGw)
+				Expect(err).Should(Not(HaveOccurred()))
+
+				expectedGw.ResourceVersion = latestGw.ResourceVersion
+
+				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+			},
+			Entry("gateway", "gateway"),
+			Entry("other gateway", "other-gateway"),
+		)
+
+		It("should have the updated status of HTTPRoute in the API server", func() {
+			latestHR := &v1beta1.HTTPRoute{}
+			expectedHR := createExpectedHR()
+
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "route1"}, latestHR)
+			Expect(err).Should(Not(HaveOccurred()))
+
+			expectedHR.ResourceVersion = latestHR.ResourceVersion
+
+			Expect(helpers.Diff(expectedHR, latestHR)).To(BeEmpty())
+		})
+
+		It("should have the updated status of GRPCRoute in the API server", func() {
+			latestGR := &v1alpha2.GRPCRoute{}
+			expectedGR := createExpectedGR()
 
-			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "gateway"}, latestGw)
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "grpc-route1"}, latestGR)
 			Expect(err).Should(Not(HaveOccurred()))
 
-			expectedGw.ResourceVersion = latestGw.ResourceVersion
+			expectedGR.ResourceVersion = latestGR.ResourceVersion
 
-			Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+			Expect(helpers.Diff(expectedGR, latestGR)).To(BeEmpty())
 		})
 
-		It("should have the updated status of ignored Gateway in the API server", func() {
-			latestGw := &v1beta1.Gateway{}
-			expectedGw := createExpectedIgnoredGw()
+		It("should have the updated status of TLSRoute in the API server", func() {
+			latestTR := &v1alpha2.TLSRoute{}
+			expectedTR := createExpectedTR()
 
-			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "ignored-gateway"}, latestGw)
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "tls-route1"}, latestTR)
 			Expect(err).Should(Not(HaveOccurred()))
 
-			expectedGw.ResourceVersion = latestGw.ResourceVersion
+			expectedTR.ResourceVersion = latestTR.ResourceVersion
 
-			Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+			Expect(helpers.Diff(expectedTR, latestTR)).To(BeEmpty())
 		})
 
-		It("should have the updated status of HTTPRoute in the API server", func() {
-			latestHR := &v1beta1.HTTPRoute{}
-			expectedHR := createExpectedHR()
+		It("should have the updated status of TCPRoute in the API server", func() {
+			latestTCPR := &v1alpha2.TCPRoute{}
+			expectedTCPR := createExpectedTCPR()
 
-			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "route1"}, latestHR)
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "tcp-route1"}, latestTCPR)
 			Expect(err).Should(Not(HaveOccurred()))
 
-			expectedHR.ResourceVersion = latestHR.ResourceVersion
+			expectedTCPR.ResourceVersion = latestTCPR.ResourceVersion
 
-			Expect(helpers.Diff(expectedHR, latestHR)).To(BeEmpty())
+			Expect(helpers.Diff(expectedTCPR, latestTCPR)).To(BeEmpty())
+		})
+
+		It("should have the updated status of UDPRoute in the API server", func() {
+			latestUDPR := &v1alpha2.UDPRoute{}
+			expectedUDPR := createExpectedUDPR()
+
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "udp-route1"}, latestUDPR)
+			Expect(err).Should(Not(HaveOccurred()))
+
+			expectedUDPR.ResourceVersion = latestUDPR.ResourceVersion
+
+			Expect(helpers.Diff(expectedUDPR, latestUDPR)).To(BeEmpty())
 		})
 
 		It("should update statuses with canceled context - function normally returns", func() {
@@ -343,30 +593,22 @@ var _ = Describe("Updater", func() {
 				Expect(helpers.Diff(expectedGc, latestGc)).To(BeEmpty())
 			})
 
-			It("should have the updated status of Gateway in the API server", func() {
-				latestGw := &v1beta1.Gateway{}
-				expectedGw := createExpectedGw(metav1.ConditionFalse, string(v1beta1.ListenerReasonInvalid))
-
-				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "gateway"}, latestGw)
-				Expect(err).Should(Not(HaveOccurred()))
+			DescribeTable("should not have the updated status of Gateways in the API server",
+				func(name string) {
+					latestGw := &v1beta1.Gateway{}
+					expectedGw := createExpectedGw(name, metav1.ConditionTrue, string(v1beta1.ListenerReasonReady))
 
-				expectedGw.ResourceVersion = latestGw.ResourceVersion
+					err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: name}, latestGw)
+					Expect(err).Should(Not(HaveOccurred()))
 
-				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
-			})
+					expectedGw.ResourceVersion = latestGw.ResourceVersion
 
-			It("should not have the updated status of ignored Gateway in the API server", func() {
-				latestGw := &v1beta1.Gateway{}
-				expectedGw := createExpectedIgnoredGw()
-
-				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "ignored-gateway"}, latestGw)
-				Expect(err).Should(Not(HaveOccurred()))
-
-				expectedGw.ResourceVersion = latestGw.ResourceVersion
-
-				// if the status was updated, we would see a different ObservedGeneration
-				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
-			})
+					// if the status was updated, we would see the listener invalid (Ready = false)
+					Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+				},
+				Entry("gateway", "gateway"),
+				Entry("other gateway", "other-gateway"),
+			)
 
 			It("should not have the updated status of HTTPRoute in the API server", func() {
 				latestHR := &v1beta1.HTTPRoute{}
@@ -380,6 +622,19 @@ var _ = Describe("Updater", func() {
 				// if the status was updated, we would see the route rejected (Accepted = false)
 				Expect(helpers.Diff(expectedHR, latestHR)).To(BeEmpty())
 			})
+
+			It("should not have the updated status of GRPCRoute in the API server", func() {
+				latestGR := &v1alpha2.GRPCRoute{}
+				expectedGR := createExpectedGR()
+
+				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "grpc-route1"}, latestGR)
+				Expect(err).Should(Not(HaveOccurred()))
+
+				expectedGR.ResourceVersion = latestGR.ResourceVersion
+
+				// if the status was updated, we would see the route rejected (Accepted = false)
+				Expect(helpers.Diff(expectedGR, latestGR)).To(BeEmpty())
+			})
 		})
 	})
 })
@@ -19,6 +19,7 @@ type GatewayConfig struct {
 type GatewayConfigSpec struct {
 	Worker *Worker `json:"worker,omitempty"`
 	HTTP   *HTTP   `json:"http,omitempty"`
+	Stream *Stream `json:"stream,omitempty"`
 }
 
 type Worker struct {
@@ -26,11 +27,50 @@ type Worker struct {
 }
 
 type HTTP struct {
+	// AccessLogs configures the access logs of the HTTP traffic. The NGINX default access log is used when not set.
 	AccessLogs []AccessLog `json:"accessLogs,omitempty"`
+	// ProxyReadTimeout is the timeout for reading a response from a backend. It also limits how long a proxied
+	// long-lived connection, like a WebSocket, can stay idle. The NGINX default of 60s is used when not set.
+	ProxyReadTimeout *metav1.Duration `json:"proxyReadTimeout,omitempty"`
+	// ProxySendTimeout is the timeout for transmitting a request to a backend.
+	// The NGINX default of 60s is used when not set.
+	ProxySendTimeout *metav1.Duration `json:"proxySendTimeout,omitempty"`
+	// HTTP2 enables HTTP/2 for HTTPS listeners. HTTP/2 is enabled when not set.
+	HTTP2 *bool `json:"http2,omitempty"`
+	// ConfigHashHeader adds the X-Gateway-Config-Hash header with the hash of the NGINX configuration to all
+	// responses. It helps to find out which configuration served a request. Disabled when not set.
+	ConfigHashHeader *bool `json:"configHashHeader,omitempty"`
 }
 
+type Stream struct {
+	UDP *UDP `json:"udp,omitempty"`
+}
+
+type UDP struct {
+	// ProxyResponses is the number of datagrams expected from a backend in response to a client datagram.
+	// Zero means no response is expected, which suits protocols like syslog.
+	// When not set, NGINX waits for responses until ProxyTimeout expires.
+	ProxyResponses *int32 `json:"proxyResponses,omitempty"`
+	// ProxyTimeout is the timeout between two successive datagrams, after which the UDP session is closed.
+	// The NGINX default of 10m is used when not set.
+	ProxyTimeout *metav1.Duration `json:"proxyTimeout,omitempty"`
+}
+
+// Built-in formats of an access log.
+const (
+	// AccessLogFormatCombined is the NGINX predefined combined format.
+	AccessLogFormatCombined = "combined"
+	// AccessLogFormatJSON is a JSON format, which includes the request, the response,
+	// the HTTPRoute and the backend of the request.
+	AccessLogFormatJSON = "json"
+)
+
 type AccessLog struct {
-	Format      string `json:"format"`
+	// Format is either the name of a built-in format (combined or json) or an NGINX log format string,
+	// which can include NGINX variables. The format string must not include single quotes and backslashes.
+	Format string `json:"format"`
+	// Destination is stdout, stderr, an absolute path of a file, or a syslog server in the form
+	// syslog:server=ADDRESS[,PARAMETERS].
 	Destination string `json:"destination"`
 }
 
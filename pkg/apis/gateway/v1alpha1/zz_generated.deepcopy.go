@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -98,6 +99,11 @@ func (in *GatewayConfigSpec) DeepCopyInto(out *GatewayConfigSpec) {
 		*out = new(HTTP)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(Stream)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -119,6 +125,26 @@ func (in *HTTP) DeepCopyInto(out *HTTP) {
 		*out = make([]AccessLog, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProxyReadTimeout != nil {
+		in, out := &in.ProxyReadTimeout, &out.ProxyReadTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProxySendTimeout != nil {
+		in, out := &in.ProxySendTimeout, &out.ProxySendTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HTTP2 != nil {
+		in, out := &in.HTTP2, &out.HTTP2
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigHashHeader != nil {
+		in, out := &in.ConfigHashHeader, &out.ConfigHashHeader
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -132,6 +158,53 @@ func (in *HTTP) DeepCopy() *HTTP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Stream) DeepCopyInto(out *Stream) {
+	*out = *in
+	if in.UDP != nil {
+		in, out := &in.UDP, &out.UDP
+		*out = new(UDP)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stream.
+func (in *Stream) DeepCopy() *Stream {
+	if in == nil {
+		return nil
+	}
+	out := new(Stream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDP) DeepCopyInto(out *UDP) {
+	*out = *in
+	if in.ProxyResponses != nil {
+		in, out := &in.ProxyResponses, &out.ProxyResponses
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProxyTimeout != nil {
+		in, out := &in.ProxyTimeout, &out.ProxyTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDP.
+func (in *UDP) DeepCopy() *UDP {
+	if in == nil {
+		return nil
+	}
+	out := new(UDP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Worker) DeepCopyInto(out *Worker) {
 	*out = *in
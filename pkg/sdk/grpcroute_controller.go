@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctlr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type grpcRouteReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	impl   GRPCRouteImpl
+}
+
+// RegisterGRPCRouteController registers the GRPCRouteController in the manager.
+func RegisterGRPCRouteController(mgr manager.Manager, impl GRPCRouteImpl) error {
+	r := &grpcRouteReconciler{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		impl:   impl,
+	}
+
+	return ctlr.NewControllerManagedBy(mgr).
+		For(&v1alpha2.GRPCRoute{}).
+		Complete(r)
+}
+
+func (r *grpcRouteReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx).WithValues("grpcRoute", req.NamespacedName)
+
+	log.V(3).Info("Reconciling GRPCRoute")
+
+	found := true
+	var gr v1alpha2.GRPCRoute
+	err := r.Get(ctx, req.NamespacedName, &gr)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get GRPCRoute")
+			return reconcile.Result{}, err
+		}
+		found = false
+	}
+
+	if !found {
+		log.V(3).Info("Removing GRPCRoute")
+
+		r.impl.Remove(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	log.V(3).Info("Upserting GRPCRoute")
+
+	r.impl.Upsert(&gr)
+	return reconcile.Result{}, nil
+}
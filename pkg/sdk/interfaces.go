@@ -3,6 +3,7 @@ package sdk
 import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
@@ -29,6 +30,26 @@ type HTTPRouteImpl interface {
 	Remove(types.NamespacedName)
 }
 
+type GRPCRouteImpl interface {
+	Upsert(gr *v1alpha2.GRPCRoute)
+	Remove(types.NamespacedName)
+}
+
+type TLSRouteImpl interface {
+	Upsert(tr *v1alpha2.TLSRoute)
+	Remove(types.NamespacedName)
+}
+
+type TCPRouteImpl interface {
+	Upsert(tr *v1alpha2.TCPRoute)
+	Remove(types.NamespacedName)
+}
+
+type UDPRouteImpl interface {
+	Upsert(ur *v1alpha2.UDPRoute)
+	Remove(types.NamespacedName)
+}
+
 type ServiceImpl interface {
 	Upsert(svc *apiv1.Service)
 	Remove(nsname types.NamespacedName)